@@ -42,6 +42,11 @@ func mockGetGQLClient(_ context.Context) (*githubv4.Client, error) {
 	return githubv4.NewClient(nil), nil
 }
 
+// mockGetGQLRawClient returns a mock raw GraphQL client for documentation generation
+func mockGetGQLRawClient(_ context.Context) (*github.GQLRawClient, error) {
+	return nil, nil
+}
+
 // mockGetRawClient returns a mock raw client for documentation generation
 func mockGetRawClient(_ context.Context) (*raw.Client, error) {
 	return nil, nil
@@ -64,7 +69,7 @@ func generateReadmeDocs(readmePath string) error {
 	t, _ := translations.TranslationHelper()
 
 	// Create toolset group with mock clients
-	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, t, 5000)
+	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetGQLRawClient, mockGetRawClient, t, 5000, nil, nil)
 
 	// Generate toolsets documentation
 	toolsetsDoc := generateToolsetsDoc(tsg)
@@ -143,7 +148,11 @@ func generateToolsetsDoc(tsg *toolsets.ToolsetGroup) string {
 
 	for _, name := range toolsetNames {
 		toolset := tsg.Toolsets[name]
-		lines = append(lines, fmt.Sprintf("| `%s` | %s |", name, toolset.Description))
+		description := toolset.Description
+		if toolset.OptIn {
+			description = "**Opt-in**: " + description
+		}
+		lines = append(lines, fmt.Sprintf("| `%s` | %s |", name, description))
 	}
 
 	return strings.Join(lines, "\n")
@@ -205,6 +214,10 @@ func formatToolsetName(name string) string {
 		return "Secret Protection"
 	case "orgs":
 		return "Organizations"
+	case "graphql":
+		return "GraphQL"
+	case "rest":
+		return "REST"
 	default:
 		// Fallback: capitalize first letter and replace underscores with spaces
 		parts := strings.Split(name, "_")
@@ -302,7 +315,7 @@ func generateRemoteToolsetsDoc() string {
 	t, _ := translations.TranslationHelper()
 
 	// Create toolset group with mock clients
-	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, t, 5000)
+	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetGQLRawClient, mockGetRawClient, t, 5000, nil, nil)
 
 	// Generate table header
 	buf.WriteString("| Name           | Description                                      | API URL                                               | 1-Click Install (VS Code)                                                                                                                                                                                                 | Read-only Link                                                                                                 | 1-Click Read-only Install (VS Code)                                                                                                                                                                                                 |\n")
@@ -45,6 +45,11 @@ var (
 				return fmt.Errorf("failed to unmarshal toolsets: %w", err)
 			}
 
+			var restAllowlist []string
+			if err := viper.UnmarshalKey("rest_allowlist", &restAllowlist); err != nil {
+				return fmt.Errorf("failed to unmarshal rest_allowlist: %w", err)
+			}
+
 			stdioServerConfig := ghmcp.StdioServerConfig{
 				Version:              version,
 				Host:                 viper.GetString("host"),
@@ -56,6 +61,10 @@ var (
 				EnableCommandLogging: viper.GetBool("enable-command-logging"),
 				LogFilePath:          viper.GetString("log-file"),
 				ContentWindowSize:    viper.GetInt("content-window-size"),
+				InferRepo:            viper.GetBool("infer-repo"),
+				OutputFormat:         viper.GetString("output-format"),
+				MaxResponseTokens:    viper.GetInt("max-response-tokens"),
+				RESTAllowlist:        restAllowlist,
 			}
 			return ghmcp.RunStdioServer(stdioServerConfig)
 		},
@@ -77,6 +86,10 @@ func init() {
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
 	rootCmd.PersistentFlags().Int("content-window-size", 5000, "Specify the content window size")
+	rootCmd.PersistentFlags().Bool("infer-repo", false, "Default owner and repo to the GitHub repository of the workspace's git remote when omitted")
+	rootCmd.PersistentFlags().String("output-format", "json", "Default format of tool results: json, compact, yaml, markdown or columnar")
+	rootCmd.PersistentFlags().Int("max-response-tokens", github.DefaultMaxResponseTokens, "Approximate size limit of tool results in tokens, larger results are truncated (0 disables the limit)")
+	rootCmd.PersistentFlags().StringSlice("rest-allowlist", nil, "Comma separated \"METHOD /path\" patterns the github_rest_request tool may send besides GET requests, e.g. \"POST /repos/*/*/labels\"")
 
 	// Bind flag to viper
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
@@ -87,6 +100,10 @@ func init() {
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
 	_ = viper.BindPFlag("content-window-size", rootCmd.PersistentFlags().Lookup("content-window-size"))
+	_ = viper.BindPFlag("infer-repo", rootCmd.PersistentFlags().Lookup("infer-repo"))
+	_ = viper.BindPFlag("output-format", rootCmd.PersistentFlags().Lookup("output-format"))
+	_ = viper.BindPFlag("max-response-tokens", rootCmd.PersistentFlags().Lookup("max-response-tokens"))
+	_ = viper.BindPFlag("rest_allowlist", rootCmd.PersistentFlags().Lookup("rest-allowlist"))
 
 	// Add subcommands
 	rootCmd.AddCommand(stdioCmd)
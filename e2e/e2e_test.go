@@ -367,14 +367,17 @@ func TestTags(t *testing.T) {
 	textContent, ok = resp.Content[0].(mcp.TextContent)
 	require.True(t, ok, "expected content to be of type TextContent")
 
-	var trimmedTags []struct {
-		Name   string `json:"name"`
-		Commit struct {
-			SHA string `json:"sha"`
-		} `json:"commit"`
-	}
-	err = json.Unmarshal([]byte(textContent.Text), &trimmedTags)
+	var listTagsResult struct {
+		Items []struct {
+			Name   string `json:"name"`
+			Commit struct {
+				SHA string `json:"sha"`
+			} `json:"commit"`
+		} `json:"items"`
+	}
+	err = json.Unmarshal([]byte(textContent.Text), &listTagsResult)
 	require.NoError(t, err, "expected to unmarshal text content successfully")
+	trimmedTags := listTagsResult.Items
 
 	require.Len(t, trimmedTags, 1, "expected to find one tag")
 	require.Equal(t, "v0.0.1", trimmedTags[0].Name, "expected tag name to match")
@@ -549,18 +552,21 @@ func TestFileDeletion(t *testing.T) {
 	textContent, ok = resp.Content[0].(mcp.TextContent)
 	require.True(t, ok, "expected content to be of type TextContent")
 
-	var trimmedListCommitsText []struct {
-		SHA    string `json:"sha"`
-		Commit struct {
-			Message string `json:"message"`
-		}
-		Files []struct {
-			Filename  string `json:"filename"`
-			Deletions int    `json:"deletions"`
-		}
-	}
-	err = json.Unmarshal([]byte(textContent.Text), &trimmedListCommitsText)
+	var listCommitsResult struct {
+		Items []struct {
+			SHA    string `json:"sha"`
+			Commit struct {
+				Message string `json:"message"`
+			}
+			Files []struct {
+				Filename  string `json:"filename"`
+				Deletions int    `json:"deletions"`
+			}
+		} `json:"items"`
+	}
+	err = json.Unmarshal([]byte(textContent.Text), &listCommitsResult)
 	require.NoError(t, err, "expected to unmarshal text content successfully")
+	trimmedListCommitsText := listCommitsResult.Items
 	require.GreaterOrEqual(t, len(trimmedListCommitsText), 1, "expected to find at least one commit")
 
 	deletionCommit := trimmedListCommitsText[0]
@@ -741,18 +747,21 @@ func TestDirectoryDeletion(t *testing.T) {
 	textContent, ok = resp.Content[0].(mcp.TextContent)
 	require.True(t, ok, "expected content to be of type TextContent")
 
-	var trimmedListCommitsText []struct {
-		SHA    string `json:"sha"`
-		Commit struct {
-			Message string `json:"message"`
-		}
-		Files []struct {
-			Filename  string `json:"filename"`
-			Deletions int    `json:"deletions"`
-		} `json:"files"`
-	}
-	err = json.Unmarshal([]byte(textContent.Text), &trimmedListCommitsText)
+	var listCommitsResult struct {
+		Items []struct {
+			SHA    string `json:"sha"`
+			Commit struct {
+				Message string `json:"message"`
+			}
+			Files []struct {
+				Filename  string `json:"filename"`
+				Deletions int    `json:"deletions"`
+			} `json:"files"`
+		} `json:"items"`
+	}
+	err = json.Unmarshal([]byte(textContent.Text), &listCommitsResult)
 	require.NoError(t, err, "expected to unmarshal text content successfully")
+	trimmedListCommitsText := listCommitsResult.Items
 	require.GreaterOrEqual(t, len(trimmedListCommitsText), 1, "expected to find at least one commit")
 
 	deletionCommit := trimmedListCommitsText[0]
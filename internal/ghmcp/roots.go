@@ -0,0 +1,148 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// mcp-go can't send requests to clients other than sampling yet, so the stdio server asks for the
+// workspace roots itself, and takes the responses out of the messages read from the client.
+const (
+	methodInitialize = "initialize"
+	methodListRoots  = "roots/list"
+	// rootsRequestIDPrefix marks the IDs of our roots/list requests, to tell their responses apart
+	rootsRequestIDPrefix = "github-mcp-server/roots/"
+	// rootsRequestTimeout is how long the client has to answer a roots/list request
+	rootsRequestTimeout = 5 * time.Second
+)
+
+// errRootsNotSupported is returned when the client didn't declare the roots capability.
+var errRootsNotSupported = errors.New("the client does not support roots")
+
+// clientRoots lists the workspace roots of the client on stdio.
+type clientRoots struct {
+	out io.Writer
+
+	mu        sync.Mutex
+	supported bool
+	nextID    int
+	pending   map[string]chan rootsResponse
+}
+
+type rootsResponse struct {
+	roots []string
+	err   error
+}
+
+func newClientRoots(out io.Writer) *clientRoots {
+	return &clientRoots{out: out, pending: make(map[string]chan rootsResponse)}
+}
+
+// clientMessage holds the fields of a JSON-RPC message needed to handle roots.
+type clientMessage struct {
+	ID     any             `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// handleMessage notes whether the client supports roots from its initialize request, and takes
+// the responses to our roots/list requests. It reports whether it took line.
+func (c *clientRoots) handleMessage(line []byte) bool {
+	var msg clientMessage
+	if err := json.Unmarshal(line, &msg); err != nil {
+		return false
+	}
+
+	if msg.Method == methodInitialize {
+		var params mcp.InitializeParams
+		if err := json.Unmarshal(msg.Params, &params); err == nil {
+			c.mu.Lock()
+			c.supported = params.Capabilities.Roots != nil
+			c.mu.Unlock()
+		}
+		return false
+	}
+
+	id, ok := msg.ID.(string)
+	if msg.Method != "" || !ok || !strings.HasPrefix(id, rootsRequestIDPrefix) {
+		return false
+	}
+	c.mu.Lock()
+	ch, ok := c.pending[id]
+	delete(c.pending, id)
+	c.mu.Unlock()
+	if !ok {
+		// The request timed out already
+		return true
+	}
+
+	var response rootsResponse
+	if msg.Error != nil {
+		response.err = fmt.Errorf("failed to list roots: %s", msg.Error.Message)
+	} else {
+		var result mcp.ListRootsResult
+		if err := json.Unmarshal(msg.Result, &result); err != nil {
+			response.err = fmt.Errorf("failed to parse roots: %w", err)
+		}
+		for _, root := range result.Roots {
+			response.roots = append(response.roots, root.URI)
+		}
+	}
+	ch <- response
+	return true
+}
+
+// List asks the client for its workspace roots, as file:// URIs.
+func (c *clientRoots) List(ctx context.Context) ([]string, error) {
+	c.mu.Lock()
+	if !c.supported {
+		c.mu.Unlock()
+		return nil, errRootsNotSupported
+	}
+	c.nextID++
+	id := fmt.Sprintf("%s%d", rootsRequestIDPrefix, c.nextID)
+	ch := make(chan rootsResponse, 1)
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	cancel := func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}
+
+	data, err := json.Marshal(map[string]any{"jsonrpc": mcp.JSONRPC_VERSION, "id": id, "method": methodListRoots})
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to marshal roots request: %w", err)
+	}
+	if _, err := fmt.Fprintf(c.out, "%s\n", data); err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to send roots request: %w", err)
+	}
+
+	timer := time.NewTimer(rootsRequestTimeout)
+	defer timer.Stop()
+	select {
+	case response := <-ch:
+		return response.roots, response.err
+	case <-timer.C:
+		cancel()
+		return nil, errors.New("the client did not answer the roots request in time")
+	case <-ctx.Done():
+		cancel()
+		return nil, ctx.Err()
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 
@@ -50,18 +51,61 @@ type MCPServerConfig struct {
 
 	// Content window size
 	ContentWindowSize int
+
+	// InferRepo indicates if owner and repo should default to the repository of the workspace when omitted
+	InferRepo bool
+
+	// ClientRoots asks the client for its workspace roots, for transports that can. The working
+	// directory is the workspace otherwise.
+	ClientRoots github.RootsFn
+
+	// OutputFormat is the format tool results are returned in when a call doesn't ask for one, defaults to json
+	OutputFormat string
+
+	// MaxResponseTokens limits the size of tool results in approximate tokens, larger results are truncated.
+	// Zero disables the limit.
+	MaxResponseTokens int
+
+	// RESTAllowlist lists the "METHOD /path" patterns github_rest_request may send other than GET requests
+	RESTAllowlist []string
+
+	// Logger receives the server's diagnostics. Records logged while handling a request
+	// are also sent to the client as notifications/message.
+	Logger *slog.Logger
 }
 
 const stdioServerLogPrefix = "stdioserver"
 
 func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
+	ghServer, _, err := newMCPServer(cfg, false)
+	return ghServer, err
+}
+
+// newMCPServer creates the MCP server along with the resource subscriptions of its session,
+// which transports that support subscriptions need to run and route requests to. The resources
+// subscribe capability is only advertised when the transport does, as subscribe says.
+func newMCPServer(cfg MCPServerConfig, subscribe bool) (*server.MCPServer, *github.ResourceSubscriptions, error) {
 	apiHost, err := parseAPIHost(cfg.Host)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse API host: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse API host: %w", err)
+	}
+
+	// Send diagnostics to the client of the request being handled, as well as to the configured logger
+	var logHandler slog.Handler = mcplog.NewClientHandler()
+	if cfg.Logger != nil {
+		logHandler = mcplog.NewMultiHandler(cfg.Logger.Handler(), logHandler)
 	}
+	logger := slog.New(logHandler)
 
 	// Construct our REST client
-	restClient := gogithub.NewClient(nil).WithAuthToken(cfg.Token)
+	restClient := gogithub.NewClient(&http.Client{
+		Transport: &github.PageLinksTransport{
+			Transport: &apiLoggingTransport{
+				transport: http.DefaultTransport,
+				logger:    logger,
+			},
+		},
+	}).WithAuthToken(cfg.Token)
 	restClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", cfg.Version)
 	restClient.BaseURL = apiHost.baseRESTURL
 	restClient.UploadURL = apiHost.uploadURL
@@ -71,8 +115,11 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	// did the necessary API host parsing so that github.com will return the correct URL anyway.
 	gqlHTTPClient := &http.Client{
 		Transport: &bearerAuthTransport{
-			transport: http.DefaultTransport,
-			token:     cfg.Token,
+			transport: &apiLoggingTransport{
+				transport: http.DefaultTransport,
+				logger:    logger,
+			},
+			token: cfg.Token,
 		},
 	} // We're going to wrap the Transport later in beforeInit
 	gqlClient := githubv4.NewEnterpriseClient(apiHost.graphqlURL.String(), gqlHTTPClient)
@@ -105,6 +152,26 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		},
 	}
 
+	// Report the GitHub API errors a tool call ran into, even if the tool handled them gracefully
+	hooks.AddAfterCallTool(func(ctx context.Context, _ any, message *mcp.CallToolRequest, _ *mcp.CallToolResult) {
+		apiErrors, _ := errors.GetGitHubAPIErrors(ctx)
+		for _, apiErr := range apiErrors {
+			attrs := []any{"tool", message.Params.Name, "error", apiErr.Err}
+			if apiErr.Response != nil {
+				attrs = append(attrs, "status", apiErr.Response.StatusCode)
+			}
+			logger.WarnContext(ctx, apiErr.Message, attrs...)
+		}
+		gqlErrors, _ := errors.GetGitHubGraphQLErrors(ctx)
+		for _, gqlErr := range gqlErrors {
+			logger.WarnContext(ctx, gqlErr.Message, "tool", message.Params.Name, "error", gqlErr.Err)
+		}
+	})
+
+	// Track running tool calls so that clients can cancel them
+	inFlight := github.NewInFlightRequests()
+	hooks.AddBeforeCallTool(inFlight.BeforeCallTool)
+
 	enabledToolsets := cfg.EnabledToolsets
 	if cfg.DynamicToolsets {
 		// filter "all" from the enabled toolsets
@@ -116,14 +183,6 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		}
 	}
 
-	// Generate instructions based on enabled toolsets
-	instructions := github.GenerateInstructions(enabledToolsets)
-	
-	ghServer := github.NewServer(cfg.Version, 
-		server.WithInstructions(instructions),
-		server.WithHooks(hooks),
-	)
-
 	getClient := func(_ context.Context) (*gogithub.Client, error) {
 		return restClient, nil // closing over client
 	}
@@ -132,6 +191,10 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		return gqlClient, nil // closing over client
 	}
 
+	getGQLRawClient := func(_ context.Context) (*github.GQLRawClient, error) {
+		return github.NewGQLRawClient(gqlHTTPClient, apiHost.graphqlURL.String()), nil // closing over client
+	}
+
 	getRawClient := func(ctx context.Context) (*raw.Client, error) {
 		client, err := getClient(ctx)
 		if err != nil {
@@ -140,14 +203,64 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		return raw.NewClient(client, apiHost.rawURL), nil // closing over client
 	}
 
+	// The workspace is the roots of the client where the transport can ask for them, and otherwise
+	// the working directory, since clients usually start the server in the workspace they operate in
+	var repoResolver *github.RepoContextResolver
+	if cfg.InferRepo {
+		getRoots := func(ctx context.Context) ([]string, error) {
+			if cfg.ClientRoots != nil {
+				if roots, err := cfg.ClientRoots(ctx); err == nil && len(roots) > 0 {
+					return roots, nil
+				}
+			}
+			wd, err := os.Getwd()
+			if err != nil {
+				return nil, err
+			}
+			return []string{wd}, nil
+		}
+		repoResolver = github.NewRepoContextResolver(apiHost.hostname, getRoots)
+	}
+
+	restAllowlist, err := github.ParseRESTAllowlist(cfg.RESTAllowlist)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Create default toolsets
-	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getRawClient, cfg.Translator, cfg.ContentWindowSize)
+	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getGQLRawClient, getRawClient, cfg.Translator, cfg.ContentWindowSize, repoResolver, restAllowlist)
 	err = tsg.EnableToolsets(enabledToolsets)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to enable toolsets: %w", err)
+		return nil, nil, fmt.Errorf("failed to enable toolsets: %w", err)
 	}
 
+	outputFormat, err := github.ParseOutputFormat(cfg.OutputFormat)
+	if err != nil {
+		return nil, nil, err
+	}
+	tsg.UpdateTools(github.WithAutoPagination(cfg.MaxResponseTokens))
+	tsg.UpdateTools(github.WithResponseBudget(cfg.MaxResponseTokens))
+	tsg.UpdateTools(github.WithOutputFormat(outputFormat))
+
+	// Generate instructions based on enabled toolsets
+	instructions := github.GenerateInstructions(enabledToolsets)
+
+	serverOpts := []server.ServerOption{
+		server.WithInstructions(instructions),
+		server.WithHooks(hooks),
+		server.WithToolHandlerMiddleware(inFlight.Middleware),
+	}
+	if cfg.InferRepo {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.EnableRepoInference(tsg, repoResolver)))
+	}
+	if subscribe {
+		serverOpts = append(serverOpts, server.WithResourceCapabilities(true, true))
+	}
+
+	ghServer := github.NewServer(cfg.Version, serverOpts...)
+	ghServer.AddNotificationHandler(github.MethodNotificationCancelled, inFlight.HandleCancelled)
+
 	// Register all mcp functionality with the server
 	tsg.RegisterAll(ghServer)
 
@@ -156,7 +269,11 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		dynamic.RegisterTools(ghServer)
 	}
 
-	return ghServer, nil
+	subscriptions := github.NewResourceSubscriptions(getClient, func(uri string) {
+		ghServer.SendNotificationToAllClients(mcp.MethodNotificationResourceUpdated, map[string]any{"uri": uri})
+	}, github.ResourceSubscriptionsConfig{})
+
+	return ghServer, subscriptions, nil
 }
 
 type StdioServerConfig struct {
@@ -192,6 +309,19 @@ type StdioServerConfig struct {
 
 	// Content window size
 	ContentWindowSize int
+
+	// InferRepo indicates if owner and repo should default to the repository of the workspace when omitted
+	InferRepo bool
+
+	// OutputFormat is the format tool results are returned in when a call doesn't ask for one, defaults to json
+	OutputFormat string
+
+	// MaxResponseTokens limits the size of tool results in approximate tokens, larger results are truncated.
+	// Zero disables the limit.
+	MaxResponseTokens int
+
+	// RESTAllowlist lists the "METHOD /path" patterns github_rest_request may send other than GET requests
+	RESTAllowlist []string
 }
 
 // RunStdioServer is not concurrent safe.
@@ -202,22 +332,6 @@ func RunStdioServer(cfg StdioServerConfig) error {
 
 	t, dumpTranslations := translations.TranslationHelper()
 
-	ghServer, err := NewMCPServer(MCPServerConfig{
-		Version:           cfg.Version,
-		Host:              cfg.Host,
-		Token:             cfg.Token,
-		EnabledToolsets:   cfg.EnabledToolsets,
-		DynamicToolsets:   cfg.DynamicToolsets,
-		ReadOnly:          cfg.ReadOnly,
-		Translator:        t,
-		ContentWindowSize: cfg.ContentWindowSize,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to create MCP server: %w", err)
-	}
-
-	stdioServer := server.NewStdioServer(ghServer)
-
 	var slogHandler slog.Handler
 	var logOutput io.Writer
 	if cfg.LogFilePath != "" {
@@ -233,6 +347,37 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	}
 	logger := slog.New(slogHandler)
 	logger.Info("starting server", "version", cfg.Version, "host", cfg.Host, "dynamicToolsets", cfg.DynamicToolsets, "readOnly", cfg.ReadOnly)
+
+	in, out := io.Reader(os.Stdin), io.Writer(os.Stdout)
+	if cfg.EnableCommandLogging {
+		loggedIO := mcplog.NewIOLogger(in, out, logger)
+		in, out = loggedIO, loggedIO
+	}
+	out = &lockedWriter{w: out}
+	roots := newClientRoots(out)
+
+	ghServer, subscriptions, err := newMCPServer(MCPServerConfig{
+		Version:           cfg.Version,
+		Host:              cfg.Host,
+		Token:             cfg.Token,
+		EnabledToolsets:   cfg.EnabledToolsets,
+		DynamicToolsets:   cfg.DynamicToolsets,
+		ReadOnly:          cfg.ReadOnly,
+		Translator:        t,
+		ContentWindowSize: cfg.ContentWindowSize,
+		InferRepo:         cfg.InferRepo,
+		ClientRoots:       roots.List,
+		OutputFormat:      cfg.OutputFormat,
+		MaxResponseTokens: cfg.MaxResponseTokens,
+		RESTAllowlist:     cfg.RESTAllowlist,
+		Logger:            logger,
+	}, true)
+	if err != nil {
+		return fmt.Errorf("failed to create MCP server: %w", err)
+	}
+
+	stdioServer := server.NewStdioServer(ghServer)
+
 	stdLogger := log.New(logOutput, stdioServerLogPrefix, 0)
 	stdioServer.SetErrorLogger(stdLogger)
 
@@ -244,12 +389,12 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	// Start listening for messages
 	errC := make(chan error, 1)
 	go func() {
-		in, out := io.Reader(os.Stdin), io.Writer(os.Stdout)
-
-		if cfg.EnableCommandLogging {
-			loggedIO := mcplog.NewIOLogger(in, out, logger)
-			in, out = loggedIO, loggedIO
-		}
+		// Poll subscribed resources and answer subscription requests ourselves, and take the
+		// responses to our roots requests
+		in := interceptMessages(ctx, in, func(line []byte) bool {
+			return roots.handleMessage(line) || handleSubscriptionMessage(line, out, subscriptions)
+		})
+		go subscriptions.Run(ctx)
 		// enable GitHub errors in the context
 		ctx := errors.ContextWithGitHubErrors(ctx)
 		errC <- stdioServer.Listen(ctx, in, out)
@@ -258,6 +403,13 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	// Output github-mcp-server string
 	_, _ = fmt.Fprintf(os.Stderr, "GitHub MCP Server running on stdio\n")
 
+	// Remove the repository snapshots grep_repository extracted once the server stops
+	defer func() {
+		if err := github.RemoveRepositorySnapshots(); err != nil {
+			logger.Error("failed to remove repository snapshots", "error", err)
+		}
+	}()
+
 	// Wait for shutdown signal
 	select {
 	case <-ctx.Done():
@@ -277,6 +429,8 @@ type apiHost struct {
 	graphqlURL  *url.URL
 	uploadURL   *url.URL
 	rawURL      *url.URL
+	// hostname is the host git remotes point at, e.g. github.com
+	hostname string
 }
 
 func newDotcomHost() (apiHost, error) {
@@ -305,6 +459,7 @@ func newDotcomHost() (apiHost, error) {
 		graphqlURL:  gqlURL,
 		uploadURL:   uploadURL,
 		rawURL:      rawURL,
+		hostname:    "github.com",
 	}, nil
 }
 
@@ -344,6 +499,7 @@ func newGHECHost(hostname string) (apiHost, error) {
 		graphqlURL:  gqlURL,
 		uploadURL:   uploadURL,
 		rawURL:      rawURL,
+		hostname:    u.Hostname(),
 	}, nil
 }
 
@@ -377,6 +533,7 @@ func newGHESHost(hostname string) (apiHost, error) {
 		graphqlURL:  gqlURL,
 		uploadURL:   uploadURL,
 		rawURL:      rawURL,
+		hostname:    u.Hostname(),
 	}, nil
 }
 
@@ -417,6 +574,56 @@ func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error
 	return t.transport.RoundTrip(req)
 }
 
+// rateLimitWarningThreshold is the share of the rate limit left below which every response is reported.
+const rateLimitWarningThreshold = 0.1
+
+// apiLoggingTransport logs what GitHub tells us about the health of our requests: a rate limit
+// running out or already hit, and deprecation notices for the endpoints we call.
+type apiLoggingTransport struct {
+	transport http.RoundTripper
+	logger    *slog.Logger
+}
+
+func (t *apiLoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	ctx := req.Context()
+
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+		if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+			t.logger.WarnContext(ctx, "GitHub secondary rate limit hit", "url", req.URL.Path, "retryAfterSeconds", retryAfter)
+		} else if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			t.logger.WarnContext(ctx, "GitHub rate limit exhausted", "url", req.URL.Path, "resource", resp.Header.Get("X-RateLimit-Resource"), "reset", resp.Header.Get("X-RateLimit-Reset"))
+		}
+	} else if remaining, limit, ok := rateLimitFromHeaders(resp.Header); ok && float64(remaining) < float64(limit)*rateLimitWarningThreshold {
+		t.logger.WarnContext(ctx, "GitHub rate limit running low", "remaining", remaining, "limit", limit, "resource", resp.Header.Get("X-RateLimit-Resource"), "reset", resp.Header.Get("X-RateLimit-Reset"))
+	}
+
+	if deprecation := resp.Header.Get("Deprecation"); deprecation != "" {
+		attrs := []any{"url", req.URL.Path, "deprecation", deprecation}
+		if sunset := resp.Header.Get("Sunset"); sunset != "" {
+			attrs = append(attrs, "sunset", sunset)
+		}
+		t.logger.WarnContext(ctx, "GitHub API endpoint is deprecated", attrs...)
+	}
+
+	return resp, nil
+}
+
+func rateLimitFromHeaders(header http.Header) (remaining, limit int, ok bool) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return 0, 0, false
+	}
+	limit, err = strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil || limit <= 0 {
+		return 0, 0, false
+	}
+	return remaining, limit, true
+}
+
 type bearerAuthTransport struct {
 	transport http.RoundTripper
 	token     string
@@ -0,0 +1,106 @@
+package ghmcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// mcp-go can advertise the resources subscribe capability but does not route resources/subscribe
+// or resources/unsubscribe requests, so the stdio server, the only transport advertising it,
+// answers them before they reach it.
+const (
+	methodResourcesSubscribe   = "resources/subscribe"
+	methodResourcesUnsubscribe = "resources/unsubscribe"
+)
+
+// lockedWriter serializes writes so that responses written by the subscription handler
+// do not interleave with the messages written by the stdio server.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+// subscriptionRequest holds the fields of a JSON-RPC message needed to handle subscriptions.
+type subscriptionRequest struct {
+	ID     *mcp.RequestId `json:"id"`
+	Method string         `json:"method"`
+	Params struct {
+		URI string `json:"uri"`
+	} `json:"params"`
+}
+
+// interceptMessages passes every message read from in to handle, and returns a reader with the
+// messages it didn't take for the stdio server.
+func interceptMessages(ctx context.Context, in io.Reader, handle func(line []byte) bool) io.Reader {
+	pr, pw := io.Pipe()
+
+	go func() {
+		reader := bufio.NewReader(in)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(line) > 0 && !handle(line) {
+				if _, werr := pw.Write(line); werr != nil {
+					return
+				}
+			}
+			if err != nil {
+				_ = pw.CloseWithError(err)
+				return
+			}
+			if ctx.Err() != nil {
+				_ = pw.CloseWithError(ctx.Err())
+				return
+			}
+		}
+	}()
+
+	return pr
+}
+
+// handleSubscriptionMessage handles line if it is a subscription request and reports whether it did.
+func handleSubscriptionMessage(line []byte, out io.Writer, subs *github.ResourceSubscriptions) bool {
+	var req subscriptionRequest
+	if err := json.Unmarshal(line, &req); err != nil || req.ID == nil {
+		return false
+	}
+
+	var response any
+	switch req.Method {
+	case methodResourcesSubscribe:
+		if err := subs.Subscribe(req.Params.URI); err != nil {
+			code := mcp.INVALID_PARAMS
+			if errors.Is(err, github.ErrTooManySubscriptions) {
+				code = mcp.INVALID_REQUEST
+			}
+			response = mcp.NewJSONRPCError(*req.ID, code, err.Error(), nil)
+		} else {
+			response = mcp.NewJSONRPCResponse(*req.ID, mcp.Result{})
+		}
+	case methodResourcesUnsubscribe:
+		subs.Unsubscribe(req.Params.URI)
+		response = mcp.NewJSONRPCResponse(*req.ID, mcp.Result{})
+	default:
+		return false
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return true
+	}
+	_, _ = fmt.Fprintf(out, "%s\n", data)
+	return true
+}
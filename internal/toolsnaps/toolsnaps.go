@@ -12,7 +12,7 @@ import (
 )
 
 // Test checks that the JSON schema for a tool has not changed unexpectedly.
-// It compares the marshaled JSON of the provided tool against a stored snapshot file.
+// It compares the marshaled JSON of the provided tool, including its input and output schemas, against a stored snapshot file.
 // If the UPDATE_TOOLSNAPS environment variable is set to "true", it updates the snapshot file instead.
 // If the snapshot does not exist and not running in CI, it creates the snapshot file.
 // If the snapshot does not exist and running in CI (GITHUB_ACTIONS="true"), it returns an error.
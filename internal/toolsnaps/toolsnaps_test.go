@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -131,3 +132,26 @@ func TestMalformedSnapshotJSON(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to parse snapshot JSON for dummy", "expected error about malformed snapshot JSON")
 }
+
+func TestSnapshotOutputSchemaDiff(t *testing.T) {
+	withIsolatedWorkingDir(t)
+	// Ensure that UPDATE_TOOLSNAPS is not set for this test, which it might be if someone is running
+	// UPDATE_TOOLSNAPS=true go test ./...
+	t.Setenv("UPDATE_TOOLSNAPS", "false")
+	t.Setenv("GITHUB_ACTIONS", "false")
+
+	// Given a snapshot of a tool with an output schema
+	tool := mcp.NewTool("dummy", mcp.WithRawOutputSchema(json.RawMessage(`{"type":"object","properties":{"id":{"type":"integer"}}}`)))
+	require.NoError(t, Test("dummy", tool))
+	snap, err := os.ReadFile(filepath.Join("__toolsnaps__", "dummy.snap"))
+	require.NoError(t, err)
+	assert.Contains(t, string(snap), "outputSchema")
+
+	// When only the output schema of the tool changes
+	tool = mcp.NewTool("dummy", mcp.WithRawOutputSchema(json.RawMessage(`{"type":"object","properties":{"id":{"type":"string"}}}`)))
+	err = Test("dummy", tool)
+
+	// Then it should error about the schema diff
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tool schema for dummy has changed unexpectedly", "expected error about diff")
+}
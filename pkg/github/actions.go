@@ -39,6 +39,8 @@ func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc)
 				mcp.Description(DescriptionRepositoryName),
 			),
 			WithPagination(),
+			WithOutputSchema[ListResult[*github.Workflow]](),
+			WithMinimalOutput[ListResult[MinimalWorkflow]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -56,6 +58,11 @@ func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -73,7 +80,12 @@ func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(workflows)
+			result := restListResult(workflows.Workflows, resp).withTotalCount(workflows.GetTotalCount())
+			if minimalOutput {
+				return MarshalledTextResult(convertListResult(result, convertToMinimalWorkflow)), nil
+			}
+
+			r, err := json.Marshal(result)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -150,6 +162,8 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Enum("queued", "in_progress", "completed", "requested", "waiting"),
 			),
 			WithPagination(),
+			WithOutputSchema[ListResult[*github.WorkflowRun]](),
+			WithMinimalOutput[ListResult[MinimalWorkflowRun]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -189,6 +203,11 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -212,7 +231,12 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(workflowRuns)
+			result := restListResult(workflowRuns.WorkflowRuns, resp).withTotalCount(workflowRuns.GetTotalCount())
+			if minimalOutput {
+				return MarshalledTextResult(convertListResult(result, convertToMinimalWorkflowRun)), nil
+			}
+
+			r, err := json.Marshal(result)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -248,6 +272,7 @@ func RunWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			mcp.WithObject("inputs",
 				mcp.Description("Inputs the workflow accepts"),
 			),
+			WithOutputSchema[map[string]any](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -340,6 +365,8 @@ func GetWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc)
 				mcp.Required(),
 				mcp.Description("The unique identifier of the workflow run"),
 			),
+			WithOutputSchema[*github.WorkflowRun](),
+			WithMinimalOutput[MinimalWorkflowRun](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -356,6 +383,11 @@ func GetWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc)
 			}
 			runID := int64(runIDInt)
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -367,6 +399,10 @@ func GetWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
+			if minimalOutput {
+				return MarshalledTextResult(convertToMinimalWorkflowRun(workflowRun)), nil
+			}
+
 			r, err := json.Marshal(workflowRun)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
@@ -396,6 +432,7 @@ func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperF
 				mcp.Required(),
 				mcp.Description("The unique identifier of the workflow run"),
 			),
+			WithOutputSchema[map[string]any](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -467,6 +504,8 @@ func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Enum("latest", "all"),
 			),
 			WithPagination(),
+			WithOutputSchema[workflowJobsResult[*github.WorkflowJob]](),
+			WithMinimalOutput[workflowJobsResult[MinimalWorkflowJob]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -495,6 +534,11 @@ func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -516,9 +560,18 @@ func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFun
 			defer func() { _ = resp.Body.Close() }()
 
 			// Add optimization tip for failed job debugging
-			response := map[string]any{
-				"jobs":             jobs,
-				"optimization_tip": "For debugging failed jobs, consider using get_job_logs with failed_only=true and run_id=" + fmt.Sprintf("%d", runID) + " to get logs directly without needing to list jobs first",
+			optimizationTip := "For debugging failed jobs, consider using get_job_logs with failed_only=true and run_id=" + fmt.Sprintf("%d", runID) + " to get logs directly without needing to list jobs first"
+			result := restListResult(jobs.Jobs, resp).withTotalCount(jobs.GetTotalCount())
+			if minimalOutput {
+				return MarshalledTextResult(workflowJobsResult[MinimalWorkflowJob]{
+					ListResult:      convertListResult(result, convertToMinimalWorkflowJob),
+					OptimizationTip: optimizationTip,
+				}), nil
+			}
+
+			response := workflowJobsResult[*github.WorkflowJob]{
+				ListResult:      result,
+				OptimizationTip: optimizationTip,
 			}
 
 			r, err := json.Marshal(response)
@@ -530,6 +583,12 @@ func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFun
 		}
 }
 
+// workflowJobsResult is a page of workflow jobs, with a tip on debugging the failed ones.
+type workflowJobsResult[T any] struct {
+	ListResult[T]
+	OptimizationTip string `json:"optimization_tip"`
+}
+
 // GetJobLogs creates a tool to download logs for a specific workflow job or efficiently get all failed job logs for a workflow run
 func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc, contentWindowSize int) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_job_logs",
@@ -562,6 +621,7 @@ func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc, con
 				mcp.Description("Number of lines to return from the end of the log"),
 				mcp.DefaultNumber(500),
 			),
+			WithOutputSchema[map[string]any](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -614,7 +674,7 @@ func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc, con
 
 			if failedOnly && runID > 0 {
 				// Handle failed-only mode: get logs for all failed jobs in the workflow run
-				return handleFailedJobLogs(ctx, client, owner, repo, int64(runID), returnContent, tailLines, contentWindowSize)
+				return handleFailedJobLogs(ctx, request, client, owner, repo, int64(runID), returnContent, tailLines, contentWindowSize)
 			} else if jobID > 0 {
 				// Handle single job mode
 				return handleSingleJobLogs(ctx, client, owner, repo, int64(jobID), returnContent, tailLines, contentWindowSize)
@@ -625,7 +685,7 @@ func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc, con
 }
 
 // handleFailedJobLogs gets logs for all failed jobs in a workflow run
-func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo string, runID int64, returnContent bool, tailLines int, contentWindowSize int) (*mcp.CallToolResult, error) {
+func handleFailedJobLogs(ctx context.Context, request mcp.CallToolRequest, client *github.Client, owner, repo string, runID int64, returnContent bool, tailLines int, contentWindowSize int) (*mcp.CallToolResult, error) {
 	// First, get all jobs for the workflow run
 	jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, runID, &github.ListWorkflowJobsOptions{
 		Filter: "latest",
@@ -656,7 +716,8 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 
 	// Collect logs for all failed jobs
 	var logResults []map[string]any
-	for _, job := range failedJobs {
+	for i, job := range failedJobs {
+		SendProgress(ctx, request, float64(i), float64(len(failedJobs)), fmt.Sprintf("Fetching logs for job %s", job.GetName()))
 		jobResult, resp, err := getJobLogData(ctx, client, owner, repo, job.GetID(), job.GetName(), returnContent, tailLines, contentWindowSize)
 		if err != nil {
 			// Continue with other jobs even if one fails
@@ -671,6 +732,7 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 
 		logResults = append(logResults, jobResult)
 	}
+	SendProgress(ctx, request, float64(len(failedJobs)), float64(len(failedJobs)), "")
 
 	result := map[string]any{
 		"message":       fmt.Sprintf("Retrieved logs for %d failed jobs", len(failedJobs)),
@@ -747,7 +809,12 @@ func downloadLogContent(ctx context.Context, logURL string, tailLines int, maxLi
 	prof := profiler.New(nil, profiler.IsProfilingEnabled())
 	finish := prof.Start(ctx, "log_buffer_processing")
 
-	httpResp, err := http.Get(logURL) //nolint:gosec
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logURL, nil)
+	if err != nil {
+		return "", 0, nil, fmt.Errorf("failed to create log request: %w", err)
+	}
+
+	httpResp, err := http.DefaultClient.Do(req) //nolint:gosec
 	if err != nil {
 		return "", 0, httpResp, fmt.Errorf("failed to download logs: %w", err)
 	}
@@ -798,6 +865,7 @@ func RerunWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Required(),
 				mcp.Description("The unique identifier of the workflow run"),
 			),
+			WithOutputSchema[map[string]any](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -861,6 +929,7 @@ func RerunFailedJobs(getClient GetClientFn, t translations.TranslationHelperFunc
 				mcp.Required(),
 				mcp.Description("The unique identifier of the workflow run"),
 			),
+			WithOutputSchema[map[string]any](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -924,6 +993,7 @@ func CancelWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFu
 				mcp.Required(),
 				mcp.Description("The unique identifier of the workflow run"),
 			),
+			WithOutputSchema[map[string]any](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -990,6 +1060,7 @@ func ListWorkflowRunArtifacts(getClient GetClientFn, t translations.TranslationH
 				mcp.Description("The unique identifier of the workflow run"),
 			),
 			WithPagination(),
+			WithOutputSchema[ListResult[*github.Artifact]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1029,7 +1100,7 @@ func ListWorkflowRunArtifacts(getClient GetClientFn, t translations.TranslationH
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(artifacts)
+			r, err := json.Marshal(restListResult(artifacts.Artifacts, resp).withTotalCount(int(artifacts.GetTotalCount())))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -1058,6 +1129,7 @@ func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.Translati
 				mcp.Required(),
 				mcp.Description("The unique identifier of the artifact"),
 			),
+			WithOutputSchema[map[string]any](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1080,11 +1152,13 @@ func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.Translati
 			}
 
 			// Get the download URL for the artifact
+			SendProgress(ctx, request, 0, 1, "Requesting artifact download URL")
 			url, resp, err := client.Actions.DownloadArtifact(ctx, owner, repo, artifactID, 1)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get artifact download URL", resp, err), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
+			SendProgress(ctx, request, 1, 1, "")
 
 			// Create response with the download URL and information
 			result := map[string]any{
@@ -1124,6 +1198,7 @@ func DeleteWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelp
 				mcp.Required(),
 				mcp.Description("The unique identifier of the workflow run"),
 			),
+			WithOutputSchema[map[string]any](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1187,6 +1262,7 @@ func GetWorkflowRunUsage(getClient GetClientFn, t translations.TranslationHelper
 				mcp.Required(),
 				mcp.Description("The unique identifier of the workflow run"),
 			),
+			WithOutputSchema[*github.WorkflowRunUsage](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
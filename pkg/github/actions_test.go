@@ -11,8 +11,10 @@ import (
 	"runtime/debug"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/github/github-mcp-server/internal/profiler"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
 	buffer "github.com/github/github-mcp-server/pkg/buffer"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
@@ -122,16 +124,169 @@ func Test_ListWorkflows(t *testing.T) {
 			}
 
 			// Unmarshal and verify the result
-			var response github.Workflows
+			var response ListResult[*github.Workflow]
 			err = json.Unmarshal([]byte(textContent.Text), &response)
 			require.NoError(t, err)
-			assert.NotNil(t, response.TotalCount)
-			assert.Greater(t, *response.TotalCount, 0)
-			assert.NotEmpty(t, response.Workflows)
+			require.NotNil(t, response.PageInfo.TotalCount)
+			assert.Equal(t, 2, *response.PageInfo.TotalCount)
+			assert.NotEmpty(t, response.Items)
 		})
 	}
 }
 
+func Test_ListWorkflowRuns(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWorkflowRuns(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_workflow_runs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "workflow_id")
+	assert.Contains(t, tool.InputSchema.Properties, "minimal_output")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "workflow_id"})
+
+	mockRuns := &github.WorkflowRuns{
+		TotalCount: github.Ptr(1),
+		WorkflowRuns: []*github.WorkflowRun{
+			{
+				ID:           github.Ptr(int64(42)),
+				Name:         github.Ptr("CI"),
+				NodeID:       github.Ptr("WFR_42"),
+				RunNumber:    github.Ptr(7),
+				Event:        github.Ptr("push"),
+				Status:       github.Ptr("completed"),
+				Conclusion:   github.Ptr("failure"),
+				HeadBranch:   github.Ptr("main"),
+				HeadSHA:      github.Ptr("abc123"),
+				HTMLURL:      github.Ptr("https://github.com/owner/repo/actions/runs/42"),
+				JobsURL:      github.Ptr("https://api.github.com/repos/owner/repo/actions/runs/42/jobs"),
+				Actor:        &github.User{Login: github.Ptr("octocat"), ID: github.Ptr(int64(1))},
+				CreatedAt:    &github.Timestamp{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+				HeadCommit:   &github.HeadCommit{Message: github.Ptr("Fix the build")},
+				Repository:   &github.Repository{FullName: github.Ptr("owner/repo")},
+				PullRequests: []*github.PullRequest{{Number: github.Ptr(1)}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		requestArgs  map[string]any
+		expectedJSON string
+	}{
+		{
+			name: "full workflow runs",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "ci.yml",
+			},
+		},
+		{
+			name: "minimal workflow runs",
+			requestArgs: map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"workflow_id":    "ci.yml",
+				"minimal_output": true,
+			},
+			expectedJSON: `{
+				"items": [{
+					"id": 42,
+					"name": "CI",
+					"run_number": 7,
+					"event": "push",
+					"status": "completed",
+					"conclusion": "failure",
+					"head_branch": "main",
+					"head_sha": "abc123",
+					"html_url": "https://github.com/owner/repo/actions/runs/42",
+					"actor": {"login": "octocat"},
+					"created_at": "2025-01-02T03:04:05Z"
+				}],
+				"page_info": {"has_next": false, "total_count": 1},
+				"truncated": false
+			}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+					mockRuns,
+				),
+			))
+			_, handler := ListWorkflowRuns(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedJSON != "" {
+				assert.JSONEq(t, tc.expectedJSON, textContent.Text)
+				return
+			}
+
+			var response ListResult[*github.WorkflowRun]
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			require.NotNil(t, response.PageInfo.TotalCount)
+			assert.Equal(t, 1, *response.PageInfo.TotalCount)
+			require.Len(t, response.Items, 1)
+			assert.Equal(t, "Fix the build", response.Items[0].GetHeadCommit().GetMessage())
+		})
+	}
+}
+
+func Test_GetWorkflowRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWorkflowRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_workflow_run", tool.Name)
+	assert.Contains(t, tool.InputSchema.Properties, "minimal_output")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	mockRun := &github.WorkflowRun{
+		ID:         github.Ptr(int64(42)),
+		Name:       github.Ptr("CI"),
+		RunNumber:  github.Ptr(7),
+		RunAttempt: github.Ptr(2),
+		Event:      github.Ptr("pull_request"),
+		Status:     github.Ptr("in_progress"),
+		HTMLURL:    github.Ptr("https://github.com/owner/repo/actions/runs/42"),
+		Repository: &github.Repository{FullName: github.Ptr("owner/repo")},
+	}
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposActionsRunsByOwnerByRepoByRunId,
+			mockRun,
+		),
+	))
+	_, handler := GetWorkflowRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":          "owner",
+		"repo":           "repo",
+		"run_id":         float64(42),
+		"minimal_output": true,
+	}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": 42,
+		"name": "CI",
+		"run_number": 7,
+		"run_attempt": 2,
+		"event": "pull_request",
+		"status": "in_progress",
+		"html_url": "https://github.com/owner/repo/actions/runs/42"
+	}`, getTextResult(t, result).Text)
+}
+
 func Test_RunWorkflow(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -531,12 +686,12 @@ func Test_ListWorkflowRunArtifacts(t *testing.T) {
 			}
 
 			// Unmarshal and verify the result
-			var response github.ArtifactList
+			var response ListResult[*github.Artifact]
 			err = json.Unmarshal([]byte(textContent.Text), &response)
 			require.NoError(t, err)
-			assert.NotNil(t, response.TotalCount)
-			assert.Greater(t, *response.TotalCount, int64(0))
-			assert.NotEmpty(t, response.Artifacts)
+			require.NotNil(t, response.PageInfo.TotalCount)
+			assert.Greater(t, *response.PageInfo.TotalCount, 0)
+			assert.NotEmpty(t, response.Items)
 		})
 	}
 }
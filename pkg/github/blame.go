@@ -0,0 +1,246 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// BlameRange is a range of lines last changed by the same commit.
+type BlameRange struct {
+	StartLine   int    `json:"start_line"`
+	EndLine     int    `json:"end_line"`
+	SHA         string `json:"sha"`
+	Author      string `json:"author,omitempty"`
+	AuthorLogin string `json:"author_login,omitempty"`
+	Date        string `json:"date,omitempty"`
+	Message     string `json:"message"`
+}
+
+// BlameLines is a range of lines within a BlameCommit.
+type BlameLines struct {
+	StartLine int `json:"start_line"`
+	EndLine   int `json:"end_line"`
+}
+
+// BlameCommit is a commit with all the line ranges it last changed.
+type BlameCommit struct {
+	SHA         string       `json:"sha"`
+	Author      string       `json:"author,omitempty"`
+	AuthorLogin string       `json:"author_login,omitempty"`
+	Date        string       `json:"date,omitempty"`
+	Message     string       `json:"message"`
+	Lines       int          `json:"lines"`
+	Ranges      []BlameLines `json:"ranges"`
+}
+
+// FileBlame is the output type of get_file_blame. Ranges are set unless the
+// blame is grouped by commit, then Commits is.
+type FileBlame struct {
+	Path    string        `json:"path"`
+	SHA     string        `json:"sha"`
+	Ranges  []BlameRange  `json:"ranges,omitempty"`
+	Commits []BlameCommit `json:"commits,omitempty"`
+}
+
+type blameCommitFragment struct {
+	Blame struct {
+		Ranges []struct {
+			StartingLine githubv4.Int
+			EndingLine   githubv4.Int
+			Commit       struct {
+				OID             githubv4.GitObjectID
+				MessageHeadline githubv4.String
+				CommittedDate   githubv4.DateTime
+				Author          struct {
+					Name githubv4.String
+					User struct {
+						Login githubv4.String
+					}
+				}
+			}
+		}
+	} `graphql:"blame(path: $path)"`
+}
+
+// fileBlameQuery looks up the object by SHA, which is a tag object rather than
+// a commit for annotated tags.
+type fileBlameQuery struct {
+	Repository struct {
+		Object struct {
+			Commit blameCommitFragment `graphql:"... on Commit"`
+			Tag    struct {
+				Target struct {
+					Commit blameCommitFragment `graphql:"... on Commit"`
+				}
+			} `graphql:"... on Tag"`
+		} `graphql:"object(oid: $sha)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// GetFileBlame creates a tool to get the blame of a file in a GitHub repository.
+func GetFileBlame(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_file_blame",
+			mcp.WithDescription(t("TOOL_GET_FILE_BLAME_DESCRIPTION", "Get the blame of a file in a GitHub repository: for each range of lines, the commit that last changed it with its author, date and message headline. Use this to find out who last touched a line and why.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_FILE_BLAME_USER_TITLE", "Get file blame"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path to the file"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Accepts optional git refs such as `refs/tags/{tag}`, `refs/heads/{branch}`, or a branch or tag name. Defaults to the default branch"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Accepts optional commit SHA. If specified, it will be used instead of ref"),
+			),
+			mcp.WithNumber("start_line",
+				mcp.Description("First line to return blame for (min 1)"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("end_line",
+				mcp.Description("Last line to return blame for (min 1)"),
+				mcp.Min(1),
+			),
+			mcp.WithBoolean("group_by_commit",
+				mcp.Description("Return each commit once with all the line ranges it last changed, instead of ranges in line order. Default is false."),
+				mcp.DefaultBool(false),
+			),
+			WithOutputSchema[FileBlame](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			startLine, err := OptionalIntParam(request, "start_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			endLine, err := OptionalIntParam(request, "end_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if startLine > 0 && endLine > 0 && endLine < startLine {
+				return mcp.NewToolResultError("end_line must not be before start_line"), nil
+			}
+			groupByCommit, err := OptionalBoolParamWithDefault(request, "group_by_commit", false)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			rawOpts, err := resolveGitReference(ctx, client, owner, repo, ref, sha)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil
+			}
+
+			gqlClient, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GraphQL client: %w", err)
+			}
+
+			var query fileBlameQuery
+			vars := map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+				"sha":   githubv4.GitObjectID(rawOpts.SHA),
+				"path":  githubv4.String(path),
+			}
+			if err := gqlClient.Query(ctx, &query, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to get blame of %s", path), err), nil
+			}
+
+			blame := query.Repository.Object.Commit.Blame
+			if len(blame.Ranges) == 0 {
+				blame = query.Repository.Object.Tag.Target.Commit.Blame
+			}
+			if len(blame.Ranges) == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("no blame found for %s at %s", path, rawOpts.SHA)), nil
+			}
+
+			result := FileBlame{Path: path, SHA: rawOpts.SHA}
+			commits := make(map[string]int)
+			for _, r := range blame.Ranges {
+				start, end := int(r.StartingLine), int(r.EndingLine)
+				// Keep the part of the range within the requested lines
+				if startLine > 0 {
+					start = max(start, startLine)
+				}
+				if endLine > 0 {
+					end = min(end, endLine)
+				}
+				if start > end {
+					continue
+				}
+
+				blameRange := BlameRange{
+					StartLine:   start,
+					EndLine:     end,
+					SHA:         string(r.Commit.OID),
+					Author:      string(r.Commit.Author.Name),
+					AuthorLogin: string(r.Commit.Author.User.Login),
+					Message:     string(r.Commit.MessageHeadline),
+				}
+				if !r.Commit.CommittedDate.IsZero() {
+					blameRange.Date = r.Commit.CommittedDate.UTC().Format("2006-01-02T15:04:05Z")
+				}
+
+				if !groupByCommit {
+					result.Ranges = append(result.Ranges, blameRange)
+					continue
+				}
+				i, ok := commits[blameRange.SHA]
+				if !ok {
+					i = len(result.Commits)
+					commits[blameRange.SHA] = i
+					result.Commits = append(result.Commits, BlameCommit{
+						SHA:         blameRange.SHA,
+						Author:      blameRange.Author,
+						AuthorLogin: blameRange.AuthorLogin,
+						Date:        blameRange.Date,
+						Message:     blameRange.Message,
+					})
+				}
+				result.Commits[i].Lines += end - start + 1
+				result.Commits[i].Ranges = append(result.Commits[i].Ranges, BlameLines{StartLine: start, EndLine: end})
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,223 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetFileBlame(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GetFileBlame(nil, nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_file_blame", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "get_file_blame tool should be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "start_line")
+	assert.Contains(t, tool.InputSchema.Properties, "end_line")
+	assert.Contains(t, tool.InputSchema.Properties, "group_by_commit")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path"})
+
+	blameRange := func(start, end int, sha, author, login, message string) map[string]any {
+		return map[string]any{
+			"startingLine": start,
+			"endingLine":   end,
+			"commit": map[string]any{
+				"oid":             sha,
+				"messageHeadline": message,
+				"committedDate":   "2025-01-02T03:04:05Z",
+				"author": map[string]any{
+					"name": author,
+					"user": map[string]any{"login": login},
+				},
+			},
+		}
+	}
+	ranges := []any{
+		blameRange(1, 3, "aaa111", "Alice", "alice", "Initial version"),
+		blameRange(4, 4, "bbb222", "Bob", "bob", "Fix off by one"),
+		blameRange(5, 9, "aaa111", "Alice", "alice", "Initial version"),
+	}
+	commitResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"object": map[string]any{
+				"blame": map[string]any{"ranges": ranges},
+			},
+		},
+	})
+	tagResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"object": map[string]any{
+				"target": map[string]any{
+					"blame": map[string]any{"ranges": ranges},
+				},
+			},
+		},
+	})
+
+	varsFor := func(sha string) map[string]any {
+		return map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+			"sha":   githubv4.GitObjectID(sha),
+			"path":  githubv4.String("main.go"),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		gqlMatchers    []githubv4mock.Matcher
+		requestArgs    map[string]any
+		expectError    bool
+		expectedBlame  FileBlame
+		expectedErrMsg string
+	}{
+		{
+			name:         "blame at a SHA",
+			mockedClient: mock.NewMockedHTTPClient(),
+			gqlMatchers:  []githubv4mock.Matcher{githubv4mock.NewQueryMatcher(fileBlameQuery{}, varsFor("abc123"), commitResponse)},
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "main.go",
+				"sha":   "abc123",
+			},
+			expectedBlame: FileBlame{
+				Path: "main.go",
+				SHA:  "abc123",
+				Ranges: []BlameRange{
+					{StartLine: 1, EndLine: 3, SHA: "aaa111", Author: "Alice", AuthorLogin: "alice", Date: "2025-01-02T03:04:05Z", Message: "Initial version"},
+					{StartLine: 4, EndLine: 4, SHA: "bbb222", Author: "Bob", AuthorLogin: "bob", Date: "2025-01-02T03:04:05Z", Message: "Fix off by one"},
+					{StartLine: 5, EndLine: 9, SHA: "aaa111", Author: "Alice", AuthorLogin: "alice", Date: "2025-01-02T03:04:05Z", Message: "Initial version"},
+				},
+			},
+		},
+		{
+			name: "blame of an annotated tag with a line range",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/git/ref/tags/v1.0").andThen(
+						mockResponse(t, http.StatusOK, &github.Reference{
+							Ref:    github.Ptr("refs/tags/v1.0"),
+							Object: &github.GitObject{SHA: github.Ptr("tag999"), Type: github.Ptr("tag")},
+						}),
+					),
+				),
+			),
+			gqlMatchers: []githubv4mock.Matcher{githubv4mock.NewQueryMatcher(fileBlameQuery{}, varsFor("tag999"), tagResponse)},
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "main.go",
+				"ref":        "refs/tags/v1.0",
+				"start_line": float64(3),
+				"end_line":   float64(6),
+			},
+			expectedBlame: FileBlame{
+				Path: "main.go",
+				SHA:  "tag999",
+				Ranges: []BlameRange{
+					{StartLine: 3, EndLine: 3, SHA: "aaa111", Author: "Alice", AuthorLogin: "alice", Date: "2025-01-02T03:04:05Z", Message: "Initial version"},
+					{StartLine: 4, EndLine: 4, SHA: "bbb222", Author: "Bob", AuthorLogin: "bob", Date: "2025-01-02T03:04:05Z", Message: "Fix off by one"},
+					{StartLine: 5, EndLine: 6, SHA: "aaa111", Author: "Alice", AuthorLogin: "alice", Date: "2025-01-02T03:04:05Z", Message: "Initial version"},
+				},
+			},
+		},
+		{
+			name:         "blame grouped by commit",
+			mockedClient: mock.NewMockedHTTPClient(),
+			gqlMatchers:  []githubv4mock.Matcher{githubv4mock.NewQueryMatcher(fileBlameQuery{}, varsFor("abc123"), commitResponse)},
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"path":            "main.go",
+				"sha":             "abc123",
+				"group_by_commit": true,
+			},
+			expectedBlame: FileBlame{
+				Path: "main.go",
+				SHA:  "abc123",
+				Commits: []BlameCommit{
+					{
+						SHA: "aaa111", Author: "Alice", AuthorLogin: "alice", Date: "2025-01-02T03:04:05Z", Message: "Initial version",
+						Lines:  8,
+						Ranges: []BlameLines{{StartLine: 1, EndLine: 3}, {StartLine: 5, EndLine: 9}},
+					},
+					{
+						SHA: "bbb222", Author: "Bob", AuthorLogin: "bob", Date: "2025-01-02T03:04:05Z", Message: "Fix off by one",
+						Lines:  1,
+						Ranges: []BlameLines{{StartLine: 4, EndLine: 4}},
+					},
+				},
+			},
+		},
+		{
+			name:         "invalid line range",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "main.go",
+				"sha":        "abc123",
+				"start_line": float64(10),
+				"end_line":   float64(2),
+			},
+			expectError:    true,
+			expectedErrMsg: "end_line must not be before start_line",
+		},
+		{
+			name:         "blame query fails",
+			mockedClient: mock.NewMockedHTTPClient(),
+			gqlMatchers:  []githubv4mock.Matcher{githubv4mock.NewQueryMatcher(fileBlameQuery{}, varsFor("abc123"), githubv4mock.ErrorResponse("Could not resolve file"))},
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "main.go",
+				"sha":   "abc123",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get blame of main.go",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.gqlMatchers...))
+			_, handler := GetFileBlame(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var blame FileBlame
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &blame))
+			assert.Equal(t, tc.expectedBlame, blame)
+		})
+	}
+}
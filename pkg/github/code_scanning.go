@@ -33,6 +33,8 @@ func GetCodeScanningAlert(getClient GetClientFn, t translations.TranslationHelpe
 				mcp.Required(),
 				mcp.Description("The number of the alert."),
 			),
+			WithOutputSchema[*github.Alert](),
+			WithMinimalOutput[MinimalCodeScanningAlert](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -48,6 +50,11 @@ func GetCodeScanningAlert(getClient GetClientFn, t translations.TranslationHelpe
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -71,6 +78,10 @@ func GetCodeScanningAlert(getClient GetClientFn, t translations.TranslationHelpe
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get alert: %s", string(body))), nil
 			}
 
+			if minimalOutput {
+				return MarshalledTextResult(convertToMinimalCodeScanningAlert(alert)), nil
+			}
+
 			r, err := json.Marshal(alert)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal alert: %w", err)
@@ -110,6 +121,8 @@ func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHel
 			mcp.WithString("tool_name",
 				mcp.Description("The name of the tool used for code scanning."),
 			),
+			WithOutputSchema[[]*github.Alert](),
+			WithMinimalOutput[[]MinimalCodeScanningAlert](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -137,6 +150,11 @@ func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHel
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -159,6 +177,10 @@ func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHel
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list alerts: %s", string(body))), nil
 			}
 
+			if minimalOutput {
+				return MarshalledTextResult(convertToMinimalList(alerts, convertToMinimalCodeScanningAlert)), nil
+			}
+
 			r, err := json.Marshal(alerts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal alerts: %w", err)
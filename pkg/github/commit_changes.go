@@ -0,0 +1,469 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"path"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// commitChangesSteps is the number of stages commit_changes reports progress for.
+const commitChangesSteps = 3
+
+// fileModes maps the mode names commit_changes accepts to Git file modes.
+var fileModes = map[string]string{
+	"file":       "100644",
+	"executable": "100755",
+	"symlink":    "120000",
+}
+
+// fileChange is one entry of the changes of a commit_changes call.
+type fileChange struct {
+	Operation    string
+	Path         string
+	PreviousPath string
+	Content      *string
+	Encoding     string
+	Mode         string
+}
+
+// CommitChangesResult is the commit created by commit_changes.
+type CommitChangesResult struct {
+	SHA       string `json:"sha"`
+	HTMLURL   string `json:"html_url,omitempty"`
+	TreeSHA   string `json:"tree_sha"`
+	ParentSHA string `json:"parent_sha"`
+	Ref       string `json:"ref"`
+}
+
+// validRepoPath reports whether p is a clean path relative to the repository root.
+func validRepoPath(p string) bool {
+	return p != "" && p != "." && p != ".." && path.Clean(p) == p && !strings.HasPrefix(p, "/") && !strings.HasPrefix(p, "../")
+}
+
+// parseFileChanges parses and validates the changes parameter of commit_changes.
+func parseFileChanges(raw any) ([]fileChange, error) {
+	items, ok := raw.([]any)
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("changes must be a non-empty array of objects")
+	}
+
+	changes := make([]fileChange, 0, len(items))
+	seen := make(map[string]bool)
+	claim := func(p string) error {
+		if seen[p] {
+			return fmt.Errorf("%s is changed more than once", p)
+		}
+		seen[p] = true
+		return nil
+	}
+	for i, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("change %d must be an object", i)
+		}
+		var change fileChange
+		change.Operation, _ = m["operation"].(string)
+		change.Path, _ = m["path"].(string)
+		change.PreviousPath, _ = m["previous_path"].(string)
+		change.Encoding, _ = m["encoding"].(string)
+		change.Mode, _ = m["mode"].(string)
+		if content, ok := m["content"].(string); ok {
+			change.Content = &content
+		}
+
+		if !validRepoPath(change.Path) {
+			return nil, fmt.Errorf("change %d has an invalid path %q", i, change.Path)
+		}
+		if err := claim(change.Path); err != nil {
+			return nil, err
+		}
+
+		switch change.Operation {
+		case "create", "update":
+			if change.Content == nil {
+				return nil, fmt.Errorf("%s of %s requires content", change.Operation, change.Path)
+			}
+		case "delete":
+			if change.Content != nil || change.Mode != "" {
+				return nil, fmt.Errorf("delete of %s does not take content or mode", change.Path)
+			}
+		case "rename":
+			if !validRepoPath(change.PreviousPath) {
+				return nil, fmt.Errorf("rename of %s requires a valid previous_path", change.Path)
+			}
+			if err := claim(change.PreviousPath); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("change %d has an invalid operation %q, it must be create, update, delete or rename", i, change.Operation)
+		}
+
+		if change.Mode != "" {
+			if _, ok := fileModes[change.Mode]; !ok {
+				return nil, fmt.Errorf("%s has an invalid mode %q, it must be file, executable or symlink", change.Path, change.Mode)
+			}
+		}
+		switch change.Encoding {
+		case "":
+			change.Encoding = "utf-8"
+		case "utf-8":
+		case "base64":
+			if change.Content != nil {
+				if _, err := base64.StdEncoding.DecodeString(*change.Content); err != nil {
+					return nil, fmt.Errorf("content of %s is not valid base64: %w", change.Path, err)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("%s has an invalid encoding %q, it must be utf-8 or base64", change.Path, change.Encoding)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// treeLookup finds entries of a Git tree by path, listing each directory at most once.
+type treeLookup struct {
+	client  *github.Client
+	owner   string
+	repo    string
+	rootSHA string
+	dirs    map[string]map[string]*github.TreeEntry
+}
+
+func newTreeLookup(client *github.Client, owner, repo, rootSHA string) *treeLookup {
+	return &treeLookup{
+		client:  client,
+		owner:   owner,
+		repo:    repo,
+		rootSHA: rootSHA,
+		dirs:    make(map[string]map[string]*github.TreeEntry),
+	}
+}
+
+// get returns the entry at p, or nil if there is none.
+func (l *treeLookup) get(ctx context.Context, p string) (*github.TreeEntry, *github.Response, error) {
+	entries, resp, err := l.list(ctx, path.Dir(p))
+	if err != nil {
+		return nil, resp, err
+	}
+	return entries[path.Base(p)], nil, nil
+}
+
+// list returns the entries of the directory dir by name, or nil if it doesn't exist.
+func (l *treeLookup) list(ctx context.Context, dir string) (map[string]*github.TreeEntry, *github.Response, error) {
+	if entries, ok := l.dirs[dir]; ok {
+		return entries, nil, nil
+	}
+
+	sha := l.rootSHA
+	if dir != "." {
+		parent, resp, err := l.list(ctx, path.Dir(dir))
+		if err != nil {
+			return nil, resp, err
+		}
+		entry := parent[path.Base(dir)]
+		if entry == nil || entry.GetType() != "tree" {
+			l.dirs[dir] = nil
+			return nil, nil, nil
+		}
+		sha = entry.GetSHA()
+	}
+
+	tree, resp, err := l.client.Git.GetTree(ctx, l.owner, l.repo, sha, false)
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+
+	entries := make(map[string]*github.TreeEntry, len(tree.Entries))
+	for _, entry := range tree.Entries {
+		entries[entry.GetPath()] = entry
+	}
+	l.dirs[dir] = entries
+	return entries, nil, nil
+}
+
+// CommitChanges creates a tool to commit a list of file creations, updates, deletions and
+// renames to a branch as a single commit.
+func CommitChanges(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("commit_changes",
+			mcp.WithDescription(t("TOOL_COMMIT_CHANGES_DESCRIPTION", "Commit a list of file changes to a branch of a GitHub repository as a single commit. Files can be created, updated, deleted or renamed, with text or base64 encoded binary content, and made executable or symbolic links. Either all changes are committed or none are.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_COMMIT_CHANGES_USER_TITLE", "Commit changes to repository"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to commit to"),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message"),
+			),
+			mcp.WithArray("changes",
+				mcp.Required(),
+				mcp.Items(
+					map[string]any{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"operation", "path"},
+						"properties": map[string]any{
+							"operation": map[string]any{
+								"type":        "string",
+								"enum":        []string{"create", "update", "delete", "rename"},
+								"description": "create a file that doesn't exist, update an existing file, delete a file or directory, or rename previous_path to path",
+							},
+							"path": map[string]any{
+								"type":        "string",
+								"description": "path of the file, relative to the repository root",
+							},
+							"previous_path": map[string]any{
+								"type":        "string",
+								"description": "path the file is renamed from, required for rename",
+							},
+							"content": map[string]any{
+								"type":        "string",
+								"description": "new file content, required for create and update, optional for rename. For symlinks, the link target",
+							},
+							"encoding": map[string]any{
+								"type":        "string",
+								"enum":        []string{"utf-8", "base64"},
+								"description": "encoding of content, base64 for binary files. Defaults to utf-8",
+							},
+							"mode": map[string]any{
+								"type":        "string",
+								"enum":        []string{"file", "executable", "symlink"},
+								"description": "file mode. Defaults to the mode of the existing file, or file",
+							},
+						},
+					}),
+				mcp.Description("Changes to commit, applied together"),
+			),
+			mcp.WithString("expected_head_sha",
+				mcp.Description("SHA the branch must point to. If the branch has moved on, nothing is committed"),
+			),
+			WithOutputSchema[CommitChangesResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			expectedHeadSHA, err := OptionalParam[string](request, "expected_head_sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			changes, err := parseFileChanges(request.GetArguments()["changes"])
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			SendProgress(ctx, request, 0, commitChangesSteps, "Reading branch")
+			ref, baseCommit, errResult := getBranchHead(ctx, client, owner, repo, branch, expectedHeadSHA)
+			if errResult != nil {
+				return errResult, nil
+			}
+
+			SendProgress(ctx, request, 1, commitChangesSteps, "Preparing changes")
+			lookup := newTreeLookup(client, owner, repo, baseCommit.GetTree().GetSHA())
+			lookupEntry := func(p string) (*github.TreeEntry, *mcp.CallToolResult) {
+				entry, resp, err := lookup.get(ctx, p)
+				if err != nil {
+					return nil, ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get git tree", resp, err)
+				}
+				return entry, nil
+			}
+
+			var entries []*github.TreeEntry
+			for _, change := range changes {
+				existing, errResult := lookupEntry(change.Path)
+				if errResult != nil {
+					return errResult, nil
+				}
+
+				entry := &github.TreeEntry{Path: github.Ptr(change.Path), Type: github.Ptr("blob")}
+				switch change.Operation {
+				case "create":
+					if existing != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("cannot create %s: it already exists", change.Path)), nil
+					}
+					entry.Mode = github.Ptr(fileModes["file"])
+				case "update":
+					if existing == nil || existing.GetType() != "blob" {
+						return mcp.NewToolResultError(fmt.Sprintf("cannot update %s: no such file", change.Path)), nil
+					}
+					entry.Mode = existing.Mode
+				case "delete":
+					if existing == nil {
+						return mcp.NewToolResultError(fmt.Sprintf("cannot delete %s: no such file or directory", change.Path)), nil
+					}
+					// An entry with neither a SHA nor content removes the path
+					entries = append(entries, &github.TreeEntry{Path: github.Ptr(change.Path), Mode: existing.Mode, Type: existing.Type})
+					continue
+				case "rename":
+					if existing != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("cannot rename %s to %s: it already exists", change.PreviousPath, change.Path)), nil
+					}
+					previous, errResult := lookupEntry(change.PreviousPath)
+					if errResult != nil {
+						return errResult, nil
+					}
+					if previous == nil {
+						return mcp.NewToolResultError(fmt.Sprintf("cannot rename %s: no such file or directory", change.PreviousPath)), nil
+					}
+					if change.Content != nil && previous.GetType() != "blob" {
+						return mcp.NewToolResultError(fmt.Sprintf("cannot rename %s with new content: it is not a file", change.PreviousPath)), nil
+					}
+					entries = append(entries, &github.TreeEntry{Path: github.Ptr(change.PreviousPath), Mode: previous.Mode, Type: previous.Type})
+					entry.Mode, entry.Type, entry.SHA = previous.Mode, previous.Type, previous.SHA
+				}
+
+				if change.Mode != "" {
+					entry.Mode = github.Ptr(fileModes[change.Mode])
+				}
+				if change.Content != nil {
+					entry.SHA = nil
+					if change.Encoding == "base64" {
+						blob, resp, err := client.Git.CreateBlob(ctx, owner, repo, &github.Blob{
+							Content:  change.Content,
+							Encoding: github.Ptr("base64"),
+						})
+						if err != nil {
+							return ghErrors.NewGitHubAPIErrorResponse(ctx,
+								fmt.Sprintf("failed to create blob for %s", change.Path),
+								resp,
+								err,
+							), nil
+						}
+						_ = resp.Body.Close()
+						entry.SHA = blob.SHA
+					} else {
+						entry.Content = change.Content
+					}
+				}
+				entries = append(entries, entry)
+			}
+
+			SendProgress(ctx, request, 2, commitChangesSteps, "Creating commit")
+			result, errResult := commitTreeEntries(ctx, client, owner, repo, ref, baseCommit, entries, message)
+			if errResult != nil {
+				return errResult, nil
+			}
+			SendProgress(ctx, request, commitChangesSteps, commitChangesSteps, "")
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// getBranchHead returns the reference of a branch and the commit it points to. If
+// expectedHeadSHA is set and the branch points elsewhere, it returns an error result.
+func getBranchHead(ctx context.Context, client *github.Client, owner, repo, branch, expectedHeadSHA string) (*github.Reference, *github.Commit, *mcp.CallToolResult) {
+	ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+	if err != nil {
+		return nil, nil, ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get branch reference",
+			resp,
+			err,
+		)
+	}
+	_ = resp.Body.Close()
+
+	headSHA := ref.GetObject().GetSHA()
+	if expectedHeadSHA != "" && !strings.EqualFold(headSHA, expectedHeadSHA) {
+		return nil, nil, mcp.NewToolResultError(fmt.Sprintf("branch %s is at %s, not at expected_head_sha %s", branch, headSHA, expectedHeadSHA))
+	}
+
+	baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, headSHA)
+	if err != nil {
+		return nil, nil, ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get base commit",
+			resp,
+			err,
+		)
+	}
+	_ = resp.Body.Close()
+	return ref, baseCommit, nil
+}
+
+// commitTreeEntries commits entries on top of baseCommit and moves ref to the new commit.
+// The ref is not forced, so the update fails if the branch has moved on since it was read.
+func commitTreeEntries(ctx context.Context, client *github.Client, owner, repo string, ref *github.Reference, baseCommit *github.Commit, entries []*github.TreeEntry, message string) (CommitChangesResult, *mcp.CallToolResult) {
+	newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, baseCommit.GetTree().GetSHA(), entries)
+	if err != nil {
+		return CommitChangesResult{}, ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to create tree",
+			resp,
+			err,
+		)
+	}
+	_ = resp.Body.Close()
+
+	newCommit, resp, err := client.Git.CreateCommit(ctx, owner, repo, &github.Commit{
+		Message: github.Ptr(message),
+		Tree:    newTree,
+		Parents: []*github.Commit{{SHA: baseCommit.SHA}},
+	}, nil)
+	if err != nil {
+		return CommitChangesResult{}, ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to create commit",
+			resp,
+			err,
+		)
+	}
+	_ = resp.Body.Close()
+
+	branch := strings.TrimPrefix(ref.GetRef(), "refs/heads/")
+	updatedRef, resp, err := client.Git.UpdateRef(ctx, owner, repo, &github.Reference{
+		Ref:    ref.Ref,
+		Object: &github.GitObject{SHA: newCommit.SHA},
+	}, false)
+	if err != nil {
+		return CommitChangesResult{}, ghErrors.NewGitHubAPIErrorResponse(ctx,
+			fmt.Sprintf("failed to update reference, branch %s may have moved on from %s", branch, baseCommit.GetSHA()),
+			resp,
+			err,
+		)
+	}
+	_ = resp.Body.Close()
+
+	return CommitChangesResult{
+		SHA:       newCommit.GetSHA(),
+		HTMLURL:   newCommit.GetHTMLURL(),
+		TreeSHA:   newTree.GetSHA(),
+		ParentSHA: baseCommit.GetSHA(),
+		Ref:       updatedRef.GetRef(),
+	}, nil
+}
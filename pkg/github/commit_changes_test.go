@@ -0,0 +1,281 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CommitChanges(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := CommitChanges(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "commit_changes", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint, "commit_changes tool should not be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.Contains(t, tool.InputSchema.Properties, "changes")
+	assert.Contains(t, tool.InputSchema.Properties, "expected_head_sha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "message", "changes"})
+
+	mockRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{SHA: github.Ptr("abc123")},
+	}
+	mockCommit := &github.Commit{
+		SHA:  github.Ptr("abc123"),
+		Tree: &github.Tree{SHA: github.Ptr("tree-root")},
+	}
+	trees := map[string]*github.Tree{
+		"/repos/owner/repo/git/trees/tree-root": {Entries: []*github.TreeEntry{
+			{Path: github.Ptr("README.md"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("sha-readme")},
+			{Path: github.Ptr("build.sh"), Type: github.Ptr("blob"), Mode: github.Ptr("100755"), SHA: github.Ptr("sha-build")},
+			{Path: github.Ptr("docs"), Type: github.Ptr("tree"), Mode: github.Ptr("040000"), SHA: github.Ptr("tree-docs")},
+		}},
+		"/repos/owner/repo/git/trees/tree-docs": {Entries: []*github.TreeEntry{
+			{Path: github.Ptr("old.md"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("sha-old")},
+		}},
+	}
+	treesHandler := mock.WithRequestMatchHandler(
+		mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tree, ok := trees[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				return
+			}
+			mockResponse(t, http.StatusOK, tree)(w, r)
+		}),
+	)
+	mockNewCommit := &github.Commit{
+		SHA:     github.Ptr("new456"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/new456"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedResult CommitChangesResult
+	}{
+		{
+			name: "creates, updates, deletes and renames files in one commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+				mock.WithRequestMatch(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, mockCommit),
+				treesHandler,
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitBlobsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"content":  "iVBORw0KGgo=",
+						"encoding": "base64",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Blob{SHA: github.Ptr("sha-logo")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"base_tree": "tree-root",
+						"tree": []any{
+							map[string]any{"path": "logo.png", "mode": "100644", "type": "blob", "sha": "sha-logo"},
+							map[string]any{"path": "build.sh", "mode": "100755", "type": "blob", "content": "#!/bin/sh\nmake\n"},
+							map[string]any{"path": "README.md", "mode": "100644", "type": "blob", "sha": nil},
+							map[string]any{"path": "docs/old.md", "mode": "100644", "type": "blob", "sha": nil},
+							map[string]any{"path": "docs/new.md", "mode": "100644", "type": "blob", "sha": "sha-old"},
+							map[string]any{"path": "docs/README.md", "mode": "120000", "type": "blob", "content": "../README.md"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Tree{SHA: github.Ptr("tree-new")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"message": "Rework docs",
+						"tree":    "tree-new",
+						"parents": []any{"abc123"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockNewCommit),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					expectRequestBody(t, map[string]any{
+						"sha":   "new456",
+						"force": false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Reference{Ref: github.Ptr("refs/heads/main"), Object: &github.GitObject{SHA: github.Ptr("new456")}}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":             "owner",
+				"repo":              "repo",
+				"branch":            "main",
+				"message":           "Rework docs",
+				"expected_head_sha": "abc123",
+				"changes": []any{
+					map[string]any{"operation": "create", "path": "logo.png", "content": "iVBORw0KGgo=", "encoding": "base64"},
+					map[string]any{"operation": "update", "path": "build.sh", "content": "#!/bin/sh\nmake\n"},
+					map[string]any{"operation": "delete", "path": "README.md"},
+					map[string]any{"operation": "rename", "path": "docs/new.md", "previous_path": "docs/old.md"},
+					map[string]any{"operation": "create", "path": "docs/README.md", "content": "../README.md", "mode": "symlink"},
+				},
+			},
+			expectedResult: CommitChangesResult{
+				SHA:       "new456",
+				HTMLURL:   "https://github.com/owner/repo/commit/new456",
+				TreeSHA:   "tree-new",
+				ParentSHA: "abc123",
+				Ref:       "refs/heads/main",
+			},
+		},
+		{
+			name: "branch moved on from expected_head_sha",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+			),
+			requestArgs: map[string]any{
+				"owner":             "owner",
+				"repo":              "repo",
+				"branch":            "main",
+				"message":           "Update",
+				"expected_head_sha": "old000",
+				"changes":           []any{map[string]any{"operation": "delete", "path": "README.md"}},
+			},
+			expectError:    true,
+			expectedErrMsg: "branch main is at abc123, not at expected_head_sha old000",
+		},
+		{
+			name: "create of an existing file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+				mock.WithRequestMatch(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, mockCommit),
+				treesHandler,
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Update",
+				"changes": []any{map[string]any{"operation": "create", "path": "docs/old.md", "content": "x"}},
+			},
+			expectError:    true,
+			expectedErrMsg: "cannot create docs/old.md: it already exists",
+		},
+		{
+			name: "update of a missing file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+				mock.WithRequestMatch(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, mockCommit),
+				treesHandler,
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Update",
+				"changes": []any{map[string]any{"operation": "update", "path": "missing/file.md", "content": "x"}},
+			},
+			expectError:    true,
+			expectedErrMsg: "cannot update missing/file.md: no such file",
+		},
+		{
+			name: "branch updated concurrently",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+				mock.WithRequestMatch(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, mockCommit),
+				treesHandler,
+				mock.WithRequestMatch(mock.PostReposGitTreesByOwnerByRepo, &github.Tree{SHA: github.Ptr("tree-new")}),
+				mock.WithRequestMatch(mock.PostReposGitCommitsByOwnerByRepo, mockNewCommit),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Update is not a fast forward"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Update",
+				"changes": []any{map[string]any{"operation": "delete", "path": "README.md"}},
+			},
+			expectError:    true,
+			expectedErrMsg: "branch main may have moved on from abc123",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CommitChanges(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var commitResult CommitChangesResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &commitResult))
+			assert.Equal(t, tc.expectedResult, commitResult)
+		})
+	}
+}
+
+func Test_ParseFileChanges(t *testing.T) {
+	tests := []struct {
+		name           string
+		changes        any
+		expectedErrMsg string
+	}{
+		{"not an array", "README.md", "changes must be a non-empty array"},
+		{"empty", []any{}, "changes must be a non-empty array"},
+		{"invalid operation", []any{map[string]any{"operation": "copy", "path": "a"}}, `invalid operation "copy"`},
+		{"path outside the repository", []any{map[string]any{"operation": "delete", "path": "../a"}}, `invalid path "../a"`},
+		{"unclean path", []any{map[string]any{"operation": "delete", "path": "a//b"}}, `invalid path "a//b"`},
+		{"create without content", []any{map[string]any{"operation": "create", "path": "a"}}, "create of a requires content"},
+		{"rename without previous_path", []any{map[string]any{"operation": "rename", "path": "a"}}, "requires a valid previous_path"},
+		{"path changed twice", []any{
+			map[string]any{"operation": "delete", "path": "a"},
+			map[string]any{"operation": "rename", "path": "b", "previous_path": "a"},
+		}, "a is changed more than once"},
+		{"invalid mode", []any{map[string]any{"operation": "create", "path": "a", "content": "x", "mode": "100644"}}, `invalid mode "100644"`},
+		{"invalid base64", []any{map[string]any{"operation": "create", "path": "a", "content": "not base64!", "encoding": "base64"}}, "content of a is not valid base64"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseFileChanges(tc.changes)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedErrMsg)
+		})
+	}
+
+	changes, err := parseFileChanges([]any{map[string]any{"operation": "update", "path": "a/b.txt", "content": "x"}})
+	require.NoError(t, err)
+	assert.Equal(t, []fileChange{{Operation: "update", Path: "a/b.txt", Content: github.Ptr("x"), Encoding: "utf-8"}}, changes)
+}
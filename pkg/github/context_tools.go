@@ -41,6 +41,7 @@ func GetMe(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Too
 			Title:        t("TOOL_GET_ME_USER_TITLE", "Get my user profile"),
 			ReadOnlyHint: ToBoolPtr(true),
 		}),
+		WithOutputSchema[MinimalUser](),
 	)
 
 	type args struct{}
@@ -113,6 +114,7 @@ func GetTeams(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations
 				Title:        t("TOOL_GET_TEAMS_TITLE", "Get teams"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithOutputSchema[[]OrganizationTeams](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			user, err := OptionalParam[string](request, "user")
@@ -205,6 +207,7 @@ func GetTeamMembers(getGQLClient GetGQLClientFn, t translations.TranslationHelpe
 				Title:        t("TOOL_GET_TEAM_MEMBERS_TITLE", "Get team members"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithOutputSchema[[]string](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			org, err := RequiredParam[string](request, "org")
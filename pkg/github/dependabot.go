@@ -34,6 +34,8 @@ func GetDependabotAlert(getClient GetClientFn, t translations.TranslationHelperF
 				mcp.Required(),
 				mcp.Description("The number of the alert."),
 			),
+			WithOutputSchema[*github.DependabotAlert](),
+			WithMinimalOutput[MinimalDependabotAlert](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -49,6 +51,11 @@ func GetDependabotAlert(getClient GetClientFn, t translations.TranslationHelperF
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -72,6 +79,10 @@ func GetDependabotAlert(getClient GetClientFn, t translations.TranslationHelperF
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get alert: %s", string(body))), nil
 			}
 
+			if minimalOutput {
+				return MarshalledTextResult(convertToMinimalDependabotAlert(alert)), nil
+			}
+
 			r, err := json.Marshal(alert)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal alert: %w", err)
@@ -106,6 +117,8 @@ func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelpe
 				mcp.Description("Filter dependabot alerts by severity"),
 				mcp.Enum("low", "medium", "high", "critical"),
 			),
+			WithOutputSchema[[]*github.DependabotAlert](),
+			WithMinimalOutput[[]MinimalDependabotAlert](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -125,6 +138,11 @@ func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelpe
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -151,6 +169,10 @@ func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelpe
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list alerts: %s", string(body))), nil
 			}
 
+			if minimalOutput {
+				return MarshalledTextResult(convertToMinimalList(alerts, convertToMinimalDependabotAlert)), nil
+			}
+
 			r, err := json.Marshal(alerts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal alerts: %w", err)
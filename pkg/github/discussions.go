@@ -143,6 +143,8 @@ func ListDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelp
 				mcp.Enum("ASC", "DESC"),
 			),
 			WithCursorPagination(),
+			WithOutputSchema[ListResult[*github.Discussion]](),
+			WithMinimalOutput[ListResult[MinimalDiscussion]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -184,6 +186,11 @@ func ListDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelp
 				return nil, err
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getGQLClient(ctx)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
@@ -237,15 +244,11 @@ func ListDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelp
 			}
 
 			// Create response with pagination info
-			response := map[string]interface{}{
-				"discussions": discussions,
-				"pageInfo": map[string]interface{}{
-					"hasNextPage":     pageInfo.HasNextPage,
-					"hasPreviousPage": pageInfo.HasPreviousPage,
-					"startCursor":     string(pageInfo.StartCursor),
-					"endCursor":       string(pageInfo.EndCursor),
-				},
-				"totalCount": totalCount,
+			response := graphQLListResult(discussions, pageInfo.HasNextPage, string(pageInfo.EndCursor), int(totalCount))
+			if minimalOutput {
+				return MarshalledTextResult(convertListResult(response, func(discussion *github.Discussion) MinimalDiscussion {
+					return convertToMinimalDiscussion(discussion, false)
+				})), nil
 			}
 
 			out, err := json.Marshal(response)
@@ -275,6 +278,8 @@ func GetDiscussion(getGQLClient GetGQLClientFn, t translations.TranslationHelper
 				mcp.Required(),
 				mcp.Description("Discussion Number"),
 			),
+			WithOutputSchema[*github.Discussion](),
+			WithMinimalOutput[MinimalDiscussion](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			// Decode params
@@ -286,6 +291,12 @@ func GetDiscussion(getGQLClient GetGQLClientFn, t translations.TranslationHelper
 			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getGQLClient(ctx)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
@@ -324,6 +335,10 @@ func GetDiscussion(getGQLClient GetGQLClientFn, t translations.TranslationHelper
 					Name: github.Ptr(string(d.Category.Name)),
 				},
 			}
+			if minimalOutput {
+				return MarshalledTextResult(convertToMinimalDiscussion(discussion, true)), nil
+			}
+
 			out, err := json.Marshal(discussion)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal discussion: %w", err)
@@ -344,6 +359,8 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 			mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name")),
 			mcp.WithNumber("discussionNumber", mcp.Required(), mcp.Description("Discussion Number")),
 			WithCursorPagination(),
+			WithOutputSchema[ListResult[*github.IssueComment]](),
+			WithMinimalOutput[ListResult[MinimalIssueComment]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			// Decode params
@@ -377,6 +394,11 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 				paginationParams.First = &defaultFirst
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getGQLClient(ctx)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
@@ -387,7 +409,15 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 					Discussion struct {
 						Comments struct {
 							Nodes []struct {
-								Body githubv4.String
+								DatabaseID int64 `graphql:"databaseId"`
+								Body       githubv4.String
+								Author     struct {
+									Login githubv4.String
+								}
+								AuthorAssociation githubv4.String
+								URL               githubv4.String `graphql:"url"`
+								CreatedAt         githubv4.DateTime
+								UpdatedAt         githubv4.DateTime
 							}
 							PageInfo struct {
 								HasNextPage     githubv4.Boolean
@@ -417,19 +447,22 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 
 			var comments []*github.IssueComment
 			for _, c := range q.Repository.Discussion.Comments.Nodes {
-				comments = append(comments, &github.IssueComment{Body: github.Ptr(string(c.Body))})
+				comments = append(comments, &github.IssueComment{
+					ID:                github.Ptr(c.DatabaseID),
+					Body:              github.Ptr(string(c.Body)),
+					User:              &github.User{Login: github.Ptr(string(c.Author.Login))},
+					AuthorAssociation: github.Ptr(string(c.AuthorAssociation)),
+					HTMLURL:           github.Ptr(string(c.URL)),
+					CreatedAt:         &github.Timestamp{Time: c.CreatedAt.Time},
+					UpdatedAt:         &github.Timestamp{Time: c.UpdatedAt.Time},
+				})
 			}
 
 			// Create response with pagination info
-			response := map[string]interface{}{
-				"comments": comments,
-				"pageInfo": map[string]interface{}{
-					"hasNextPage":     q.Repository.Discussion.Comments.PageInfo.HasNextPage,
-					"hasPreviousPage": q.Repository.Discussion.Comments.PageInfo.HasPreviousPage,
-					"startCursor":     string(q.Repository.Discussion.Comments.PageInfo.StartCursor),
-					"endCursor":       string(q.Repository.Discussion.Comments.PageInfo.EndCursor),
-				},
-				"totalCount": q.Repository.Discussion.Comments.TotalCount,
+			pageInfo := q.Repository.Discussion.Comments.PageInfo
+			response := graphQLListResult(comments, bool(pageInfo.HasNextPage), string(pageInfo.EndCursor), q.Repository.Discussion.Comments.TotalCount)
+			if minimalOutput {
+				return MarshalledTextResult(convertListResult(response, convertToMinimalIssueComment)), nil
 			}
 
 			out, err := json.Marshal(response)
@@ -455,6 +488,7 @@ func ListDiscussionCategories(getGQLClient GetGQLClientFn, t translations.Transl
 			mcp.WithString("repo",
 				mcp.Description("Repository name. If not provided, discussion categories will be queried at the organisation level."),
 			),
+			WithOutputSchema[ListResult[map[string]string]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -511,16 +545,8 @@ func ListDiscussionCategories(getGQLClient GetGQLClientFn, t translations.Transl
 			}
 
 			// Create response with pagination info
-			response := map[string]interface{}{
-				"categories": categories,
-				"pageInfo": map[string]interface{}{
-					"hasNextPage":     q.Repository.DiscussionCategories.PageInfo.HasNextPage,
-					"hasPreviousPage": q.Repository.DiscussionCategories.PageInfo.HasPreviousPage,
-					"startCursor":     string(q.Repository.DiscussionCategories.PageInfo.StartCursor),
-					"endCursor":       string(q.Repository.DiscussionCategories.PageInfo.EndCursor),
-				},
-				"totalCount": q.Repository.DiscussionCategories.TotalCount,
-			}
+			pageInfo := q.Repository.DiscussionCategories.PageInfo
+			response := graphQLListResult(categories, bool(pageInfo.HasNextPage), string(pageInfo.EndCursor), q.Repository.DiscussionCategories.TotalCount)
 
 			out, err := json.Marshal(response)
 			if err != nil {
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
 	"github.com/shurcooL/githubv4"
@@ -200,6 +201,7 @@ var (
 func Test_ListDiscussions(t *testing.T) {
 	mockClient := githubv4.NewClient(nil)
 	toolDef, _ := ListDiscussions(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(toolDef.Name, toolDef))
 	assert.Equal(t, "list_discussions", toolDef.Name)
 	assert.NotEmpty(t, toolDef.Description)
 	assert.Contains(t, toolDef.InputSchema.Properties, "owner")
@@ -442,29 +444,21 @@ func Test_ListDiscussions(t *testing.T) {
 			require.NoError(t, err)
 
 			// Parse the structured response with pagination info
-			var response struct {
-				Discussions []*github.Discussion `json:"discussions"`
-				PageInfo    struct {
-					HasNextPage     bool   `json:"hasNextPage"`
-					HasPreviousPage bool   `json:"hasPreviousPage"`
-					StartCursor     string `json:"startCursor"`
-					EndCursor       string `json:"endCursor"`
-				} `json:"pageInfo"`
-				TotalCount int `json:"totalCount"`
-			}
+			var response ListResult[*github.Discussion]
 			err = json.Unmarshal([]byte(text), &response)
 			require.NoError(t, err)
 
-			assert.Len(t, response.Discussions, tc.expectedCount, "Expected %d discussions, got %d", tc.expectedCount, len(response.Discussions))
+			assert.Len(t, response.Items, tc.expectedCount, "Expected %d discussions, got %d", tc.expectedCount, len(response.Items))
+			require.NotNil(t, response.PageInfo.TotalCount)
 
 			// Verify order if verifyOrder function is provided
 			if tc.verifyOrder != nil {
-				tc.verifyOrder(t, response.Discussions)
+				tc.verifyOrder(t, response.Items)
 			}
 
 			// Verify that all returned discussions have a category if filtered
 			if _, hasCategory := tc.reqParams["category"]; hasCategory {
-				for _, discussion := range response.Discussions {
+				for _, discussion := range response.Items {
 					require.NotNil(t, discussion.DiscussionCategory, "Discussion should have category")
 					assert.NotEmpty(t, *discussion.DiscussionCategory.Name, "Discussion should have category name")
 				}
@@ -476,6 +470,7 @@ func Test_ListDiscussions(t *testing.T) {
 func Test_GetDiscussion(t *testing.T) {
 	// Verify tool definition and schema
 	toolDef, _ := GetDiscussion(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(toolDef.Name, toolDef))
 	assert.Equal(t, "get_discussion", toolDef.Name)
 	assert.NotEmpty(t, toolDef.Description)
 	assert.Contains(t, toolDef.InputSchema.Properties, "owner")
@@ -570,7 +565,7 @@ func Test_GetDiscussionComments(t *testing.T) {
 	assert.ElementsMatch(t, toolDef.InputSchema.Required, []string{"owner", "repo", "discussionNumber"})
 
 	// Use exact string query that matches implementation output
-	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{body},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}"
+	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{databaseId,body,author{login},authorAssociation,url,createdAt,updatedAt},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}"
 
 	// Variables matching what GraphQL receives after JSON marshaling/unmarshaling
 	vars := map[string]interface{}{
@@ -586,8 +581,24 @@ func Test_GetDiscussionComments(t *testing.T) {
 			"discussion": map[string]any{
 				"comments": map[string]any{
 					"nodes": []map[string]any{
-						{"body": "This is the first comment"},
-						{"body": "This is the second comment"},
+						{
+							"databaseId":        101,
+							"body":              "This is the first comment",
+							"author":            map[string]any{"login": "octocat"},
+							"authorAssociation": "OWNER",
+							"url":               "https://github.com/owner/repo/discussions/1#discussioncomment-101",
+							"createdAt":         "2025-01-02T03:04:05Z",
+							"updatedAt":         "2025-01-02T03:04:05Z",
+						},
+						{
+							"databaseId":        102,
+							"body":              "This is the second comment",
+							"author":            map[string]any{"login": "hubot"},
+							"authorAssociation": "NONE",
+							"url":               "https://github.com/owner/repo/discussions/1#discussioncomment-102",
+							"createdAt":         "2025-01-03T03:04:05Z",
+							"updatedAt":         "2025-01-04T03:04:05Z",
+						},
 					},
 					"pageInfo": map[string]any{
 						"hasNextPage":     false,
@@ -600,40 +611,86 @@ func Test_GetDiscussionComments(t *testing.T) {
 			},
 		},
 	})
-	matcher := githubv4mock.NewQueryMatcher(qGetComments, vars, mockResponse)
-	httpClient := githubv4mock.NewMockedHTTPClient(matcher)
-	gqlClient := githubv4.NewClient(httpClient)
-	_, handler := GetDiscussionComments(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
-
-	request := createMCPRequest(map[string]interface{}{
-		"owner":            "owner",
-		"repo":             "repo",
-		"discussionNumber": int32(1),
-	})
+	tests := []struct {
+		name          string
+		minimalOutput bool
+		expected      string
+	}{
+		{
+			name: "full comments",
+			expected: `{
+				"items": [
+					{
+						"id": 101,
+						"body": "This is the first comment",
+						"user": {"login": "octocat"},
+						"author_association": "OWNER",
+						"html_url": "https://github.com/owner/repo/discussions/1#discussioncomment-101",
+						"created_at": "2025-01-02T03:04:05Z",
+						"updated_at": "2025-01-02T03:04:05Z"
+					},
+					{
+						"id": 102,
+						"body": "This is the second comment",
+						"user": {"login": "hubot"},
+						"author_association": "NONE",
+						"html_url": "https://github.com/owner/repo/discussions/1#discussioncomment-102",
+						"created_at": "2025-01-03T03:04:05Z",
+						"updated_at": "2025-01-04T03:04:05Z"
+					}
+				],
+				"page_info": {"has_next": false, "total_count": 2},
+				"truncated": false
+			}`,
+		},
+		{
+			name:          "minimal comments",
+			minimalOutput: true,
+			expected: `{
+				"items": [
+					{
+						"id": 101,
+						"body": "This is the first comment",
+						"user": {"login": "octocat"},
+						"html_url": "https://github.com/owner/repo/discussions/1#discussioncomment-101",
+						"created_at": "2025-01-02T03:04:05Z",
+						"updated_at": "2025-01-02T03:04:05Z"
+					},
+					{
+						"id": 102,
+						"body": "This is the second comment",
+						"user": {"login": "hubot"},
+						"html_url": "https://github.com/owner/repo/discussions/1#discussioncomment-102",
+						"created_at": "2025-01-03T03:04:05Z",
+						"updated_at": "2025-01-04T03:04:05Z"
+					}
+				],
+				"page_info": {"has_next": false, "total_count": 2},
+				"truncated": false
+			}`,
+		},
+	}
 
-	result, err := handler(context.Background(), request)
-	require.NoError(t, err)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			matcher := githubv4mock.NewQueryMatcher(qGetComments, vars, mockResponse)
+			httpClient := githubv4mock.NewMockedHTTPClient(matcher)
+			gqlClient := githubv4.NewClient(httpClient)
+			_, handler := GetDiscussionComments(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
 
-	textContent := getTextResult(t, result)
+			request := createMCPRequest(map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"discussionNumber": int32(1),
+				"minimal_output":   tc.minimalOutput,
+			})
 
-	// (Lines removed)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
 
-	var response struct {
-		Comments []*github.IssueComment `json:"comments"`
-		PageInfo struct {
-			HasNextPage     bool   `json:"hasNextPage"`
-			HasPreviousPage bool   `json:"hasPreviousPage"`
-			StartCursor     string `json:"startCursor"`
-			EndCursor       string `json:"endCursor"`
-		} `json:"pageInfo"`
-		TotalCount int `json:"totalCount"`
-	}
-	err = json.Unmarshal([]byte(textContent.Text), &response)
-	require.NoError(t, err)
-	assert.Len(t, response.Comments, 2)
-	expectedBodies := []string{"This is the first comment", "This is the second comment"}
-	for i, comment := range response.Comments {
-		assert.Equal(t, expectedBodies[i], *comment.Body)
+			textContent := getTextResult(t, result)
+			assert.JSONEq(t, tc.expected, textContent.Text)
+		})
 	}
 }
 
@@ -761,18 +818,9 @@ func Test_ListDiscussionCategories(t *testing.T) {
 			}
 			require.NoError(t, err)
 
-			var response struct {
-				Categories []map[string]string `json:"categories"`
-				PageInfo   struct {
-					HasNextPage     bool   `json:"hasNextPage"`
-					HasPreviousPage bool   `json:"hasPreviousPage"`
-					StartCursor     string `json:"startCursor"`
-					EndCursor       string `json:"endCursor"`
-				} `json:"pageInfo"`
-				TotalCount int `json:"totalCount"`
-			}
+			var response ListResult[map[string]string]
 			require.NoError(t, json.Unmarshal([]byte(text), &response))
-			assert.Equal(t, tc.expectedCategories, response.Categories)
+			assert.Equal(t, tc.expectedCategories, response.Items)
 		})
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -11,11 +12,20 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// offeredDynamically reports whether the dynamic toolset can offer a toolset to the model.
+// Opt-in toolsets are only offered once the operator has enabled them by name.
+func offeredDynamically(toolset *toolsets.Toolset) bool {
+	return toolset != nil && (!toolset.OptIn || toolset.Enabled)
+}
+
 func ToolsetEnum(toolsetGroup *toolsets.ToolsetGroup) mcp.PropertyOption {
 	toolsetNames := make([]string, 0, len(toolsetGroup.Toolsets))
-	for name := range toolsetGroup.Toolsets {
-		toolsetNames = append(toolsetNames, name)
+	for name, toolset := range toolsetGroup.Toolsets {
+		if offeredDynamically(toolset) {
+			toolsetNames = append(toolsetNames, name)
+		}
 	}
+	sort.Strings(toolsetNames)
 	return mcp.Enum(toolsetNames...)
 }
 
@@ -43,6 +53,9 @@ func EnableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, t t
 			if toolset == nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Toolset %s not found", toolsetName)), nil
 			}
+			if !offeredDynamically(toolset) {
+				return mcp.NewToolResultError(fmt.Sprintf("Toolset %s can only be enabled by the server operator", toolsetName)), nil
+			}
 			if toolset.Enabled {
 				return mcp.NewToolResultText(fmt.Sprintf("Toolset %s is already enabled", toolsetName)), nil
 			}
@@ -66,6 +79,7 @@ func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.T
 				Title:        t("TOOL_LIST_AVAILABLE_TOOLSETS_USER_TITLE", "List available toolsets"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithOutputSchema[[]map[string]string](),
 		),
 		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			// We need to convert the toolsetGroup back to a map for JSON serialization
@@ -73,6 +87,9 @@ func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.T
 			payload := []map[string]string{}
 
 			for name, ts := range toolsetGroup.Toolsets {
+				if !offeredDynamically(ts) {
+					continue
+				}
 				{
 					t := map[string]string{
 						"name":              name,
@@ -105,6 +122,7 @@ func GetToolsetsTools(toolsetGroup *toolsets.ToolsetGroup, t translations.Transl
 				mcp.Description("The name of the toolset you want to get the tools for"),
 				ToolsetEnum(toolsetGroup),
 			),
+			WithOutputSchema[[]map[string]string](),
 		),
 		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			// We need to convert the toolsetGroup back to a map for JSON serialization
@@ -113,7 +131,7 @@ func GetToolsetsTools(toolsetGroup *toolsets.ToolsetGroup, t translations.Transl
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 			toolset := toolsetGroup.Toolsets[toolsetName]
-			if toolset == nil {
+			if !offeredDynamically(toolset) {
 				return mcp.NewToolResultError(fmt.Sprintf("Toolset %s not found", toolsetName)), nil
 			}
 			payload := []map[string]string{}
@@ -0,0 +1,98 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// optInToolsetGroup has a regular toolset, an opt-in toolset the operator didn't enable,
+// and an opt-in toolset the operator enabled by name.
+func optInToolsetGroup(t *testing.T) *toolsets.ToolsetGroup {
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(toolsets.NewToolset("issues", "Issues"))
+	graphQL := toolsets.NewToolset("graphql", "GraphQL")
+	graphQL.OptIn = true
+	tsg.AddToolset(graphQL)
+	rest := toolsets.NewToolset("rest", "REST")
+	rest.OptIn = true
+	tsg.AddToolset(rest)
+	require.NoError(t, tsg.EnableToolsets([]string{"rest"}))
+	return tsg
+}
+
+func Test_DynamicToolsetsLeaveOutOptInToolsets(t *testing.T) {
+	tsg := optInToolsetGroup(t)
+
+	tool, _ := EnableToolset(server.NewMCPServer("test", "0.0.1"), tsg, translations.NullTranslationHelper)
+	toolsetProperty := tool.InputSchema.Properties["toolset"].(map[string]any)
+	assert.Equal(t, []string{"issues", "rest"}, toolsetProperty["enum"])
+
+	tool, _ = GetToolsetsTools(tsg, translations.NullTranslationHelper)
+	toolsetProperty = tool.InputSchema.Properties["toolset"].(map[string]any)
+	assert.Equal(t, []string{"issues", "rest"}, toolsetProperty["enum"])
+
+	_, handler := ListAvailableToolsets(tsg, translations.NullTranslationHelper)
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	var listed []map[string]string
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &listed))
+	names := make([]string, 0, len(listed))
+	for _, toolset := range listed {
+		names = append(names, toolset["name"])
+	}
+	assert.ElementsMatch(t, []string{"issues", "rest"}, names)
+}
+
+func Test_EnableToolsetRejectsOptInToolsets(t *testing.T) {
+	tests := []struct {
+		name           string
+		toolset        string
+		expectError    bool
+		expectedText   string
+		expectEnabled  bool
+		expectedErrMsg string
+	}{
+		{
+			name:          "regular toolset",
+			toolset:       "issues",
+			expectedText:  "Toolset issues enabled",
+			expectEnabled: true,
+		},
+		{
+			name:           "opt-in toolset the operator didn't enable",
+			toolset:        "graphql",
+			expectError:    true,
+			expectedErrMsg: "Toolset graphql can only be enabled by the server operator",
+		},
+		{
+			name:          "opt-in toolset the operator enabled",
+			toolset:       "rest",
+			expectedText:  "Toolset rest is already enabled",
+			expectEnabled: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsg := optInToolsetGroup(t)
+			_, handler := EnableToolset(server.NewMCPServer("test", "0.0.1"), tsg, translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{"toolset": tc.toolset}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+			} else {
+				assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+			}
+			assert.Equal(t, tc.expectEnabled, tsg.Toolsets[tc.toolset].Enabled)
+		})
+	}
+}
@@ -0,0 +1,334 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxContentsAPIFileSize is the size above which the raw host and the Contents API
+// don't reliably return file contents, so they are read from the Git blobs API.
+const maxContentsAPIFileSize = 1 << 20
+
+// maxStreamedFileBytes is the most get_file_contents returns of a large or Git LFS file.
+const maxStreamedFileBytes = 1 << 20
+
+// maxLFSDownloadBytes is the most read of a Git LFS object, however large its pointer says it is.
+const maxLFSDownloadBytes = 100 << 20
+
+// lfsDownloadClient downloads Git LFS objects from their signed URLs, which are not on the
+// GitHub API host and so are fetched without the GitHub clients.
+var lfsDownloadClient = &http.Client{Timeout: 2 * time.Minute}
+
+// lfsPointerVersion is the first line of Git LFS pointer files.
+const lfsPointerVersion = "version https://git-lfs.github.com/spec/v1"
+
+// SubmoduleFile is returned by get_file_contents for a path that is a submodule.
+type SubmoduleFile struct {
+	Type   string `json:"type"`
+	Path   string `json:"path"`
+	SHA    string `json:"sha"`
+	GitURL string `json:"git_url,omitempty"`
+	Owner  string `json:"owner,omitempty"`
+	Repo   string `json:"repo,omitempty"`
+}
+
+// SymlinkFile is returned by get_file_contents for a path that is a symbolic link.
+type SymlinkFile struct {
+	Type   string `json:"type"`
+	Path   string `json:"path"`
+	SHA    string `json:"sha,omitempty"`
+	Target string `json:"target"`
+}
+
+// PathMatches is returned by get_file_contents for a path that doesn't exist, listing the paths
+// that end with it at the resolved ref.
+type PathMatches struct {
+	Message string   `json:"message"`
+	Ref     string   `json:"ref,omitempty"`
+	SHA     string   `json:"sha"`
+	Matches []string `json:"matches"`
+}
+
+func newSubmoduleFile(path string, content *github.RepositoryContent) SubmoduleFile {
+	submodule := SubmoduleFile{
+		Type:   "submodule",
+		Path:   path,
+		SHA:    content.GetSHA(),
+		GitURL: content.GetSubmoduleGitURL(),
+	}
+	// Both https://host/owner/repo.git and git@host:owner/repo.git end with owner/repo
+	gitURL := strings.TrimSuffix(strings.TrimSuffix(submodule.GitURL, "/"), ".git")
+	gitURL = strings.ReplaceAll(gitURL, ":", "/")
+	if parts := strings.Split(gitURL, "/"); len(parts) >= 2 && !strings.HasPrefix(submodule.GitURL, ".") {
+		submodule.Owner, submodule.Repo = parts[len(parts)-2], parts[len(parts)-1]
+	}
+	return submodule
+}
+
+// fileResourceURI returns the repo:// URI of a file at a ref or SHA.
+func fileResourceURI(owner, repo, ref, sha, path string) (string, error) {
+	switch {
+	case sha != "":
+		return url.JoinPath("repo://", owner, repo, "sha", sha, "contents", path)
+	case ref != "":
+		return url.JoinPath("repo://", owner, repo, ref, "contents", path)
+	default:
+		return url.JoinPath("repo://", owner, repo, "contents", path)
+	}
+}
+
+// fileRange is the part of a file get_file_contents returns.
+type fileRange struct {
+	startLine int
+	endLine   int
+	maxBytes  int
+}
+
+// getLargeFileContents streams a file that is too large for the Contents API from the
+// Git blobs API.
+func getLargeFileContents(ctx context.Context, client *github.Client, owner, repo, sha string, size int, resourceURI string, lines fileRange) (*mcp.CallToolResult, error) {
+	req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/git/blobs/%s", owner, repo, sha), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github.raw")
+
+	resp, err := client.BareDo(ctx, req)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get blob", resp, err), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return streamedFileResult(resp.Body, "large", fmt.Sprintf("(SHA: %s, %d bytes)", sha, size), size, resourceURI, lines)
+}
+
+// lfsPointer is the content of a Git LFS pointer file.
+type lfsPointer struct {
+	OID  string
+	Size int
+}
+
+// parseLFSPointer reports whether content is a Git LFS pointer and parses it.
+func parseLFSPointer(content []byte) (lfsPointer, bool) {
+	var pointer lfsPointer
+	if len(content) > 1024 || !bytes.HasPrefix(content, []byte(lfsPointerVersion+"\n")) {
+		return pointer, false
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "oid":
+			pointer.OID = strings.TrimPrefix(value, "sha256:")
+		case "size":
+			pointer.Size, _ = strconv.Atoi(value)
+		}
+	}
+	return pointer, pointer.OID != ""
+}
+
+type lfsBatchResponse struct {
+	Objects []struct {
+		OID     string `json:"oid"`
+		Actions struct {
+			Download *struct {
+				Href   string            `json:"href"`
+				Header map[string]string `json:"header"`
+			} `json:"download"`
+		} `json:"actions"`
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"objects"`
+}
+
+// getLFSFileContents downloads the object a Git LFS pointer points to and returns the
+// requested lines of it.
+func getLFSFileContents(ctx context.Context, client *github.Client, owner, repo string, pointer lfsPointer, resourceURI string, lines fileRange) (*mcp.CallToolResult, error) {
+	object, err := downloadLFSObject(ctx, client, owner, repo, pointer)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	defer func() { _ = object.Close() }()
+
+	return streamedFileResult(object, "Git LFS", fmt.Sprintf("(OID: sha256:%s, %d bytes)", pointer.OID, pointer.Size), pointer.Size, resourceURI, lines)
+}
+
+// downloadLFSObject opens the object a Git LFS pointer points to, using the LFS batch API
+// of the repository to get its download URL. At most the size in the pointer is read of it.
+func downloadLFSObject(ctx context.Context, client *github.Client, owner, repo string, pointer lfsPointer) (io.ReadCloser, error) {
+	repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to get repository", resp, err)
+		return nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	batchURL := strings.TrimSuffix(repository.GetCloneURL(), "/") + "/info/lfs/objects/batch"
+	req, err := client.NewRequest(http.MethodPost, batchURL, map[string]any{
+		"operation": "download",
+		"transfers": []string{"basic"},
+		"objects":   []map[string]any{{"oid": pointer.OID, "size": pointer.Size}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.git-lfs+json")
+	req.Header.Set("Content-Type", "application/vnd.git-lfs+json")
+
+	var batch lfsBatchResponse
+	batchResp, err := client.Do(ctx, req, &batch)
+	if err != nil {
+		_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to resolve Git LFS object", batchResp, err)
+		return nil, fmt.Errorf("failed to resolve Git LFS object: %w", err)
+	}
+	defer func() { _ = batchResp.Body.Close() }()
+
+	if len(batch.Objects) == 0 {
+		return nil, fmt.Errorf("Git LFS object %s not found", pointer.OID)
+	}
+	object := batch.Objects[0]
+	if object.Error != nil {
+		return nil, fmt.Errorf("failed to resolve Git LFS object %s: %s", pointer.OID, object.Error.Message)
+	}
+	if object.Actions.Download == nil {
+		return nil, fmt.Errorf("Git LFS object %s has no download URL", pointer.OID)
+	}
+
+	// The download URL is signed, so it is fetched without the GitHub credentials
+	downloadReq, err := http.NewRequestWithContext(ctx, http.MethodGet, object.Actions.Download.Href, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, value := range object.Actions.Download.Header {
+		downloadReq.Header.Set(key, value)
+	}
+	downloadResp, err := lfsDownloadClient.Do(downloadReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download Git LFS object %s: %w", pointer.OID, err)
+	}
+	if downloadResp.StatusCode != http.StatusOK {
+		_ = downloadResp.Body.Close()
+		return nil, fmt.Errorf("failed to download Git LFS object %s: %s", pointer.OID, downloadResp.Status)
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(downloadResp.Body, int64(min(pointer.Size, maxLFSDownloadBytes))), downloadResp.Body}, nil
+}
+
+// streamedFileResult returns the requested lines of a text file read from r, or a
+// binary file if it is small enough. kind and details describe the file in messages.
+func streamedFileResult(r io.Reader, kind, details string, size int, resourceURI string, lines fileRange) (*mcp.CallToolResult, error) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(512)
+	contentType := http.DetectContentType(head)
+
+	if !strings.HasPrefix(contentType, "text/") {
+		if size > maxStreamedFileBytes {
+			return MarshalledTextResult(MessageResponse{Message: fmt.Sprintf("%s binary file %s of type %s is larger than %d bytes, so its contents can't be returned", kind, details, contentType, maxStreamedFileBytes)}), nil
+		}
+		body, err := io.ReadAll(io.LimitReader(br, maxStreamedFileBytes))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		return mcp.NewToolResultResource(fmt.Sprintf("successfully downloaded %s binary file %s", kind, details), mcp.BlobResourceContents{
+			URI:      resourceURI,
+			Blob:     base64.StdEncoding.EncodeToString(body),
+			MIMEType: contentType,
+		}), nil
+	}
+
+	maxBytes := maxStreamedFileBytes
+	if lines.maxBytes > 0 {
+		maxBytes = min(lines.maxBytes, maxStreamedFileBytes)
+	}
+	slice, err := readTextLines(br, lines.startLine, lines.endLine, maxBytes)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	message := fmt.Sprintf("successfully downloaded %s text file %s", kind, details)
+	if slice.Partial {
+		message = fmt.Sprintf("successfully downloaded lines %d-%d of %s text file %s", slice.StartLine, slice.EndLine, kind, details)
+		if slice.TotalLines == 0 {
+			message += fmt.Sprintf(", read on from line %d with start_line", slice.EndLine+1)
+		}
+	}
+	return mcp.NewToolResultResource(message, mcp.TextResourceContents{
+		URI:      resourceURI,
+		Text:     slice.Text,
+		MIMEType: contentType,
+	}), nil
+}
+
+// readTextLines reads the lines startLine to endLine from r, like sliceTextFile, but
+// stops reading once it has them. TotalLines is only set if r was read to the end.
+func readTextLines(r *bufio.Reader, startLine, endLine, maxBytes int) (textSlice, error) {
+	slice := textSlice{StartLine: max(startLine, 1)}
+	var buf bytes.Buffer
+	line, lineStart := 1, 0
+	for {
+		chunk, err := r.ReadSlice('\n')
+		if err != nil && !errors.Is(err, bufio.ErrBufferFull) && !errors.Is(err, io.EOF) {
+			return textSlice{}, fmt.Errorf("failed to read file: %w", err)
+		}
+		if line >= slice.StartLine && len(chunk) > 0 {
+			if buf.Len()+len(chunk) > maxBytes {
+				// Keep whole lines, unless not even the first one fits
+				if lineStart > 0 {
+					buf.Truncate(lineStart)
+					slice.EndLine = line - 1
+				} else {
+					buf.Write(chunk[:runeBoundary(string(chunk), maxBytes-buf.Len())])
+					slice.EndLine = line
+				}
+				slice.Text = buf.String()
+				slice.Partial = true
+				return slice, nil
+			}
+			buf.Write(chunk)
+			slice.EndLine = line
+		}
+
+		switch {
+		case errors.Is(err, bufio.ErrBufferFull):
+			// The rest of the line is still to come
+			continue
+		case errors.Is(err, io.EOF):
+			slice.TotalLines = line
+			if len(chunk) == 0 {
+				slice.TotalLines--
+			}
+			if startLine > slice.TotalLines {
+				return textSlice{}, fmt.Errorf("start_line %d is past the end of the file, which has %d lines", startLine, slice.TotalLines)
+			}
+			slice.Text = buf.String()
+			slice.Partial = slice.StartLine > 1 || slice.EndLine < slice.TotalLines
+			return slice, nil
+		}
+
+		if endLine > 0 && line == endLine {
+			slice.Text = buf.String()
+			slice.Partial = true
+			return slice, nil
+		}
+		line++
+		lineStart = buf.Len()
+	}
+}
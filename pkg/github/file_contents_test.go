@@ -0,0 +1,301 @@
+package github
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetFileContents_SpecialFiles(t *testing.T) {
+	lfsContent := "first line\nsecond line\nthird line\n"
+	lfsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Download-Token") != "secret" || r.Header.Get("Authorization") != "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(lfsContent))
+	}))
+	defer lfsServer.Close()
+
+	contentsHandler := func(content *github.RepositoryContent) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.GetReposContentsByOwnerByRepoByPath,
+			mockResponse(t, http.StatusOK, content),
+		)
+	}
+	lfsPointerHandler := mock.WithRequestMatchHandler(
+		raw.GetRawReposContentsByOwnerByRepoBySHAByPath,
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			_, _ = w.Write([]byte("version https://git-lfs.github.com/spec/v1\noid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393\nsize 34\n"))
+		}),
+	)
+	repoHandler := mock.WithRequestMatchHandler(
+		mock.GetReposByOwnerByRepo,
+		mockResponse(t, http.StatusOK, &github.Repository{CloneURL: github.Ptr("https://github.com/owner/repo.git")}),
+	)
+	lfsBatchHandler := func(object map[string]any) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.EndpointPattern{Pattern: "/owner/repo.git/info/lfs/objects/batch", Method: "POST"},
+			expectRequestBody(t, map[string]any{
+				"operation": "download",
+				"transfers": []any{"basic"},
+				"objects": []any{
+					map[string]any{"oid": "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393", "size": float64(34)},
+				},
+			}).andThen(
+				mockResponse(t, http.StatusOK, map[string]any{"objects": []any{object}}),
+			),
+		)
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedJSON     string
+		expectedText     string
+		expectedResource mcp.TextResourceContents
+	}{
+		{
+			name: "submodule",
+			mockedClient: mock.NewMockedHTTPClient(contentsHandler(&github.RepositoryContent{
+				Type:            github.Ptr("submodule"),
+				Path:            github.Ptr("third_party/lib"),
+				SHA:             github.Ptr("sub123"),
+				SubmoduleGitURL: github.Ptr("https://github.com/other/lib.git"),
+			})),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "path": "third_party/lib", "sha": "commit123"},
+			expectedJSON: `{"type":"submodule","path":"third_party/lib","sha":"sub123",` +
+				`"git_url":"https://github.com/other/lib.git","owner":"other","repo":"lib"}`,
+		},
+		{
+			name: "symlink",
+			mockedClient: mock.NewMockedHTTPClient(contentsHandler(&github.RepositoryContent{
+				Type:   github.Ptr("symlink"),
+				Path:   github.Ptr("docs/latest"),
+				SHA:    github.Ptr("link123"),
+				Target: github.Ptr("v2"),
+			})),
+			requestArgs:  map[string]any{"owner": "owner", "repo": "repo", "path": "docs/latest", "sha": "commit123"},
+			expectedJSON: `{"type":"symlink","path":"docs/latest","sha":"link123","target":"v2"}`,
+		},
+		{
+			name: "symlink to a normal file",
+			mockedClient: mock.NewMockedHTTPClient(contentsHandler(&github.RepositoryContent{
+				Type: github.Ptr("file"),
+				Path: github.Ptr("docs/README.md"),
+				SHA:  github.Ptr("file123"),
+			})),
+			requestArgs:  map[string]any{"owner": "owner", "repo": "repo", "path": "README.md", "sha": "commit123"},
+			expectedJSON: `{"type":"symlink","path":"README.md","target":"docs/README.md"}`,
+		},
+		{
+			name: "large file read from the blobs API",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectQueryParams(t, map[string]string{"ref": "commit123"}).andThen(
+						mockResponse(t, http.StatusOK, &github.RepositoryContent{
+							Type: github.Ptr("file"),
+							Path: github.Ptr("data.csv"),
+							SHA:  github.Ptr("blob123"),
+							Size: github.Ptr(5 << 20),
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					expectPath(t, "/repos/owner/repo/git/blobs/blob123").andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							_, _ = w.Write([]byte("a,b\n1,2\n3,4\n5,6\n"))
+						}),
+					),
+				),
+			),
+			requestArgs:  map[string]any{"owner": "owner", "repo": "repo", "path": "data.csv", "sha": "commit123", "start_line": float64(2), "end_line": float64(3)},
+			expectedText: "successfully downloaded lines 2-3 of large text file (SHA: blob123, 5242880 bytes), read on from line 4 with start_line",
+			expectedResource: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/sha/commit123/contents/data.csv",
+				Text:     "1,2\n3,4\n",
+				MIMEType: "text/plain; charset=utf-8",
+			},
+		},
+		{
+			name: "Git LFS file",
+			mockedClient: mock.NewMockedHTTPClient(
+				contentsHandler(&github.RepositoryContent{Type: github.Ptr("file"), Path: github.Ptr("notes.txt"), SHA: github.Ptr("ptr123"), Size: github.Ptr(130)}),
+				lfsPointerHandler,
+				repoHandler,
+				lfsBatchHandler(map[string]any{
+					"oid": "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393",
+					"actions": map[string]any{
+						"download": map[string]any{
+							"href":   lfsServer.URL + "/objects/4d7a",
+							"header": map[string]any{"X-Download-Token": "secret"},
+						},
+					},
+				}),
+			),
+			requestArgs:  map[string]any{"owner": "owner", "repo": "repo", "path": "notes.txt", "sha": "commit123"},
+			expectedText: "successfully downloaded Git LFS text file (OID: sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393, 34 bytes)",
+			expectedResource: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/sha/commit123/contents/notes.txt",
+				Text:     lfsContent,
+				MIMEType: "text/plain; charset=utf-8",
+			},
+		},
+		{
+			name: "missing Git LFS object",
+			mockedClient: mock.NewMockedHTTPClient(
+				contentsHandler(&github.RepositoryContent{Type: github.Ptr("file"), Path: github.Ptr("notes.txt"), SHA: github.Ptr("ptr123"), Size: github.Ptr(130)}),
+				lfsPointerHandler,
+				repoHandler,
+				lfsBatchHandler(map[string]any{
+					"oid":   "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393",
+					"error": map[string]any{"code": 404, "message": "Object does not exist"},
+				}),
+			),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "path": "notes.txt", "sha": "commit123"},
+			expectError:    true,
+			expectedErrMsg: "failed to resolve Git LFS object 4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393: Object does not exist",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			mockRawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+			_, handler := GetFileContents(stubGetClientFn(client), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			if tc.expectedJSON != "" {
+				textContent := getTextResult(t, result)
+				assert.JSONEq(t, tc.expectedJSON, textContent.Text)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+			assert.Equal(t, tc.expectedResource, getTextResourceResult(t, result))
+		})
+	}
+}
+
+func Test_ReadTextLines(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		startLine     int
+		endLine       int
+		maxBytes      int
+		expectedSlice textSlice
+		expectedErr   string
+	}{
+		{
+			name:          "whole file",
+			content:       "one\ntwo\n",
+			maxBytes:      100,
+			expectedSlice: textSlice{Text: "one\ntwo\n", StartLine: 1, EndLine: 2, TotalLines: 2},
+		},
+		{
+			name:          "last lines",
+			content:       "one\ntwo\nthree",
+			startLine:     2,
+			maxBytes:      100,
+			expectedSlice: textSlice{Text: "two\nthree", StartLine: 2, EndLine: 3, TotalLines: 3, Partial: true},
+		},
+		{
+			name:          "stops reading after the end line",
+			content:       "one\ntwo\nthree\n",
+			endLine:       2,
+			maxBytes:      100,
+			expectedSlice: textSlice{Text: "one\ntwo\n", StartLine: 1, EndLine: 2, Partial: true},
+		},
+		{
+			name:          "cut at a line boundary",
+			content:       "one\ntwo\nthree\n",
+			maxBytes:      9,
+			expectedSlice: textSlice{Text: "one\ntwo\n", StartLine: 1, EndLine: 2, Partial: true},
+		},
+		{
+			name:          "cut within a line longer than the read buffer",
+			content:       strings.Repeat("x", 5000) + "\nnext\n",
+			maxBytes:      4500,
+			expectedSlice: textSlice{Text: strings.Repeat("x", 4500), StartLine: 1, EndLine: 1, Partial: true},
+		},
+		{
+			name:          "empty file",
+			maxBytes:      100,
+			expectedSlice: textSlice{StartLine: 1},
+		},
+		{
+			name:        "start line past the end",
+			content:     "one\n",
+			startLine:   3,
+			maxBytes:    100,
+			expectedErr: "start_line 3 is past the end of the file, which has 1 lines",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			slice, err := readTextLines(bufio.NewReader(strings.NewReader(tc.content)), tc.startLine, tc.endLine, tc.maxBytes)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSlice, slice)
+		})
+	}
+}
+
+func Test_ParseLFSPointer(t *testing.T) {
+	pointer, ok := parseLFSPointer([]byte("version https://git-lfs.github.com/spec/v1\noid sha256:abc123\nsize 42\n"))
+	require.True(t, ok)
+	assert.Equal(t, lfsPointer{OID: "abc123", Size: 42}, pointer)
+
+	_, ok = parseLFSPointer([]byte("version 1.0\noid sha256:abc123\n"))
+	assert.False(t, ok)
+
+	_, ok = parseLFSPointer([]byte("version https://git-lfs.github.com/spec/v1\n" + strings.Repeat("x", 2000)))
+	assert.False(t, ok)
+
+}
+
+func Test_NewSubmoduleFile(t *testing.T) {
+	for gitURL, expected := range map[string][2]string{
+		"https://github.com/other/lib.git": {"other", "lib"},
+		"git@github.com:other/lib.git":     {"other", "lib"},
+		"../lib.git":                       {"", ""},
+	} {
+		submodule := newSubmoduleFile("lib", &github.RepositoryContent{SubmoduleGitURL: github.Ptr(gitURL)})
+		assert.Equal(t, expected, [2]string{submodule.Owner, submodule.Repo}, gitURL)
+	}
+}
@@ -28,6 +28,7 @@ func ListGists(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				mcp.Description("Only gists updated after this time (ISO 8601 timestamp)"),
 			),
 			WithPagination(),
+			WithOutputSchema[ListResult[*github.Gist]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			username, err := OptionalParam[string](request, "username")
@@ -80,7 +81,7 @@ func ListGists(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list gists: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(gists)
+			r, err := json.Marshal(restListResult(gists, resp))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -112,6 +113,7 @@ func CreateGist(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				mcp.Description("Whether the gist is public"),
 				mcp.DefaultBool(false),
 			),
+			WithOutputSchema[MinimalResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			description, err := OptionalParam[string](request, "description")
@@ -202,6 +204,7 @@ func UpdateGist(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				mcp.Required(),
 				mcp.Description("Content for the file"),
 			),
+			WithOutputSchema[MinimalResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			gistID, err := RequiredParam[string](request, "gist_id")
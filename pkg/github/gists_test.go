@@ -177,9 +177,10 @@ func Test_ListGists(t *testing.T) {
 			textContent := getTextResult(t, result)
 
 			// Unmarshal and verify the result
-			var returnedGists []*github.Gist
-			err = json.Unmarshal([]byte(textContent.Text), &returnedGists)
+			var response ListResult[*github.Gist]
+			err = json.Unmarshal([]byte(textContent.Text), &response)
 			require.NoError(t, err)
+			returnedGists := response.Items
 
 			assert.Len(t, returnedGists, len(tc.expectedGists))
 			for i, gist := range returnedGists {
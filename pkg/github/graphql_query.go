@@ -0,0 +1,137 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GetGQLRawClientFn is a function type that returns a GQLRawClient instance.
+type GetGQLRawClientFn func(context.Context) (*GQLRawClient, error)
+
+// GQLRawClient sends GraphQL documents as written, for queries that aren't known
+// ahead of time and so can't be expressed as githubv4 structs. It shares the
+// HTTP client, and with it the authentication, of the githubv4 client.
+type GQLRawClient struct {
+	httpClient *http.Client
+	url        string
+}
+
+// NewGQLRawClient creates a GQLRawClient that posts to the GraphQL endpoint at url.
+func NewGQLRawClient(httpClient *http.Client, url string) *GQLRawClient {
+	return &GQLRawClient{httpClient: httpClient, url: url}
+}
+
+// GQLRawError is an entry of the errors list of a GraphQL response.
+type GQLRawError struct {
+	Message string `json:"message"`
+	Type    string `json:"type,omitempty"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// GQLRawResponse is the body of a GraphQL response.
+type GQLRawResponse struct {
+	Data   json.RawMessage `json:"data,omitempty"`
+	Errors []GQLRawError   `json:"errors,omitempty"`
+}
+
+// Query posts the query with its variables and decodes the response.
+func (c *GQLRawClient) Query(ctx context.Context, query string, variables map[string]any) (*GQLRawResponse, error) {
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal query: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("non-200 OK status code: %s body: %q", resp.Status, respBody)
+	}
+
+	var result GQLRawResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &result, nil
+}
+
+// GraphQLQuery creates a tool to run a read-only GraphQL query against the GitHub API.
+func GraphQLQuery(getGQLRawClient GetGQLRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("graphql_query",
+			mcp.WithDescription(t("TOOL_GRAPHQL_QUERY_DESCRIPTION", fmt.Sprintf("Run a read-only query against the GitHub GraphQL API and return the JSON result. Use this only for questions no other tool can answer. Mutations are rejected, fields may be nested at most %d deep, first/last arguments may be at most %d and the query may request at most %d nodes in total.", GraphQLMaxDepth, GraphQLMaxPageSize, GraphQLMaxNodes))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GRAPHQL_QUERY_USER_TITLE", "Run GraphQL query"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("GraphQL document with a single query operation, and optionally fragments"),
+			),
+			mcp.WithObject("variables",
+				mcp.Description("Values for the variables of the query"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			query, err := RequiredParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var variables map[string]any
+			if requestVariables, ok := request.GetArguments()["variables"]; ok && requestVariables != nil {
+				variablesMap, ok := requestVariables.(map[string]any)
+				if !ok {
+					return mcp.NewToolResultError("variables must be an object"), nil
+				}
+				variables = variablesMap
+			}
+
+			if err := ValidateGraphQLQuery(query, variables); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid query: %s", err)), nil
+			}
+
+			client, err := getGQLRawClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GraphQL client: %w", err)
+			}
+
+			result, err := client.Query(ctx, query, variables)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to run query", err), nil
+			}
+
+			// Without data there is nothing to return but the errors
+			if len(result.Data) == 0 || string(result.Data) == "null" {
+				messages := make([]string, 0, len(result.Errors))
+				for _, e := range result.Errors {
+					messages = append(messages, e.Message)
+				}
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to run query", fmt.Errorf("%s", strings.Join(messages, "; "))), nil
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubGetGQLRawClientFn(url string) GetGQLRawClientFn {
+	return func(_ context.Context) (*GQLRawClient, error) {
+		return NewGQLRawClient(http.DefaultClient, url), nil
+	}
+}
+
+func Test_GraphQLQuery(t *testing.T) {
+	tool, _ := GraphQLQuery(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "graphql_query", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "graphql_query tool should be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "variables")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"query"})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		response       string
+		status         int
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+		expectedBody   map[string]any
+	}{
+		{
+			name: "runs query with variables",
+			requestArgs: map[string]any{
+				"query":     "query($owner: String!, $n: Int!) { repositoryOwner(login: $owner) { repositories(first: $n) { nodes { name } } } }",
+				"variables": map[string]any{"owner": "octo", "n": float64(2)},
+			},
+			response:     `{"data":{"repositoryOwner":{"repositories":{"nodes":[{"name":"a"},{"name":"b"}]}}}}`,
+			status:       http.StatusOK,
+			expectedText: `{"data":{"repositoryOwner":{"repositories":{"nodes":[{"name":"a"},{"name":"b"}]}}}}`,
+			expectedBody: map[string]any{"owner": "octo", "n": float64(2)},
+		},
+		{
+			name: "returns partial data with errors",
+			requestArgs: map[string]any{
+				"query": "{ viewer { login secret } }",
+			},
+			response:     `{"data":{"viewer":{"login":"octo","secret":null}},"errors":[{"message":"no access","path":["viewer","secret"]}]}`,
+			status:       http.StatusOK,
+			expectedText: `{"data":{"viewer":{"login":"octo","secret":null}},"errors":[{"message":"no access","path":["viewer","secret"]}]}`,
+		},
+		{
+			name: "rejects mutations before calling the API",
+			requestArgs: map[string]any{
+				"query": `mutation { addStar(input: {starrableId: "1"}) { clientMutationId } }`,
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid query: mutations are not allowed",
+		},
+		{
+			name: "rejects variables that aren't an object",
+			requestArgs: map[string]any{
+				"query":     "{ viewer { login } }",
+				"variables": "n=1",
+			},
+			expectError:    true,
+			expectedErrMsg: "variables must be an object",
+		},
+		{
+			name: "reports errors without data",
+			requestArgs: map[string]any{
+				"query": "{ viewer { nope } }",
+			},
+			response:       `{"errors":[{"message":"Field 'nope' doesn't exist on type 'User'"}]}`,
+			status:         http.StatusOK,
+			expectError:    true,
+			expectedErrMsg: "Field 'nope' doesn't exist on type 'User'",
+		},
+		{
+			name: "reports HTTP errors",
+			requestArgs: map[string]any{
+				"query": "{ viewer { login } }",
+			},
+			response:       `{"message":"Bad credentials"}`,
+			status:         http.StatusUnauthorized,
+			expectError:    true,
+			expectedErrMsg: "Bad credentials",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			called := false
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				var body struct {
+					Query     string         `json:"query"`
+					Variables map[string]any `json:"variables"`
+				}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, tc.requestArgs["query"], body.Query)
+				if tc.expectedBody != nil {
+					assert.Equal(t, tc.expectedBody, body.Variables)
+				}
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer srv.Close()
+
+			_, handler := GraphQLQuery(stubGetGQLRawClientFn(srv.URL), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				assert.Equal(t, tc.response != "", called)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.JSONEq(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
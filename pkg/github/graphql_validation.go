@@ -0,0 +1,667 @@
+package github
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// GraphQLMaxDepth is the deepest nesting of fields a graphql_query document may select.
+	GraphQLMaxDepth = 12
+	// GraphQLMaxNodes is the most nodes a graphql_query document may request, counted the way
+	// GitHub does: every connection multiplies its first/last argument by those of its parents.
+	GraphQLMaxNodes = 10000
+	// GraphQLMaxPageSize is the largest first/last argument a connection may ask for.
+	GraphQLMaxPageSize = 100
+	// GraphQLMaxSelections is the most selections validating a graphql_query document may visit,
+	// counting those of a fragment every time it is spread, so that fragments spreading each other
+	// several times can't make validation take exponential time.
+	GraphQLMaxSelections = 10000
+)
+
+type gqlTokenKind int
+
+const (
+	gqlEOF gqlTokenKind = iota
+	gqlPunctuator
+	gqlName
+	gqlInt
+	gqlFloat
+	gqlString
+)
+
+type gqlToken struct {
+	kind  gqlTokenKind
+	value string
+	pos   int
+}
+
+type gqlValueKind int
+
+const (
+	gqlValueOther gqlValueKind = iota
+	gqlValueInt
+	gqlValueVariable
+)
+
+// gqlValue keeps just enough of an argument value to resolve page sizes.
+type gqlValue struct {
+	kind gqlValueKind
+	raw  string
+}
+
+// gqlSelection is a field, a fragment spread or an inline fragment.
+// Spreads have a fragment name, inline fragments neither a field nor a fragment name.
+type gqlSelection struct {
+	field      string
+	fragment   string
+	args       map[string]gqlValue
+	selections []gqlSelection
+}
+
+type gqlOperation struct {
+	kind       string
+	defaults   map[string]gqlValue
+	selections []gqlSelection
+}
+
+type gqlDocument struct {
+	operations []*gqlOperation
+	fragments  map[string][]gqlSelection
+}
+
+// ValidateGraphQLQuery parses a GraphQL document and checks that it is a single read-only
+// query within the depth, node and page size limits. Variables are used to resolve
+// page sizes given as $variables.
+func ValidateGraphQLQuery(query string, variables map[string]any) error {
+	doc, err := parseGraphQLDocument(query)
+	if err != nil {
+		return err
+	}
+	if len(doc.operations) != 1 {
+		return fmt.Errorf("query must contain exactly one operation, found %d", len(doc.operations))
+	}
+
+	op := doc.operations[0]
+	switch op.kind {
+	case "query":
+	case "mutation":
+		return fmt.Errorf("mutations are not allowed, only queries can be run")
+	default:
+		return fmt.Errorf("%s operations are not allowed, only queries can be run", op.kind)
+	}
+
+	v := &gqlValidator{
+		doc:       doc,
+		op:        op,
+		variables: variables,
+		visiting:  make(map[string]bool),
+	}
+	return v.walk(op.selections, 0, 1)
+}
+
+type gqlValidator struct {
+	doc       *gqlDocument
+	op        *gqlOperation
+	variables map[string]any
+	visiting  map[string]bool
+	nodes     int
+	visited   int
+}
+
+func (v *gqlValidator) walk(selections []gqlSelection, depth, multiplier int) error {
+	for _, sel := range selections {
+		v.visited++
+		if v.visited > GraphQLMaxSelections {
+			return fmt.Errorf("query selects more than %d fields, counting those of fragments every time they are spread", GraphQLMaxSelections)
+		}
+		switch {
+		case sel.fragment != "":
+			fragment, ok := v.doc.fragments[sel.fragment]
+			if !ok {
+				return fmt.Errorf("unknown fragment %q", sel.fragment)
+			}
+			if v.visiting[sel.fragment] {
+				return fmt.Errorf("fragment %q spreads itself", sel.fragment)
+			}
+			v.visiting[sel.fragment] = true
+			if err := v.walk(fragment, depth, multiplier); err != nil {
+				return err
+			}
+			delete(v.visiting, sel.fragment)
+		case sel.field == "":
+			if err := v.walk(sel.selections, depth, multiplier); err != nil {
+				return err
+			}
+		default:
+			if depth+1 > GraphQLMaxDepth {
+				return fmt.Errorf("query is nested deeper than %d fields", GraphQLMaxDepth)
+			}
+			m := multiplier
+			for _, name := range []string{"first", "last"} {
+				value, ok := sel.args[name]
+				if !ok {
+					continue
+				}
+				n, err := v.pageSize(name, value)
+				if err != nil {
+					return fmt.Errorf("field %q: %w", sel.field, err)
+				}
+				if n > GraphQLMaxPageSize {
+					return fmt.Errorf("field %q: %s must not be greater than %d", sel.field, name, GraphQLMaxPageSize)
+				}
+				if n > 0 && n*multiplier > m {
+					m = n * multiplier
+				}
+			}
+			if m != multiplier {
+				v.nodes += m
+				if v.nodes > GraphQLMaxNodes {
+					return fmt.Errorf("query requests more than %d nodes; lower first/last arguments or split it up", GraphQLMaxNodes)
+				}
+			}
+			if err := v.walk(sel.selections, depth+1, m); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pageSize resolves a first/last argument to an integer, looking up variables
+// in the request and then in the defaults of the operation.
+func (v *gqlValidator) pageSize(name string, value gqlValue) (int, error) {
+	switch value.kind {
+	case gqlValueInt:
+		return parseGraphQLInt(name, value.raw)
+	case gqlValueVariable:
+		if given, ok := v.variables[value.raw]; ok && given != nil {
+			f, ok := given.(float64)
+			if !ok || f != math.Trunc(f) {
+				return 0, fmt.Errorf("variable $%s used for %s must be an integer", value.raw, name)
+			}
+			return int(f), nil
+		}
+		if def, ok := v.op.defaults[value.raw]; ok && def.kind == gqlValueInt {
+			return parseGraphQLInt(name, def.raw)
+		}
+		return 0, fmt.Errorf("variable $%s used for %s must be set", value.raw, name)
+	default:
+		return 0, fmt.Errorf("%s must be an integer", name)
+	}
+}
+
+func parseGraphQLInt(name, raw string) (int, error) {
+	var n int
+	if _, err := fmt.Sscanf(raw, "%d", &n); err != nil {
+		return 0, fmt.Errorf("%s must be an integer", name)
+	}
+	return n, nil
+}
+
+// gqlParser is a recursive descent parser for executable GraphQL documents.
+// It keeps only what ValidateGraphQLQuery needs and leaves checking the
+// document against the schema to the API.
+type gqlParser struct {
+	src string
+	pos int
+	tok gqlToken
+}
+
+func parseGraphQLDocument(src string) (*gqlDocument, error) {
+	p := &gqlParser{src: src}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	doc := &gqlDocument{fragments: make(map[string][]gqlSelection)}
+	for p.tok.kind != gqlEOF {
+		switch {
+		case p.is(gqlPunctuator, "{"):
+			selections, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &gqlOperation{kind: "query", selections: selections})
+		case p.is(gqlName, "query"), p.is(gqlName, "mutation"), p.is(gqlName, "subscription"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case p.is(gqlName, "fragment"):
+			name, selections, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.fragments[name]; ok {
+				return nil, fmt.Errorf("fragment %q is defined more than once", name)
+			}
+			doc.fragments[name] = selections
+		default:
+			return nil, p.unexpected("a query or fragment definition")
+		}
+	}
+	return doc, nil
+}
+
+func (p *gqlParser) parseOperation() (*gqlOperation, error) {
+	op := &gqlOperation{kind: p.tok.value, defaults: make(map[string]gqlValue)}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == gqlName {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	if p.is(gqlPunctuator, "(") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		for !p.is(gqlPunctuator, ")") {
+			if err := p.expect(gqlPunctuator, "$"); err != nil {
+				return nil, err
+			}
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(gqlPunctuator, ":"); err != nil {
+				return nil, err
+			}
+			if err := p.parseType(); err != nil {
+				return nil, err
+			}
+			if p.is(gqlPunctuator, "=") {
+				if err := p.next(); err != nil {
+					return nil, err
+				}
+				value, err := p.parseValue()
+				if err != nil {
+					return nil, err
+				}
+				op.defaults[name] = value
+			}
+			if err := p.parseDirectives(); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selections = selections
+	return op, nil
+}
+
+func (p *gqlParser) parseFragment() (string, []gqlSelection, error) {
+	if err := p.next(); err != nil {
+		return "", nil, err
+	}
+	if p.is(gqlName, "on") {
+		return "", nil, p.unexpected("a fragment name")
+	}
+	name, err := p.expectName()
+	if err != nil {
+		return "", nil, err
+	}
+	if err := p.expect(gqlName, "on"); err != nil {
+		return "", nil, err
+	}
+	if _, err := p.expectName(); err != nil {
+		return "", nil, err
+	}
+	if err := p.parseDirectives(); err != nil {
+		return "", nil, err
+	}
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return "", nil, err
+	}
+	return name, selections, nil
+}
+
+func (p *gqlParser) parseSelectionSet() ([]gqlSelection, error) {
+	if err := p.expect(gqlPunctuator, "{"); err != nil {
+		return nil, err
+	}
+	var selections []gqlSelection
+	for !p.is(gqlPunctuator, "}") {
+		sel, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, sel)
+	}
+	if len(selections) == 0 {
+		return nil, fmt.Errorf("empty selection set at position %d", p.tok.pos)
+	}
+	return selections, p.next()
+}
+
+func (p *gqlParser) parseSelection() (gqlSelection, error) {
+	var sel gqlSelection
+	if p.is(gqlPunctuator, "...") {
+		if err := p.next(); err != nil {
+			return sel, err
+		}
+		if p.tok.kind == gqlName && p.tok.value != "on" {
+			sel.fragment = p.tok.value
+			if err := p.next(); err != nil {
+				return sel, err
+			}
+			return sel, p.parseDirectives()
+		}
+		if p.is(gqlName, "on") {
+			if err := p.next(); err != nil {
+				return sel, err
+			}
+			if _, err := p.expectName(); err != nil {
+				return sel, err
+			}
+		}
+		if err := p.parseDirectives(); err != nil {
+			return sel, err
+		}
+		selections, err := p.parseSelectionSet()
+		sel.selections = selections
+		return sel, err
+	}
+
+	name, err := p.expectName()
+	if err != nil {
+		return sel, err
+	}
+	if p.is(gqlPunctuator, ":") {
+		if err := p.next(); err != nil {
+			return sel, err
+		}
+		if name, err = p.expectName(); err != nil {
+			return sel, err
+		}
+	}
+	sel.field = name
+	if p.is(gqlPunctuator, "(") {
+		if sel.args, err = p.parseArguments(); err != nil {
+			return sel, err
+		}
+	}
+	if err := p.parseDirectives(); err != nil {
+		return sel, err
+	}
+	if p.is(gqlPunctuator, "{") {
+		sel.selections, err = p.parseSelectionSet()
+	}
+	return sel, err
+}
+
+func (p *gqlParser) parseArguments() (map[string]gqlValue, error) {
+	if err := p.expect(gqlPunctuator, "("); err != nil {
+		return nil, err
+	}
+	args := make(map[string]gqlValue)
+	for !p.is(gqlPunctuator, ")") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(gqlPunctuator, ":"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		args[name] = value
+	}
+	return args, p.next()
+}
+
+func (p *gqlParser) parseDirectives() error {
+	for p.is(gqlPunctuator, "@") {
+		if err := p.next(); err != nil {
+			return err
+		}
+		if _, err := p.expectName(); err != nil {
+			return err
+		}
+		if p.is(gqlPunctuator, "(") {
+			if _, err := p.parseArguments(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *gqlParser) parseType() error {
+	if p.is(gqlPunctuator, "[") {
+		if err := p.next(); err != nil {
+			return err
+		}
+		if err := p.parseType(); err != nil {
+			return err
+		}
+		if err := p.expect(gqlPunctuator, "]"); err != nil {
+			return err
+		}
+	} else if _, err := p.expectName(); err != nil {
+		return err
+	}
+	if p.is(gqlPunctuator, "!") {
+		return p.next()
+	}
+	return nil
+}
+
+func (p *gqlParser) parseValue() (gqlValue, error) {
+	tok := p.tok
+	switch {
+	case p.is(gqlPunctuator, "$"):
+		if err := p.next(); err != nil {
+			return gqlValue{}, err
+		}
+		name, err := p.expectName()
+		return gqlValue{kind: gqlValueVariable, raw: name}, err
+	case tok.kind == gqlInt:
+		return gqlValue{kind: gqlValueInt, raw: tok.value}, p.next()
+	case tok.kind == gqlFloat, tok.kind == gqlString, tok.kind == gqlName:
+		return gqlValue{raw: tok.value}, p.next()
+	case p.is(gqlPunctuator, "["):
+		if err := p.next(); err != nil {
+			return gqlValue{}, err
+		}
+		for !p.is(gqlPunctuator, "]") {
+			if _, err := p.parseValue(); err != nil {
+				return gqlValue{}, err
+			}
+		}
+		return gqlValue{}, p.next()
+	case p.is(gqlPunctuator, "{"):
+		if err := p.next(); err != nil {
+			return gqlValue{}, err
+		}
+		for !p.is(gqlPunctuator, "}") {
+			if _, err := p.expectName(); err != nil {
+				return gqlValue{}, err
+			}
+			if err := p.expect(gqlPunctuator, ":"); err != nil {
+				return gqlValue{}, err
+			}
+			if _, err := p.parseValue(); err != nil {
+				return gqlValue{}, err
+			}
+		}
+		return gqlValue{}, p.next()
+	default:
+		return gqlValue{}, p.unexpected("a value")
+	}
+}
+
+func (p *gqlParser) is(kind gqlTokenKind, value string) bool {
+	return p.tok.kind == kind && p.tok.value == value
+}
+
+func (p *gqlParser) expect(kind gqlTokenKind, value string) error {
+	if !p.is(kind, value) {
+		return p.unexpected(fmt.Sprintf("%q", value))
+	}
+	return p.next()
+}
+
+func (p *gqlParser) expectName() (string, error) {
+	if p.tok.kind != gqlName {
+		return "", p.unexpected("a name")
+	}
+	name := p.tok.value
+	return name, p.next()
+}
+
+func (p *gqlParser) unexpected(want string) error {
+	if p.tok.kind == gqlEOF {
+		return fmt.Errorf("syntax error: expected %s, found end of query", want)
+	}
+	return fmt.Errorf("syntax error at position %d: expected %s, found %q", p.tok.pos, want, p.tok.value)
+}
+
+// next reads the following token into p.tok, skipping whitespace, commas and comments.
+func (p *gqlParser) next() error {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',' {
+			p.pos++
+			continue
+		}
+		if strings.HasPrefix(p.src[p.pos:], "\uFEFF") {
+			p.pos += len("\uFEFF")
+			continue
+		}
+		if c == '#' {
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' && p.src[p.pos] != '\r' {
+				p.pos++
+			}
+			continue
+		}
+		break
+	}
+
+	start := p.pos
+	if start >= len(p.src) {
+		p.tok = gqlToken{kind: gqlEOF, pos: start}
+		return nil
+	}
+
+	c := p.src[start]
+	switch {
+	case strings.IndexByte("!$&()[]{}:=@|", c) >= 0:
+		p.pos++
+		p.tok = gqlToken{kind: gqlPunctuator, value: string(c), pos: start}
+	case strings.HasPrefix(p.src[start:], "..."):
+		p.pos += 3
+		p.tok = gqlToken{kind: gqlPunctuator, value: "...", pos: start}
+	case isGraphQLNameStart(c):
+		for p.pos < len(p.src) && (isGraphQLNameStart(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok = gqlToken{kind: gqlName, value: p.src[start:p.pos], pos: start}
+	case c == '-' || isDigit(c):
+		return p.lexNumber()
+	case c == '"':
+		return p.lexString()
+	default:
+		return fmt.Errorf("syntax error at position %d: unexpected character %q", start, c)
+	}
+	return nil
+}
+
+func (p *gqlParser) lexNumber() error {
+	start := p.pos
+	kind := gqlInt
+	if p.src[p.pos] == '-' {
+		p.pos++
+	}
+	digits := func() int {
+		n := 0
+		for p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+			p.pos++
+			n++
+		}
+		return n
+	}
+	if digits() == 0 {
+		return fmt.Errorf("syntax error at position %d: invalid number", start)
+	}
+	if p.pos < len(p.src) && p.src[p.pos] == '.' {
+		kind = gqlFloat
+		p.pos++
+		if digits() == 0 {
+			return fmt.Errorf("syntax error at position %d: invalid number", start)
+		}
+	}
+	if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+		kind = gqlFloat
+		p.pos++
+		if p.pos < len(p.src) && (p.src[p.pos] == '+' || p.src[p.pos] == '-') {
+			p.pos++
+		}
+		if digits() == 0 {
+			return fmt.Errorf("syntax error at position %d: invalid number", start)
+		}
+	}
+	p.tok = gqlToken{kind: kind, value: p.src[start:p.pos], pos: start}
+	return nil
+}
+
+func (p *gqlParser) lexString() error {
+	start := p.pos
+	if strings.HasPrefix(p.src[start:], `"""`) {
+		p.pos += 3
+		for p.pos < len(p.src) {
+			switch {
+			case strings.HasPrefix(p.src[p.pos:], `\"""`):
+				p.pos += 4
+			case strings.HasPrefix(p.src[p.pos:], `"""`):
+				p.pos += 3
+				p.tok = gqlToken{kind: gqlString, value: p.src[start:p.pos], pos: start}
+				return nil
+			default:
+				p.pos++
+			}
+		}
+		return fmt.Errorf("syntax error at position %d: unterminated string", start)
+	}
+
+	p.pos++
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case '\\':
+			p.pos += 2
+		case '\n', '\r':
+			return fmt.Errorf("syntax error at position %d: unterminated string", start)
+		case '"':
+			p.pos++
+			p.tok = gqlToken{kind: gqlString, value: p.src[start:p.pos], pos: start}
+			return nil
+		default:
+			p.pos++
+		}
+	}
+	return fmt.Errorf("syntax error at position %d: unterminated string", start)
+}
+
+func isGraphQLNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,176 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidateGraphQLQuery(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		variables      map[string]any
+		expectedErrMsg string
+	}{
+		{
+			name:  "anonymous query",
+			query: "{ viewer { login } }",
+		},
+		{
+			name: "named query with variables, fragments, directives and comments",
+			query: `# Pull requests and their reviews
+query PRs($owner: String!, $name: String!, $n: Int = 10, $withBody: Boolean = false) {
+  repository(owner: $owner, name: $name) {
+    pullRequests(first: $n, states: [OPEN, MERGED], orderBy: {field: CREATED_AT, direction: DESC}) {
+      nodes {
+        ...PR
+        body @include(if: $withBody)
+        ... on PullRequest { title }
+        ... @skip(if: $withBody) { number }
+      }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}
+
+fragment PR on PullRequest {
+  author: author { login }
+  reviews(last: 5) { nodes { state body } }
+  label: labels(first: 3, after: "Y3Vyc29yOjE=") { totalCount }
+  title(format: """block "quoted" string""")
+}`,
+			variables: map[string]any{"owner": "octo", "name": "repo"},
+		},
+		{
+			name:           "mutation",
+			query:          `mutation { addStar(input: {starrableId: "1"}) { clientMutationId } }`,
+			expectedErrMsg: "mutations are not allowed",
+		},
+		{
+			name:           "mutation next to a query",
+			query:          `query { viewer { login } } mutation M { addStar(input: {starrableId: "1"}) { clientMutationId } }`,
+			expectedErrMsg: "exactly one operation",
+		},
+		{
+			name:           "subscription",
+			query:          "subscription { viewer { login } }",
+			expectedErrMsg: "subscription operations are not allowed",
+		},
+		{
+			name:           "type system definition",
+			query:          "type Foo { bar: String }",
+			expectedErrMsg: "expected a query or fragment definition",
+		},
+		{
+			name:           "syntax error",
+			query:          "{ viewer { login }",
+			expectedErrMsg: "syntax error",
+		},
+		{
+			name:           "unterminated string",
+			query:          `{ user(login: "octo) { login } }`,
+			expectedErrMsg: "unterminated string",
+		},
+		{
+			name:           "page size over the cap",
+			query:          "{ viewer { repositories(first: 101) { nodes { name } } } }",
+			expectedErrMsg: `field "repositories": first must not be greater than 100`,
+		},
+		{
+			name:           "page size over the cap from a variable",
+			query:          "query($n: Int!) { viewer { repositories(last: $n) { nodes { name } } } }",
+			variables:      map[string]any{"n": float64(500)},
+			expectedErrMsg: "last must not be greater than 100",
+		},
+		{
+			name:           "page size over the cap from a default",
+			query:          "query($n: Int = 1000) { viewer { repositories(first: $n) { nodes { name } } } }",
+			expectedErrMsg: "first must not be greater than 100",
+		},
+		{
+			name:           "page size from an unset variable",
+			query:          "query($n: Int) { viewer { repositories(first: $n) { nodes { name } } } }",
+			expectedErrMsg: "variable $n used for first must be set",
+		},
+		{
+			name:           "page size that isn't an integer",
+			query:          `{ viewer { repositories(first: "10") { nodes { name } } } }`,
+			expectedErrMsg: "first must be an integer",
+		},
+		{
+			name:           "too many nodes",
+			query:          "{ viewer { repositories(first: 100) { nodes { issues(first: 100) { nodes { title } } } } } }",
+			expectedErrMsg: "more than 10000 nodes",
+		},
+		{
+			name:           "too many nodes through a fragment",
+			query:          "{ viewer { repositories(first: 100) { nodes { ...Issues } } } } fragment Issues on Repository { issues(first: 100) { totalCount } }",
+			expectedErrMsg: "more than 10000 nodes",
+		},
+		{
+			name:           "too deep",
+			query:          "{ a { b { c { d { e { f { g { h { i { j { k { l { m } } } } } } } } } } } } }",
+			expectedErrMsg: "nested deeper than 12 fields",
+		},
+		{
+			name:           "fragment cycle",
+			query:          "{ viewer { ...A } } fragment A on User { ...B } fragment B on User { ...A }",
+			expectedErrMsg: `fragment "A" spreads itself`,
+		},
+		{
+			name:           "unknown fragment",
+			query:          "{ viewer { ...Missing } }",
+			expectedErrMsg: `unknown fragment "Missing"`,
+		},
+		{
+			name:           "empty document",
+			query:          "  # nothing here\n",
+			expectedErrMsg: "exactly one operation, found 0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateGraphQLQuery(tc.query, tc.variables)
+			if tc.expectedErrMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedErrMsg)
+		})
+	}
+
+	t.Run("fragments spreading each other many times are rejected", func(t *testing.T) {
+		// Each fragment spreads the next one twice, doubling the selections to visit 40 times
+		var query strings.Builder
+		query.WriteString("{ viewer { ...F0 } }")
+		for i := 0; i < 40; i++ {
+			fmt.Fprintf(&query, " fragment F%d on User { ...F%d ...F%d }", i, i+1, i+1)
+		}
+		query.WriteString(" fragment F40 on User { login }")
+
+		done := make(chan error, 1)
+		go func() { done <- ValidateGraphQLQuery(query.String(), nil) }()
+		select {
+		case err := <-done:
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "selects more than 10000 fields")
+		case <-time.After(5 * time.Second):
+			t.Fatal("validation did not finish")
+		}
+	})
+
+	t.Run("depth at the limit is allowed", func(t *testing.T) {
+		query := strings.Repeat("{ f ", GraphQLMaxDepth) + "{ leaf }" + strings.Repeat(" }", GraphQLMaxDepth)
+		// The innermost selection is one field deeper than the repeated ones
+		require.Error(t, ValidateGraphQLQuery(query, nil))
+		query = strings.Repeat("{ f ", GraphQLMaxDepth-1) + "{ leaf }" + strings.Repeat(" }", GraphQLMaxDepth-1)
+		require.NoError(t, ValidateGraphQLQuery(query, nil))
+	})
+}
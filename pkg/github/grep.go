@@ -0,0 +1,625 @@
+package github
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// grepMaxSnapshotBytes is the largest repository snapshot grep_repository extracts.
+	grepMaxSnapshotBytes = 512 << 20
+	// grepCacheMaxBytes is the total size of the snapshots kept on disk between calls.
+	grepCacheMaxBytes = 1 << 30
+	// grepMaxFileBytes is the size above which files are not searched.
+	grepMaxFileBytes = 1 << 20
+	// grepMaxLineChars is the length at which matching and context lines are cut.
+	grepMaxLineChars = 500
+	// grepMaxContextLines is the most context lines that can be requested around matches.
+	grepMaxContextLines = 10
+	// grepMaxMatches is the most matches that can be requested.
+	grepMaxMatches = 1000
+)
+
+// repoSnapshots caches the repository snapshots grep_repository searches.
+var repoSnapshots = newSnapshotCache(os.TempDir(), grepCacheMaxBytes)
+
+// RemoveRepositorySnapshots removes the repository snapshots grep_repository extracted
+// while the server was running. It is meant to be called on shutdown.
+func RemoveRepositorySnapshots() error {
+	return repoSnapshots.removeAll()
+}
+
+var errSnapshotTooLarge = fmt.Errorf("repository is larger than %d bytes, which is too large to search locally", grepMaxSnapshotBytes)
+
+// GrepMatch is a line matching the pattern of grep_repository.
+type GrepMatch struct {
+	Path   string   `json:"path"`
+	Line   int      `json:"line"`
+	Text   string   `json:"text"`
+	Before []string `json:"before,omitempty"`
+	After  []string `json:"after,omitempty"`
+}
+
+// GrepResult is the output type of grep_repository.
+type GrepResult struct {
+	SHA           string      `json:"sha"`
+	Matches       []GrepMatch `json:"matches"`
+	FilesSearched int         `json:"files_searched"`
+	// Truncated is set when the search stopped at max_matches
+	Truncated bool `json:"truncated"`
+}
+
+// snapshotCache keeps extracted repository snapshots in a directory of its own below
+// parent, removing the least recently used ones once they take up more than maxBytes.
+type snapshotCache struct {
+	mu       sync.Mutex
+	parent   string
+	dir      string
+	maxBytes int64
+	entries  map[string]*snapshot
+}
+
+type snapshot struct {
+	ready    chan struct{}
+	dir      string
+	size     int64
+	err      error
+	users    int
+	lastUsed time.Time
+}
+
+func newSnapshotCache(parent string, maxBytes int64) *snapshotCache {
+	return &snapshotCache{parent: parent, maxBytes: maxBytes, entries: make(map[string]*snapshot)}
+}
+
+// get returns the directory of the snapshot for key, calling fetch to fill a new
+// directory if it isn't cached. release must be called once the directory isn't used anymore.
+func (c *snapshotCache) get(key string, fetch func(dir string) (int64, error)) (dir string, release func(), err error) {
+	c.mu.Lock()
+	s, cached := c.entries[key]
+	if !cached {
+		s = &snapshot{ready: make(chan struct{})}
+		c.entries[key] = s
+	}
+	s.users++
+	s.lastUsed = time.Now()
+	c.mu.Unlock()
+
+	release = func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		s.users--
+		c.evict()
+	}
+
+	if !cached {
+		// evict reads the sizes of all entries, so they are only set with c.mu held
+		dir, size, err := c.fill(fetch)
+		c.mu.Lock()
+		s.dir, s.size, s.err = dir, size, err
+		if err != nil {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+		close(s.ready)
+	}
+	<-s.ready
+	if s.err != nil {
+		release()
+		return "", nil, s.err
+	}
+	return s.dir, release, nil
+}
+
+func (c *snapshotCache) fill(fetch func(dir string) (int64, error)) (string, int64, error) {
+	cacheDir, err := c.cacheDir()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create snapshot cache: %w", err)
+	}
+	dir, err := os.MkdirTemp(cacheDir, "snapshot-")
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	size, err := fetch(dir)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return "", 0, err
+	}
+	return dir, size, nil
+}
+
+// cacheDir returns the directory of the cache, creating it on first use so that each
+// process gets a directory of its own.
+func (c *snapshotCache) cacheDir() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.dir == "" {
+		dir, err := os.MkdirTemp(c.parent, "github-mcp-server-snapshots-")
+		if err != nil {
+			return "", err
+		}
+		c.dir = dir
+	}
+	return c.dir, nil
+}
+
+// removeAll removes the directory of the cache with all its snapshots.
+func (c *snapshotCache) removeAll() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.dir == "" {
+		return nil
+	}
+	err := os.RemoveAll(c.dir)
+	c.dir = ""
+	c.entries = make(map[string]*snapshot)
+	return err
+}
+
+// evict removes unused snapshots, least recently used first, until the cache fits
+// in maxBytes. c.mu must be held.
+func (c *snapshotCache) evict() {
+	var total int64
+	var unused []string
+	for key, s := range c.entries {
+		total += s.size
+		if s.users == 0 {
+			unused = append(unused, key)
+		}
+	}
+	sort.Slice(unused, func(i, j int) bool {
+		return c.entries[unused[i]].lastUsed.Before(c.entries[unused[j]].lastUsed)
+	})
+	for _, key := range unused {
+		if total <= c.maxBytes {
+			return
+		}
+		total -= c.entries[key].size
+		_ = os.RemoveAll(c.entries[key].dir)
+		delete(c.entries, key)
+	}
+}
+
+// extractTarball extracts the regular files of a gzipped repository tarball into dir,
+// dropping the top-level directory GitHub puts all files in.
+func extractTarball(r io.Reader, dir string, maxBytes int64) (int64, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read tarball: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	var size int64
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return size, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read tarball: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		_, name, ok := strings.Cut(header.Name, "/")
+		if !ok || !filepath.IsLocal(name) {
+			continue
+		}
+		if size += header.Size; size > maxBytes {
+			return 0, errSnapshotTooLarge
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+			return 0, fmt.Errorf("failed to extract tarball: %w", err)
+		}
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+		if err != nil {
+			return 0, fmt.Errorf("failed to extract tarball: %w", err)
+		}
+		_, err = io.Copy(f, io.LimitReader(tr, header.Size))
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to extract tarball: %w", err)
+		}
+	}
+}
+
+// gitattributesRule sets or unsets linguist-generated for the paths below dir
+// matching glob.
+type gitattributesRule struct {
+	dir       string
+	glob      pathGlobs
+	generated bool
+}
+
+// generatedFiles tells which files .gitattributes files mark as linguist-generated.
+type generatedFiles []gitattributesRule
+
+// loadGeneratedFiles reads the linguist-generated attributes of all .gitattributes
+// files in root. Rules of deeper files come later, so that they take precedence.
+func loadGeneratedFiles(root string) (generatedFiles, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && d.Name() == ".gitattributes" {
+			rel, _ := filepath.Rel(root, filepath.Dir(p))
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	depth := func(dir string) int {
+		if dir == "." {
+			return 0
+		}
+		return strings.Count(dir, "/") + 1
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return depth(files[i]) < depth(files[j])
+	})
+
+	var rules generatedFiles
+	for _, dir := range files {
+		content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(dir), ".gitattributes"))
+		if err != nil {
+			return nil, err
+		}
+		if dir == "." {
+			dir = ""
+		}
+		for _, line := range strings.Split(string(content), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+				continue
+			}
+			for _, attr := range fields[1:] {
+				var generated bool
+				switch attr {
+				case "linguist-generated", "linguist-generated=true":
+					generated = true
+				case "-linguist-generated", "!linguist-generated", "linguist-generated=false":
+					generated = false
+				default:
+					continue
+				}
+				glob, err := compilePathGlobs([]string{fields[0]})
+				if err != nil {
+					// Skip patterns this glob syntax doesn't support
+					continue
+				}
+				rules = append(rules, gitattributesRule{dir: dir, glob: glob, generated: generated})
+			}
+		}
+	}
+	return rules, nil
+}
+
+// isGenerated reports whether the last rule matching p marks it as generated.
+func (g generatedFiles) isGenerated(p string) bool {
+	generated := false
+	for _, rule := range g {
+		rel := p
+		if rule.dir != "" {
+			var ok bool
+			if rel, ok = strings.CutPrefix(p, rule.dir+"/"); !ok {
+				continue
+			}
+		}
+		if rule.glob.matchAny(rel, false) {
+			generated = rule.generated
+		}
+	}
+	return generated
+}
+
+// grepOptions are the filters and limits of a search.
+type grepOptions struct {
+	include          pathGlobs
+	exclude          pathGlobs
+	includeGenerated bool
+	contextLines     int
+	maxMatches       int
+}
+
+// grepSnapshot searches the text files in root for lines matching re.
+func grepSnapshot(root string, re *regexp.Regexp, opts grepOptions) (GrepResult, error) {
+	var generated generatedFiles
+	if !opts.includeGenerated {
+		var err error
+		if generated, err = loadGeneratedFiles(root); err != nil {
+			return GrepResult{}, fmt.Errorf("failed to read .gitattributes: %w", err)
+		}
+	}
+
+	result := GrepResult{Matches: []GrepMatch{}}
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if opts.exclude.matchAny(rel, false) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if len(opts.include) > 0 && !opts.include.matchAny(rel, false) {
+			return nil
+		}
+		if opts.exclude.matchAny(rel, false) || generated.isGenerated(rel) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.Size() > grepMaxFileBytes {
+			return err
+		}
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		// Skip binary files, like git does
+		if bytes.IndexByte(content[:min(len(content), 8000)], 0) >= 0 {
+			return nil
+		}
+
+		result.FilesSearched++
+		lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+		for i, line := range lines {
+			if !re.MatchString(line) {
+				continue
+			}
+			if len(result.Matches) == opts.maxMatches {
+				result.Truncated = true
+				return filepath.SkipAll
+			}
+			result.Matches = append(result.Matches, GrepMatch{
+				Path:   rel,
+				Line:   i + 1,
+				Text:   grepLine(line),
+				Before: grepLines(lines[max(i-opts.contextLines, 0):i]),
+				After:  grepLines(lines[i+1 : min(i+1+opts.contextLines, len(lines))]),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return GrepResult{}, err
+	}
+	return result, nil
+}
+
+func grepLine(line string) string {
+	line = strings.TrimSuffix(line, "\r")
+	if len(line) > grepMaxLineChars {
+		return line[:runeBoundary(line, grepMaxLineChars)] + "…"
+	}
+	return line
+}
+
+func grepLines(lines []string) []string {
+	if len(lines) == 0 {
+		return nil
+	}
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		result[i] = grepLine(line)
+	}
+	return result
+}
+
+// downloadSnapshot downloads and extracts the tarball at archiveURL into dir.
+func downloadSnapshot(ctx context.Context, archiveURL, dir string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, archiveURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req) //nolint:gosec // the archive URL comes from the GitHub API
+	if err != nil {
+		return 0, fmt.Errorf("failed to download tarball: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to download tarball: %s", resp.Status)
+	}
+	return extractTarball(bufio.NewReader(io.LimitReader(resp.Body, grepMaxSnapshotBytes)), dir, grepMaxSnapshotBytes)
+}
+
+// GrepRepository creates a tool to search the files of a repository at any ref with a regular expression.
+func GrepRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("grep_repository",
+			mcp.WithDescription(t("TOOL_GREP_REPOSITORY_DESCRIPTION", "Search the files of a GitHub repository at any branch, tag or commit for lines matching a regular expression, and return the matches with context lines. Unlike search_code, this searches every text file of the exact ref, but it downloads the repository first, so prefer search_code for searches across repositories.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GREP_REPOSITORY_USER_TITLE", "Search repository files with a regular expression"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("pattern",
+				mcp.Required(),
+				mcp.Description("Regular expression to search for, in Go RE2 syntax. Matched against each line"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Accepts optional git refs such as `refs/tags/{tag}`, `refs/heads/{branch}`, or a branch or tag name. Defaults to the default branch"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Accepts optional commit SHA. If specified, it will be used instead of ref"),
+			),
+			mcp.WithBoolean("ignore_case",
+				mcp.Description("Match the pattern case-insensitively. Default is false."),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithArray("include",
+				mcp.Description("Glob patterns of the files to search, e.g. `*.go` or `src/**/*.ts`. Patterns without a slash match file names, others match paths from the root of the repository"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithArray("exclude",
+				mcp.Description("Glob patterns of the files and directories not to search, with the same syntax as include"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithBoolean("include_generated",
+				mcp.Description("Also search files marked as linguist-generated in .gitattributes. Default is false."),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithNumber("context_lines",
+				mcp.Description(fmt.Sprintf("Number of lines to return before and after each match (max %d). Default is 2.", grepMaxContextLines)),
+				mcp.Min(0),
+				mcp.Max(grepMaxContextLines),
+			),
+			mcp.WithNumber("max_matches",
+				mcp.Description(fmt.Sprintf("Maximum number of matches to return (max %d). Default is 100.", grepMaxMatches)),
+				mcp.Min(1),
+				mcp.Max(grepMaxMatches),
+			),
+			WithOutputSchema[GrepResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pattern, err := RequiredParam[string](request, "pattern")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ignoreCase, err := OptionalBoolParamWithDefault(request, "ignore_case", false)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includePatterns, err := OptionalStringArrayParam(request, "include")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			excludePatterns, err := OptionalStringArrayParam(request, "exclude")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeGenerated, err := OptionalBoolParamWithDefault(request, "include_generated", false)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// 0 is a valid number of context lines, so the default only applies when unset
+			contextLines := 2
+			if _, ok := request.GetArguments()["context_lines"]; ok {
+				if contextLines, err = OptionalIntParam(request, "context_lines"); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+			maxMatches, err := OptionalIntParamWithDefault(request, "max_matches", 100)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if ignoreCase {
+				pattern = "(?i)" + pattern
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid pattern: %s", err)), nil
+			}
+			opts := grepOptions{
+				includeGenerated: includeGenerated,
+				contextLines:     min(max(contextLines, 0), grepMaxContextLines),
+				maxMatches:       min(max(maxMatches, 1), grepMaxMatches),
+			}
+			if opts.include, err = compilePathGlobs(includePatterns); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if opts.exclude, err = compilePathGlobs(excludePatterns); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			rawOpts, err := resolveGitReference(ctx, client, owner, repo, ref, sha)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil
+			}
+
+			// The archive link is requested even if the snapshot is cached, as it
+			// checks that the caller can read the repository.
+			archiveURL, resp, err := client.Repositories.GetArchiveLink(ctx, owner, repo, github.Tarball, &github.RepositoryContentGetOptions{Ref: rawOpts.SHA}, 1)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get archive link", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			key := strings.ToLower(path.Join(owner, repo)) + "@" + rawOpts.SHA
+			dir, release, err := repoSnapshots.get(key, func(dir string) (int64, error) {
+				return downloadSnapshot(ctx, archiveURL.String(), dir)
+			})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to download repository snapshot: %s", err)), nil
+			}
+			defer release()
+
+			result, err := grepSnapshot(dir, re, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to search repository snapshot: %w", err)
+			}
+			result.SHA = rawOpts.SHA
+			return MarshalledTextResult(result), nil
+		}
+}
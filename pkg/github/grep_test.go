@@ -0,0 +1,297 @@
+package github
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildTarball builds a gzipped tarball laid out like the ones GitHub serves.
+func buildTarball(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "owner-repo-abc123/", Typeflag: tar.TypeDir, Mode: 0o755}))
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "owner-repo-abc123/" + name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "owner-repo-abc123/link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}))
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func Test_GrepRepository(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GrepRepository(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "grep_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "grep_repository tool should be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pattern")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "ignore_case")
+	assert.Contains(t, tool.InputSchema.Properties, "include")
+	assert.Contains(t, tool.InputSchema.Properties, "exclude")
+	assert.Contains(t, tool.InputSchema.Properties, "include_generated")
+	assert.Contains(t, tool.InputSchema.Properties, "context_lines")
+	assert.Contains(t, tool.InputSchema.Properties, "max_matches")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pattern"})
+
+	cache := repoSnapshots
+	repoSnapshots = newSnapshotCache(t.TempDir(), grepCacheMaxBytes)
+	defer func() { repoSnapshots = cache }()
+
+	tarball := buildTarball(t, map[string]string{
+		"main.go":               "package main\n\nfunc main() {\n\tserve()\n}\n",
+		"server/server.go":      "package server\n\n// Serve starts the server\nfunc Serve() {}\n",
+		"server/server.pb.go":   "package server\n\nfunc serveGenerated() {}\n",
+		"vendor/lib/lib.go":     "package lib\n\nfunc serve() {}\n",
+		"docs/README.md":        "Call serve() to start\n",
+		"image.png":             "serve\x00binary",
+		".gitattributes":        "*.pb.go linguist-generated=true\nvendor/** linguist-generated\n",
+		"vendor/.gitattributes": "lib/*.go -linguist-generated\n",
+	})
+	downloads := 0
+	archiveServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		downloads++
+		_, _ = w.Write(tarball)
+	}))
+	defer archiveServer.Close()
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposTarballByOwnerByRepoByRef,
+			expectPath(t, "/repos/owner/repo/tarball/abc123").andThen(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Location", archiveServer.URL+"/owner-repo-abc123.tar.gz")
+					w.WriteHeader(http.StatusFound)
+				}),
+			),
+		),
+	)
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedResult GrepResult
+	}{
+		{
+			name: "matches with context, skipping generated and binary files",
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"sha":           "abc123",
+				"pattern":       `serve\(`,
+				"context_lines": float64(1),
+			},
+			expectedResult: GrepResult{
+				SHA: "abc123",
+				Matches: []GrepMatch{
+					{Path: "docs/README.md", Line: 1, Text: "Call serve() to start"},
+					{Path: "main.go", Line: 4, Text: "\tserve()", Before: []string{"func main() {"}, After: []string{"}"}},
+					{Path: "vendor/lib/lib.go", Line: 3, Text: "func serve() {}", Before: []string{""}},
+				},
+				FilesSearched: 5,
+			},
+		},
+		{
+			name: "case-insensitive search with include, exclude and generated files",
+			requestArgs: map[string]any{
+				"owner":             "owner",
+				"repo":              "repo",
+				"sha":               "abc123",
+				"pattern":           `func serve`,
+				"ignore_case":       true,
+				"include":           []any{"*.go"},
+				"exclude":           []any{"vendor"},
+				"include_generated": true,
+				"context_lines":     float64(0),
+			},
+			expectedResult: GrepResult{
+				SHA: "abc123",
+				Matches: []GrepMatch{
+					{Path: "server/server.go", Line: 4, Text: "func Serve() {}"},
+					{Path: "server/server.pb.go", Line: 3, Text: "func serveGenerated() {}"},
+				},
+				FilesSearched: 3,
+			},
+		},
+		{
+			name: "stops at max_matches",
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"sha":           "abc123",
+				"pattern":       `^package`,
+				"context_lines": float64(0),
+				"max_matches":   float64(2),
+			},
+			expectedResult: GrepResult{
+				SHA: "abc123",
+				Matches: []GrepMatch{
+					{Path: "main.go", Line: 1, Text: "package main"},
+					{Path: "server/server.go", Line: 1, Text: "package server"},
+				},
+				FilesSearched: 5,
+				Truncated:     true,
+			},
+		},
+		{
+			name: "invalid pattern",
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"sha":     "abc123",
+				"pattern": `serve(`,
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid pattern",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mockedClient)
+			_, handler := GrepRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var grepResult GrepResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &grepResult))
+			assert.Equal(t, tc.expectedResult, grepResult)
+		})
+	}
+
+	// The snapshot was downloaded once and reused by the later searches
+	assert.Equal(t, 1, downloads)
+}
+
+func Test_ExtractTarball(t *testing.T) {
+	dir := t.TempDir()
+	size, err := extractTarball(bytes.NewReader(buildTarball(t, map[string]string{
+		"a/b.txt":       "hello",
+		"../escape.txt": "nope",
+	})), dir, 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), size)
+
+	content, err := os.ReadFile(filepath.Join(dir, "a", "b.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+	_, err = os.Lstat(filepath.Join(dir, "link"))
+	assert.True(t, os.IsNotExist(err), "symlinks should not be extracted")
+	_, err = os.Stat(filepath.Join(filepath.Dir(dir), "escape.txt"))
+	assert.True(t, os.IsNotExist(err), "paths outside the directory should not be extracted")
+
+	_, err = extractTarball(bytes.NewReader(buildTarball(t, map[string]string{"big.txt": "0123456789"})), t.TempDir(), 5)
+	assert.ErrorIs(t, err, errSnapshotTooLarge)
+}
+
+func Test_SnapshotCache(t *testing.T) {
+	cache := newSnapshotCache(t.TempDir(), 25)
+	fills := 0
+	fetch := func(dir string) (int64, error) {
+		fills++
+		return 10, os.WriteFile(filepath.Join(dir, "file"), []byte("content"), 0o600)
+	}
+
+	dirA, releaseA, err := cache.get("a", fetch)
+	require.NoError(t, err)
+	releaseA()
+	dirB, releaseB, err := cache.get("b", fetch)
+	require.NoError(t, err)
+
+	// a is cached
+	dir, release, err := cache.get("a", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, dirA, dir)
+	assert.Equal(t, 2, fills)
+	release()
+
+	// Adding c goes over the limit. b is the least recently used snapshot, but it
+	// is still in use, so a is removed instead
+	_, releaseC, err := cache.get("c", fetch)
+	require.NoError(t, err)
+	releaseC()
+	assert.DirExists(t, dirB)
+	assert.NoDirExists(t, dirA)
+
+	releaseB()
+	assert.DirExists(t, dirB)
+	assert.Len(t, cache.entries, 2)
+
+	// Snapshots are kept in a directory of the cache, which is removed with all of them
+	cacheDir := filepath.Dir(dirB)
+	assert.True(t, strings.HasPrefix(filepath.Base(cacheDir), "github-mcp-server-snapshots-"))
+	require.NoError(t, cache.removeAll())
+	assert.NoDirExists(t, cacheDir)
+	assert.Empty(t, cache.entries)
+
+	// The cache can be used again after that
+	dir, release, err = cache.get("a", fetch)
+	require.NoError(t, err)
+	assert.DirExists(t, dir)
+	release()
+}
+
+func Test_SnapshotCache_ConcurrentGets(t *testing.T) {
+	// Filling snapshots of different repositories while others are released and evicted
+	// must not race, which go test -race checks
+	cache := newSnapshotCache(t.TempDir(), 25)
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, release, err := cache.get(fmt.Sprintf("repo-%d", i), func(dir string) (int64, error) {
+				return 10, os.WriteFile(filepath.Join(dir, "file"), []byte("content"), 0o600)
+			})
+			if assert.NoError(t, err) {
+				release()
+			}
+		}()
+	}
+	wg.Wait()
+
+	var total int64
+	for _, s := range cache.entries {
+		total += s.size
+	}
+	assert.LessOrEqual(t, total, int64(25))
+}
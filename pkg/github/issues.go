@@ -245,6 +245,8 @@ func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				mcp.Required(),
 				mcp.Description("The number of the issue"),
 			),
+			WithOutputSchema[*github.Issue](),
+			WithMinimalOutput[MinimalIssue](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -260,6 +262,11 @@ func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -278,6 +285,10 @@ func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get issue: %s", string(body))), nil
 			}
 
+			if minimalOutput {
+				return MarshalledTextResult(convertToMinimalIssue(issue, true)), nil
+			}
+
 			r, err := json.Marshal(issue)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal issue: %w", err)
@@ -300,6 +311,7 @@ func ListIssueTypes(getClient GetClientFn, t translations.TranslationHelperFunc)
 				mcp.Required(),
 				mcp.Description("The organization owner of the repository"),
 			),
+			WithOutputSchema[[]*github.IssueType](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -358,6 +370,7 @@ func AddIssueComment(getClient GetClientFn, t translations.TranslationHelperFunc
 				mcp.Required(),
 				mcp.Description("Comment content"),
 			),
+			WithOutputSchema[*github.IssueComment](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -435,6 +448,7 @@ func AddSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			mcp.WithBoolean("replace_parent",
 				mcp.Description("When true, replaces the sub-issue's current parent issue"),
 			),
+			WithOutputSchema[*github.SubIssue](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -522,6 +536,8 @@ func ListSubIssues(getClient GetClientFn, t translations.TranslationHelperFunc)
 			mcp.WithNumber("per_page",
 				mcp.Description("Number of results per page (max 100, default: 30)"),
 			),
+			WithOutputSchema[ListResult[*github.SubIssue]](),
+			WithMinimalOutput[ListResult[MinimalIssue]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -545,6 +561,11 @@ func ListSubIssues(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -576,7 +597,13 @@ func ListSubIssues(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list sub-issues: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(subIssues)
+			if minimalOutput {
+				return MarshalledTextResult(convertListResult(restListResult(subIssues, resp), func(subIssue *github.SubIssue) MinimalIssue {
+					return convertToMinimalIssue((*github.Issue)(subIssue), false)
+				})), nil
+			}
+
+			r, err := json.Marshal(restListResult(subIssues, resp))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -614,6 +641,7 @@ func RemoveSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc)
 				mcp.Required(),
 				mcp.Description("The ID of the sub-issue to remove. ID is not the same as issue number"),
 			),
+			WithOutputSchema[*github.SubIssue](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -699,6 +727,7 @@ func ReprioritizeSubIssue(getClient GetClientFn, t translations.TranslationHelpe
 			mcp.WithNumber("before_id",
 				mcp.Description("The ID of the sub-issue to be prioritized before (either after_id OR before_id should be specified)"),
 			),
+			WithOutputSchema[*github.SubIssue](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -821,6 +850,8 @@ func SearchIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				mcp.Enum("asc", "desc"),
 			),
 			WithPagination(),
+			WithOutputSchema[*github.IssuesSearchResult](),
+			WithMinimalOutput[MinimalSearchIssuesResult](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			return searchHandler(ctx, getClient, request, "issue", "failed to search issues")
@@ -872,6 +903,7 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			mcp.WithString("type",
 				mcp.Description("Type of this issue"),
 			),
+			WithOutputSchema[MinimalResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -971,7 +1003,7 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 // ListIssues creates a tool to list and filter repository issues
 func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_issues",
-			mcp.WithDescription(t("TOOL_LIST_ISSUES_DESCRIPTION", "List issues in a GitHub repository. For pagination, use the 'endCursor' from the previous response's 'pageInfo' in the 'after' parameter.")),
+			mcp.WithDescription(t("TOOL_LIST_ISSUES_DESCRIPTION", "List issues in a GitHub repository. For pagination, use the 'end_cursor' from the previous response's 'page_info' in the 'after' parameter.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_LIST_ISSUES_USER_TITLE", "List issues"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -1008,6 +1040,8 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 				mcp.Description("Filter by date (ISO 8601 timestamp)"),
 			),
 			WithCursorPagination(),
+			WithOutputSchema[ListResult[*github.Issue]](),
+			WithMinimalOutput[ListResult[MinimalIssue]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1084,7 +1118,7 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 
 			// Check if someone tried to use page-based pagination instead of cursor-based
 			if _, pageProvided := request.GetArguments()["page"]; pageProvided {
-				return mcp.NewToolResultError("This tool uses cursor-based pagination. Use the 'after' parameter with the 'endCursor' value from the previous response instead of 'page'."), nil
+				return mcp.NewToolResultError("This tool uses cursor-based pagination. Use the 'after' parameter with the 'end_cursor' value from the previous response's 'page_info' instead of 'page'."), nil
 			}
 
 			// Check if pagination parameters were explicitly provided
@@ -1102,6 +1136,11 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 				paginationParams.First = &defaultFirst
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getGQLClient(ctx)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
@@ -1162,15 +1201,11 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 			}
 
 			// Create response with issues
-			response := map[string]interface{}{
-				"issues": issues,
-				"pageInfo": map[string]interface{}{
-					"hasNextPage":     pageInfo.HasNextPage,
-					"hasPreviousPage": pageInfo.HasPreviousPage,
-					"startCursor":     string(pageInfo.StartCursor),
-					"endCursor":       string(pageInfo.EndCursor),
-				},
-				"totalCount": totalCount,
+			response := graphQLListResult(issues, bool(pageInfo.HasNextPage), string(pageInfo.EndCursor), totalCount)
+			if minimalOutput {
+				return MarshalledTextResult(convertListResult(response, func(issue *github.Issue) MinimalIssue {
+					return convertToMinimalIssue(issue, false)
+				})), nil
 			}
 			out, err := json.Marshal(response)
 			if err != nil {
@@ -1239,6 +1274,7 @@ func UpdateIssue(getClient GetClientFn, getGQLClient GetGQLClientFn, t translati
 			mcp.WithNumber("duplicate_of",
 				mcp.Description("Issue number that this issue is a duplicate of. Only used when state_reason is 'duplicate'."),
 			),
+			WithOutputSchema[MinimalResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1458,6 +1494,8 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Description("Issue number"),
 			),
 			WithPagination(),
+			WithOutputSchema[ListResult[*github.IssueComment]](),
+			WithMinimalOutput[ListResult[MinimalIssueComment]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1484,6 +1522,11 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 				},
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -1502,7 +1545,11 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get issue comments: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(comments)
+			if minimalOutput {
+				return MarshalledTextResult(convertListResult(restListResult(comments, resp), convertToMinimalIssueComment)), nil
+			}
+
+			r, err := json.Marshal(restListResult(comments, resp))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -1571,6 +1618,7 @@ func AssignCopilotToIssue(getGQLClient GetGQLClientFn, t translations.Translatio
 				mcp.Required(),
 				mcp.Description("Issue number"),
 			),
+			WithOutputSchema[MessageResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			var params struct {
@@ -1696,7 +1744,7 @@ func AssignCopilotToIssue(getGQLClient GetGQLClientFn, t translations.Translatio
 				return nil, fmt.Errorf("failed to replace actors for assignable: %w", err)
 			}
 
-			return mcp.NewToolResultText("successfully assigned copilot to issue"), nil
+			return MarshalledTextResult(MessageResponse{Message: "successfully assigned copilot to issue"}), nil
 		}
 }
 
@@ -1,6 +1,11 @@
 package github
 
-import "github.com/google/go-github/v74/github"
+import (
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+)
 
 // MinimalUser is the output type for user and organization search results.
 type MinimalUser struct {
@@ -86,6 +91,26 @@ type MinimalCommit struct {
 	Files     []MinimalCommitFile `json:"files,omitempty"`
 }
 
+// MinimalComparisonFile represents a file changed between two refs.
+type MinimalComparisonFile struct {
+	MinimalCommitFile
+	PreviousFilename string `json:"previous_filename,omitempty"`
+	Patch            string `json:"patch,omitempty"`
+	PatchTruncated   bool   `json:"patch_truncated,omitempty"`
+}
+
+// MinimalComparison is the trimmed output type for comparisons of two refs.
+type MinimalComparison struct {
+	Status       string                  `json:"status"`
+	AheadBy      int                     `json:"ahead_by"`
+	BehindBy     int                     `json:"behind_by"`
+	TotalCommits int                     `json:"total_commits"`
+	MergeBaseSHA string                  `json:"merge_base_sha,omitempty"`
+	HTMLURL      string                  `json:"html_url,omitempty"`
+	Commits      []MinimalCommit         `json:"commits"`
+	Files        []MinimalComparisonFile `json:"files,omitempty"`
+}
+
 // MinimalRelease is the trimmed output type for release objects.
 type MinimalRelease struct {
 	ID          int64        `json:"id"`
@@ -106,6 +131,210 @@ type MinimalBranch struct {
 	Protected bool   `json:"protected"`
 }
 
+// MinimalIssue is the trimmed output type for issue objects.
+type MinimalIssue struct {
+	Number        int          `json:"number"`
+	Title         string       `json:"title"`
+	Body          string       `json:"body,omitempty"`
+	State         string       `json:"state"`
+	StateReason   string       `json:"state_reason,omitempty"`
+	Type          string       `json:"type,omitempty"`
+	HTMLURL       string       `json:"html_url,omitempty"`
+	User          *MinimalUser `json:"user,omitempty"`
+	Labels        []string     `json:"labels,omitempty"`
+	Assignees     []string     `json:"assignees,omitempty"`
+	Milestone     string       `json:"milestone,omitempty"`
+	Comments      int          `json:"comments,omitempty"`
+	IsPullRequest bool         `json:"is_pull_request,omitempty"`
+	CreatedAt     string       `json:"created_at,omitempty"`
+	UpdatedAt     string       `json:"updated_at,omitempty"`
+	ClosedAt      string       `json:"closed_at,omitempty"`
+}
+
+// MinimalSearchIssuesResult is the trimmed output type for issue and pull request search results.
+type MinimalSearchIssuesResult struct {
+	TotalCount        int            `json:"total_count"`
+	IncompleteResults bool           `json:"incomplete_results"`
+	Items             []MinimalIssue `json:"items"`
+}
+
+// MinimalIssueComment is the trimmed output type for issue comment objects.
+type MinimalIssueComment struct {
+	ID        int64        `json:"id"`
+	Body      string       `json:"body"`
+	User      *MinimalUser `json:"user,omitempty"`
+	HTMLURL   string       `json:"html_url,omitempty"`
+	CreatedAt string       `json:"created_at,omitempty"`
+	UpdatedAt string       `json:"updated_at,omitempty"`
+}
+
+// MinimalPullRequest is the trimmed output type for pull request objects.
+type MinimalPullRequest struct {
+	Number             int          `json:"number"`
+	Title              string       `json:"title"`
+	Body               string       `json:"body,omitempty"`
+	State              string       `json:"state"`
+	Draft              bool         `json:"draft"`
+	Merged             bool         `json:"merged"`
+	MergeableState     string       `json:"mergeable_state,omitempty"`
+	HTMLURL            string       `json:"html_url"`
+	User               *MinimalUser `json:"user,omitempty"`
+	Head               string       `json:"head"`
+	HeadSHA            string       `json:"head_sha,omitempty"`
+	Base               string       `json:"base"`
+	Labels             []string     `json:"labels,omitempty"`
+	Assignees          []string     `json:"assignees,omitempty"`
+	RequestedReviewers []string     `json:"requested_reviewers,omitempty"`
+	Commits            int          `json:"commits,omitempty"`
+	Additions          int          `json:"additions,omitempty"`
+	Deletions          int          `json:"deletions,omitempty"`
+	ChangedFiles       int          `json:"changed_files,omitempty"`
+	CreatedAt          string       `json:"created_at,omitempty"`
+	UpdatedAt          string       `json:"updated_at,omitempty"`
+	ClosedAt           string       `json:"closed_at,omitempty"`
+	MergedAt           string       `json:"merged_at,omitempty"`
+}
+
+// MinimalPullRequestReview is the trimmed output type for pull request review objects.
+type MinimalPullRequestReview struct {
+	ID          int64        `json:"id"`
+	State       string       `json:"state"`
+	Body        string       `json:"body,omitempty"`
+	User        *MinimalUser `json:"user,omitempty"`
+	CommitID    string       `json:"commit_id,omitempty"`
+	HTMLURL     string       `json:"html_url,omitempty"`
+	SubmittedAt string       `json:"submitted_at,omitempty"`
+}
+
+// MinimalPullRequestComment is the trimmed output type for pull request review comment objects.
+type MinimalPullRequestComment struct {
+	ID          int64        `json:"id"`
+	Path        string       `json:"path"`
+	Line        int          `json:"line,omitempty"`
+	Body        string       `json:"body"`
+	User        *MinimalUser `json:"user,omitempty"`
+	InReplyToID int64        `json:"in_reply_to_id,omitempty"`
+	HTMLURL     string       `json:"html_url,omitempty"`
+	CreatedAt   string       `json:"created_at,omitempty"`
+}
+
+// MinimalWorkflowRun is the trimmed output type for workflow run objects.
+type MinimalWorkflowRun struct {
+	ID           int64        `json:"id"`
+	Name         string       `json:"name"`
+	DisplayTitle string       `json:"display_title,omitempty"`
+	WorkflowID   int64        `json:"workflow_id,omitempty"`
+	RunNumber    int          `json:"run_number"`
+	RunAttempt   int          `json:"run_attempt,omitempty"`
+	Event        string       `json:"event"`
+	Status       string       `json:"status"`
+	Conclusion   string       `json:"conclusion,omitempty"`
+	HeadBranch   string       `json:"head_branch,omitempty"`
+	HeadSHA      string       `json:"head_sha,omitempty"`
+	HTMLURL      string       `json:"html_url"`
+	Actor        *MinimalUser `json:"actor,omitempty"`
+	CreatedAt    string       `json:"created_at,omitempty"`
+	UpdatedAt    string       `json:"updated_at,omitempty"`
+	RunStartedAt string       `json:"run_started_at,omitempty"`
+}
+
+// MinimalWorkflow is the trimmed output type for workflow objects.
+type MinimalWorkflow struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	State     string `json:"state"`
+	HTMLURL   string `json:"html_url,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// MinimalWorkflowJob is the trimmed output type for workflow job objects.
+type MinimalWorkflowJob struct {
+	ID          int64    `json:"id"`
+	RunID       int64    `json:"run_id"`
+	Name        string   `json:"name"`
+	Status      string   `json:"status"`
+	Conclusion  string   `json:"conclusion,omitempty"`
+	FailedSteps []string `json:"failed_steps,omitempty"`
+	HTMLURL     string   `json:"html_url,omitempty"`
+	StartedAt   string   `json:"started_at,omitempty"`
+	CompletedAt string   `json:"completed_at,omitempty"`
+}
+
+// MinimalCodeScanningAlert is the trimmed output type for code scanning alert objects.
+type MinimalCodeScanningAlert struct {
+	Number                int    `json:"number"`
+	State                 string `json:"state"`
+	RuleID                string `json:"rule_id"`
+	Severity              string `json:"severity,omitempty"`
+	SecuritySeverityLevel string `json:"security_severity_level,omitempty"`
+	Description           string `json:"description,omitempty"`
+	Tool                  string `json:"tool,omitempty"`
+	Path                  string `json:"path,omitempty"`
+	StartLine             int    `json:"start_line,omitempty"`
+	HTMLURL               string `json:"html_url"`
+	CreatedAt             string `json:"created_at,omitempty"`
+	DismissedReason       string `json:"dismissed_reason,omitempty"`
+}
+
+// MinimalSecretScanningAlert is the trimmed output type for secret scanning alert objects.
+// It never includes the secret itself.
+type MinimalSecretScanningAlert struct {
+	Number                 int    `json:"number"`
+	State                  string `json:"state"`
+	SecretType             string `json:"secret_type"`
+	SecretTypeDisplayName  string `json:"secret_type_display_name,omitempty"`
+	Validity               string `json:"validity,omitempty"`
+	Resolution             string `json:"resolution,omitempty"`
+	PushProtectionBypassed bool   `json:"push_protection_bypassed,omitempty"`
+	HTMLURL                string `json:"html_url"`
+	CreatedAt              string `json:"created_at,omitempty"`
+	ResolvedAt             string `json:"resolved_at,omitempty"`
+}
+
+// MinimalDependabotAlert is the trimmed output type for Dependabot alert objects.
+type MinimalDependabotAlert struct {
+	Number                 int    `json:"number"`
+	State                  string `json:"state"`
+	Package                string `json:"package"`
+	Ecosystem              string `json:"ecosystem,omitempty"`
+	ManifestPath           string `json:"manifest_path,omitempty"`
+	Severity               string `json:"severity,omitempty"`
+	Summary                string `json:"summary,omitempty"`
+	GHSAID                 string `json:"ghsa_id,omitempty"`
+	CVEID                  string `json:"cve_id,omitempty"`
+	VulnerableVersionRange string `json:"vulnerable_version_range,omitempty"`
+	FirstPatchedVersion    string `json:"first_patched_version,omitempty"`
+	HTMLURL                string `json:"html_url"`
+	CreatedAt              string `json:"created_at,omitempty"`
+	DismissedReason        string `json:"dismissed_reason,omitempty"`
+}
+
+// MinimalNotification is the trimmed output type for notification objects.
+type MinimalNotification struct {
+	ID         string `json:"id"`
+	Repository string `json:"repository,omitempty"`
+	Reason     string `json:"reason"`
+	Unread     bool   `json:"unread"`
+	Type       string `json:"type,omitempty"`
+	Title      string `json:"title,omitempty"`
+	URL        string `json:"url,omitempty"`
+	UpdatedAt  string `json:"updated_at,omitempty"`
+}
+
+// MinimalDiscussion is the trimmed output type for discussion objects.
+type MinimalDiscussion struct {
+	Number    int          `json:"number"`
+	Title     string       `json:"title"`
+	Body      string       `json:"body,omitempty"`
+	Category  string       `json:"category,omitempty"`
+	User      *MinimalUser `json:"user,omitempty"`
+	HTMLURL   string       `json:"html_url,omitempty"`
+	CreatedAt string       `json:"created_at,omitempty"`
+	UpdatedAt string       `json:"updated_at,omitempty"`
+}
+
 // MinimalResponse represents a minimal response for all CRUD operations.
 // Success is implicit in the HTTP response status, and all other information
 // can be derived from the URL or fetched separately if needed.
@@ -114,6 +343,12 @@ type MinimalResponse struct {
 	URL string `json:"url"`
 }
 
+// MessageResponse is the response of operations that don't return an object, e.g. deletions,
+// or requests GitHub accepted but still processes in the background.
+type MessageResponse struct {
+	Message string `json:"message"`
+}
+
 // Helper functions
 
 // convertToMinimalCommit converts a GitHub API RepositoryCommit to MinimalCommit
@@ -203,3 +438,351 @@ func convertToMinimalBranch(branch *github.Branch) MinimalBranch {
 		Protected: branch.GetProtected(),
 	}
 }
+
+// convertToMinimalList converts every item of a list with convert.
+func convertToMinimalList[T, M any](items []T, convert func(T) M) []M {
+	minimalItems := make([]M, 0, len(items))
+	for _, item := range items {
+		minimalItems = append(minimalItems, convert(item))
+	}
+	return minimalItems
+}
+
+// minimalTime formats a timestamp for minimal types, or returns an empty string if it is not set.
+func minimalTime(ts *github.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.UTC().Format(time.RFC3339)
+}
+
+// minimalLogin returns a MinimalUser with only the login of user, or nil if there is no user.
+func minimalLogin(user *github.User) *MinimalUser {
+	if user == nil {
+		return nil
+	}
+	return &MinimalUser{Login: user.GetLogin()}
+}
+
+func minimalLogins(users []*github.User) []string {
+	return convertToMinimalList(users, (*github.User).GetLogin)
+}
+
+// convertToMinimalIssue converts a GitHub API Issue to MinimalIssue
+func convertToMinimalIssue(issue *github.Issue, includeBody bool) MinimalIssue {
+	minimalIssue := MinimalIssue{
+		Number:        issue.GetNumber(),
+		Title:         issue.GetTitle(),
+		State:         issue.GetState(),
+		StateReason:   issue.GetStateReason(),
+		Type:          issue.GetType().GetName(),
+		HTMLURL:       issue.GetHTMLURL(),
+		User:          minimalLogin(issue.User),
+		Milestone:     issue.GetMilestone().GetTitle(),
+		Comments:      issue.GetComments(),
+		IsPullRequest: issue.IsPullRequest(),
+		CreatedAt:     minimalTime(issue.CreatedAt),
+		UpdatedAt:     minimalTime(issue.UpdatedAt),
+		ClosedAt:      minimalTime(issue.ClosedAt),
+	}
+	if len(issue.Labels) > 0 {
+		minimalIssue.Labels = labelNames(issue.Labels)
+	}
+	if len(issue.Assignees) > 0 {
+		minimalIssue.Assignees = minimalLogins(issue.Assignees)
+	}
+	if includeBody {
+		minimalIssue.Body = issue.GetBody()
+	}
+	return minimalIssue
+}
+
+// convertToMinimalSearchIssuesResult converts a GitHub API IssuesSearchResult to MinimalSearchIssuesResult
+func convertToMinimalSearchIssuesResult(result *github.IssuesSearchResult) MinimalSearchIssuesResult {
+	return MinimalSearchIssuesResult{
+		TotalCount:        result.GetTotal(),
+		IncompleteResults: result.GetIncompleteResults(),
+		Items: convertToMinimalList(result.Issues, func(issue *github.Issue) MinimalIssue {
+			return convertToMinimalIssue(issue, false)
+		}),
+	}
+}
+
+// convertToMinimalIssueComment converts a GitHub API IssueComment to MinimalIssueComment
+func convertToMinimalIssueComment(comment *github.IssueComment) MinimalIssueComment {
+	return MinimalIssueComment{
+		ID:        comment.GetID(),
+		Body:      comment.GetBody(),
+		User:      minimalLogin(comment.User),
+		HTMLURL:   comment.GetHTMLURL(),
+		CreatedAt: minimalTime(comment.CreatedAt),
+		UpdatedAt: minimalTime(comment.UpdatedAt),
+	}
+}
+
+// convertToMinimalPullRequest converts a GitHub API PullRequest to MinimalPullRequest
+func convertToMinimalPullRequest(pr *github.PullRequest, includeBody bool) MinimalPullRequest {
+	minimalPR := MinimalPullRequest{
+		Number:         pr.GetNumber(),
+		Title:          pr.GetTitle(),
+		State:          pr.GetState(),
+		Draft:          pr.GetDraft(),
+		Merged:         pr.GetMerged() || pr.MergedAt != nil,
+		MergeableState: pr.GetMergeableState(),
+		HTMLURL:        pr.GetHTMLURL(),
+		User:           minimalLogin(pr.User),
+		Head:           pr.GetHead().GetRef(),
+		HeadSHA:        pr.GetHead().GetSHA(),
+		Base:           pr.GetBase().GetRef(),
+		Commits:        pr.GetCommits(),
+		Additions:      pr.GetAdditions(),
+		Deletions:      pr.GetDeletions(),
+		ChangedFiles:   pr.GetChangedFiles(),
+		CreatedAt:      minimalTime(pr.CreatedAt),
+		UpdatedAt:      minimalTime(pr.UpdatedAt),
+		ClosedAt:       minimalTime(pr.ClosedAt),
+		MergedAt:       minimalTime(pr.MergedAt),
+	}
+	if len(pr.Labels) > 0 {
+		minimalPR.Labels = labelNames(pr.Labels)
+	}
+	if len(pr.Assignees) > 0 {
+		minimalPR.Assignees = minimalLogins(pr.Assignees)
+	}
+	if len(pr.RequestedReviewers) > 0 {
+		minimalPR.RequestedReviewers = minimalLogins(pr.RequestedReviewers)
+	}
+	if includeBody {
+		minimalPR.Body = pr.GetBody()
+	}
+	return minimalPR
+}
+
+// convertToMinimalPullRequestReview converts a GitHub API PullRequestReview to MinimalPullRequestReview
+func convertToMinimalPullRequestReview(review *github.PullRequestReview) MinimalPullRequestReview {
+	return MinimalPullRequestReview{
+		ID:          review.GetID(),
+		State:       review.GetState(),
+		Body:        review.GetBody(),
+		User:        minimalLogin(review.User),
+		CommitID:    review.GetCommitID(),
+		HTMLURL:     review.GetHTMLURL(),
+		SubmittedAt: minimalTime(review.SubmittedAt),
+	}
+}
+
+// convertToMinimalPullRequestComment converts a GitHub API PullRequestComment to MinimalPullRequestComment
+func convertToMinimalPullRequestComment(comment *github.PullRequestComment) MinimalPullRequestComment {
+	return MinimalPullRequestComment{
+		ID:          comment.GetID(),
+		Path:        comment.GetPath(),
+		Line:        comment.GetLine(),
+		Body:        comment.GetBody(),
+		User:        minimalLogin(comment.User),
+		InReplyToID: comment.GetInReplyTo(),
+		HTMLURL:     comment.GetHTMLURL(),
+		CreatedAt:   minimalTime(comment.CreatedAt),
+	}
+}
+
+// convertToMinimalWorkflowRun converts a GitHub API WorkflowRun to MinimalWorkflowRun
+func convertToMinimalWorkflowRun(run *github.WorkflowRun) MinimalWorkflowRun {
+	return MinimalWorkflowRun{
+		ID:           run.GetID(),
+		Name:         run.GetName(),
+		DisplayTitle: run.GetDisplayTitle(),
+		WorkflowID:   run.GetWorkflowID(),
+		RunNumber:    run.GetRunNumber(),
+		RunAttempt:   run.GetRunAttempt(),
+		Event:        run.GetEvent(),
+		Status:       run.GetStatus(),
+		Conclusion:   run.GetConclusion(),
+		HeadBranch:   run.GetHeadBranch(),
+		HeadSHA:      run.GetHeadSHA(),
+		HTMLURL:      run.GetHTMLURL(),
+		Actor:        minimalLogin(run.Actor),
+		CreatedAt:    minimalTime(run.CreatedAt),
+		UpdatedAt:    minimalTime(run.UpdatedAt),
+		RunStartedAt: minimalTime(run.RunStartedAt),
+	}
+}
+
+// convertToMinimalWorkflow converts a GitHub API Workflow to MinimalWorkflow
+func convertToMinimalWorkflow(workflow *github.Workflow) MinimalWorkflow {
+	return MinimalWorkflow{
+		ID:        workflow.GetID(),
+		Name:      workflow.GetName(),
+		Path:      workflow.GetPath(),
+		State:     workflow.GetState(),
+		HTMLURL:   workflow.GetHTMLURL(),
+		CreatedAt: minimalTime(workflow.CreatedAt),
+		UpdatedAt: minimalTime(workflow.UpdatedAt),
+	}
+}
+
+// convertToMinimalWorkflowJob converts a GitHub API WorkflowJob to MinimalWorkflowJob
+func convertToMinimalWorkflowJob(job *github.WorkflowJob) MinimalWorkflowJob {
+	var failedSteps []string
+	for _, step := range job.Steps {
+		if step.GetConclusion() == "failure" {
+			failedSteps = append(failedSteps, step.GetName())
+		}
+	}
+	return MinimalWorkflowJob{
+		ID:          job.GetID(),
+		RunID:       job.GetRunID(),
+		Name:        job.GetName(),
+		Status:      job.GetStatus(),
+		Conclusion:  job.GetConclusion(),
+		FailedSteps: failedSteps,
+		HTMLURL:     job.GetHTMLURL(),
+		StartedAt:   minimalTime(job.StartedAt),
+		CompletedAt: minimalTime(job.CompletedAt),
+	}
+}
+
+// convertToMinimalCodeScanningAlert converts a GitHub API Alert to MinimalCodeScanningAlert
+func convertToMinimalCodeScanningAlert(alert *github.Alert) MinimalCodeScanningAlert {
+	location := alert.GetMostRecentInstance().GetLocation()
+	return MinimalCodeScanningAlert{
+		Number:                alert.GetNumber(),
+		State:                 alert.GetState(),
+		RuleID:                alert.GetRule().GetID(),
+		Severity:              alert.GetRule().GetSeverity(),
+		SecuritySeverityLevel: alert.GetRule().GetSecuritySeverityLevel(),
+		Description:           alert.GetRule().GetDescription(),
+		Tool:                  alert.GetTool().GetName(),
+		Path:                  location.GetPath(),
+		StartLine:             location.GetStartLine(),
+		HTMLURL:               alert.GetHTMLURL(),
+		CreatedAt:             minimalTime(alert.CreatedAt),
+		DismissedReason:       alert.GetDismissedReason(),
+	}
+}
+
+// convertToMinimalSecretScanningAlert converts a GitHub API SecretScanningAlert to MinimalSecretScanningAlert
+func convertToMinimalSecretScanningAlert(alert *github.SecretScanningAlert) MinimalSecretScanningAlert {
+	return MinimalSecretScanningAlert{
+		Number:                 alert.GetNumber(),
+		State:                  alert.GetState(),
+		SecretType:             alert.GetSecretType(),
+		SecretTypeDisplayName:  alert.GetSecretTypeDisplayName(),
+		Validity:               alert.GetValidity(),
+		Resolution:             alert.GetResolution(),
+		PushProtectionBypassed: alert.GetPushProtectionBypassed(),
+		HTMLURL:                alert.GetHTMLURL(),
+		CreatedAt:              minimalTime(alert.CreatedAt),
+		ResolvedAt:             minimalTime(alert.ResolvedAt),
+	}
+}
+
+// convertToMinimalDependabotAlert converts a GitHub API DependabotAlert to MinimalDependabotAlert
+func convertToMinimalDependabotAlert(alert *github.DependabotAlert) MinimalDependabotAlert {
+	vulnerability := alert.GetSecurityVulnerability()
+	return MinimalDependabotAlert{
+		Number:                 alert.GetNumber(),
+		State:                  alert.GetState(),
+		Package:                alert.GetDependency().GetPackage().GetName(),
+		Ecosystem:              alert.GetDependency().GetPackage().GetEcosystem(),
+		ManifestPath:           alert.GetDependency().GetManifestPath(),
+		Severity:               alert.GetSecurityAdvisory().GetSeverity(),
+		Summary:                alert.GetSecurityAdvisory().GetSummary(),
+		GHSAID:                 alert.GetSecurityAdvisory().GetGHSAID(),
+		CVEID:                  alert.GetSecurityAdvisory().GetCVEID(),
+		VulnerableVersionRange: vulnerability.GetVulnerableVersionRange(),
+		FirstPatchedVersion:    vulnerability.GetFirstPatchedVersion().GetIdentifier(),
+		HTMLURL:                alert.GetHTMLURL(),
+		CreatedAt:              minimalTime(alert.CreatedAt),
+		DismissedReason:        alert.GetDismissedReason(),
+	}
+}
+
+// convertToMinimalNotification converts a GitHub API Notification to MinimalNotification
+func convertToMinimalNotification(notification *github.Notification) MinimalNotification {
+	return MinimalNotification{
+		ID:         notification.GetID(),
+		Repository: notification.GetRepository().GetFullName(),
+		Reason:     notification.GetReason(),
+		Unread:     notification.GetUnread(),
+		Type:       notification.GetSubject().GetType(),
+		Title:      notification.GetSubject().GetTitle(),
+		URL:        notification.GetSubject().GetURL(),
+		UpdatedAt:  minimalTime(notification.UpdatedAt),
+	}
+}
+
+// convertToMinimalDiscussion converts a GitHub API Discussion to MinimalDiscussion
+func convertToMinimalDiscussion(discussion *github.Discussion, includeBody bool) MinimalDiscussion {
+	minimalDiscussion := MinimalDiscussion{
+		Number:    discussion.GetNumber(),
+		Title:     discussion.GetTitle(),
+		Category:  discussion.GetDiscussionCategory().GetName(),
+		User:      minimalLogin(discussion.User),
+		HTMLURL:   discussion.GetHTMLURL(),
+		CreatedAt: minimalTime(discussion.CreatedAt),
+		UpdatedAt: minimalTime(discussion.UpdatedAt),
+	}
+	if includeBody {
+		minimalDiscussion.Body = discussion.GetBody()
+	}
+	return minimalDiscussion
+}
+
+// convertToMinimalRelease converts a GitHub API RepositoryRelease to MinimalRelease
+func convertToMinimalRelease(release *github.RepositoryRelease, includeBody bool) MinimalRelease {
+	minimalRelease := MinimalRelease{
+		ID:          release.GetID(),
+		TagName:     release.GetTagName(),
+		Name:        release.GetName(),
+		HTMLURL:     release.GetHTMLURL(),
+		PublishedAt: minimalTime(release.PublishedAt),
+		Prerelease:  release.GetPrerelease(),
+		Draft:       release.GetDraft(),
+		Author:      minimalLogin(release.Author),
+	}
+	if includeBody {
+		minimalRelease.Body = release.GetBody()
+	}
+	return minimalRelease
+}
+
+// convertToMinimalComparison converts a GitHub API CommitsComparison to MinimalComparison.
+// Patches are only kept if includePatches is true, and are cut to maxPatchLines lines each
+// unless maxPatchLines is 0.
+func convertToMinimalComparison(comparison *github.CommitsComparison, includePatches bool, maxPatchLines int) MinimalComparison {
+	minimalComparison := MinimalComparison{
+		Status:       comparison.GetStatus(),
+		AheadBy:      comparison.GetAheadBy(),
+		BehindBy:     comparison.GetBehindBy(),
+		TotalCommits: comparison.GetTotalCommits(),
+		MergeBaseSHA: comparison.GetMergeBaseCommit().GetSHA(),
+		HTMLURL:      comparison.GetHTMLURL(),
+	}
+
+	minimalComparison.Commits = convertToMinimalList(comparison.Commits, func(commit *github.RepositoryCommit) MinimalCommit {
+		return convertToMinimalCommit(commit, false)
+	})
+
+	for _, file := range comparison.Files {
+		minimalFile := MinimalComparisonFile{
+			MinimalCommitFile: MinimalCommitFile{
+				Filename:  file.GetFilename(),
+				Status:    file.GetStatus(),
+				Additions: file.GetAdditions(),
+				Deletions: file.GetDeletions(),
+				Changes:   file.GetChanges(),
+			},
+			PreviousFilename: file.GetPreviousFilename(),
+		}
+		if includePatches {
+			minimalFile.Patch = file.GetPatch()
+			if lines := strings.Split(minimalFile.Patch, "\n"); maxPatchLines > 0 && len(lines) > maxPatchLines {
+				minimalFile.Patch = strings.Join(lines[:maxPatchLines], "\n")
+				minimalFile.PatchTruncated = true
+			}
+		}
+		minimalComparison.Files = append(minimalComparison.Files, minimalFile)
+	}
+
+	return minimalComparison
+}
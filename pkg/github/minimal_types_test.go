@@ -0,0 +1,633 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var minimalTestTime = &github.Timestamp{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+func Test_ConvertToMinimal(t *testing.T) {
+	user := &github.User{
+		Login:     github.Ptr("octocat"),
+		ID:        github.Ptr(int64(1)),
+		AvatarURL: github.Ptr("https://avatars.githubusercontent.com/u/1"),
+		URL:       github.Ptr("https://api.github.com/users/octocat"),
+	}
+	issue := &github.Issue{
+		ID:          github.Ptr(int64(1001)),
+		Number:      github.Ptr(42),
+		Title:       github.Ptr("Crash on start"),
+		Body:        github.Ptr("It crashes."),
+		State:       github.Ptr("closed"),
+		StateReason: github.Ptr("completed"),
+		HTMLURL:     github.Ptr("https://github.com/owner/repo/issues/42"),
+		URL:         github.Ptr("https://api.github.com/repos/owner/repo/issues/42"),
+		User:        user,
+		Labels:      []*github.Label{{Name: github.Ptr("bug"), Color: github.Ptr("d73a4a")}},
+		Assignees:   []*github.User{user},
+		Milestone:   &github.Milestone{Title: github.Ptr("v1.0"), Number: github.Ptr(1)},
+		Comments:    github.Ptr(3),
+		Type:        &github.IssueType{Name: github.Ptr("Bug")},
+		Reactions:   &github.Reactions{TotalCount: github.Ptr(5)},
+		CreatedAt:   minimalTestTime,
+		ClosedAt:    minimalTestTime,
+	}
+
+	tests := []struct {
+		name     string
+		minimal  any
+		expected string
+	}{
+		{
+			name:    "issue",
+			minimal: convertToMinimalIssue(issue, true),
+			expected: `{
+				"number": 42,
+				"title": "Crash on start",
+				"body": "It crashes.",
+				"state": "closed",
+				"state_reason": "completed",
+				"type": "Bug",
+				"html_url": "https://github.com/owner/repo/issues/42",
+				"user": {"login": "octocat"},
+				"labels": ["bug"],
+				"assignees": ["octocat"],
+				"milestone": "v1.0",
+				"comments": 3,
+				"created_at": "2025-01-02T03:04:05Z",
+				"closed_at": "2025-01-02T03:04:05Z"
+			}`,
+		},
+		{
+			name: "issue in a list leaves out the body",
+			minimal: convertToMinimalIssue(&github.Issue{
+				Number:           github.Ptr(7),
+				Title:            github.Ptr("Add docs"),
+				Body:             github.Ptr("Long description"),
+				State:            github.Ptr("open"),
+				PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/7")},
+			}, false),
+			expected: `{"number": 7, "title": "Add docs", "state": "open", "is_pull_request": true}`,
+		},
+		{
+			name: "issue comment",
+			minimal: convertToMinimalIssueComment(&github.IssueComment{
+				ID:                github.Ptr(int64(5)),
+				Body:              github.Ptr("Same here"),
+				User:              user,
+				HTMLURL:           github.Ptr("https://github.com/owner/repo/issues/42#issuecomment-5"),
+				AuthorAssociation: github.Ptr("MEMBER"),
+				CreatedAt:         minimalTestTime,
+			}),
+			expected: `{
+				"id": 5,
+				"body": "Same here",
+				"user": {"login": "octocat"},
+				"html_url": "https://github.com/owner/repo/issues/42#issuecomment-5",
+				"created_at": "2025-01-02T03:04:05Z"
+			}`,
+		},
+		{
+			name: "pull request",
+			minimal: convertToMinimalPullRequest(&github.PullRequest{
+				Number:             github.Ptr(10),
+				Title:              github.Ptr("Fix crash"),
+				Body:               github.Ptr("Fixes #42"),
+				State:              github.Ptr("closed"),
+				Merged:             github.Ptr(true),
+				MergeableState:     github.Ptr("clean"),
+				HTMLURL:            github.Ptr("https://github.com/owner/repo/pull/10"),
+				User:               user,
+				Head:               &github.PullRequestBranch{Ref: github.Ptr("fix-crash"), SHA: github.Ptr("abc123"), Repo: &github.Repository{Name: github.Ptr("repo")}},
+				Base:               &github.PullRequestBranch{Ref: github.Ptr("main"), SHA: github.Ptr("def456")},
+				RequestedReviewers: []*github.User{{Login: github.Ptr("hubot")}},
+				Commits:            github.Ptr(2),
+				Additions:          github.Ptr(10),
+				Deletions:          github.Ptr(3),
+				ChangedFiles:       github.Ptr(1),
+				MergedAt:           minimalTestTime,
+			}, true),
+			expected: `{
+				"number": 10,
+				"title": "Fix crash",
+				"body": "Fixes #42",
+				"state": "closed",
+				"draft": false,
+				"merged": true,
+				"mergeable_state": "clean",
+				"html_url": "https://github.com/owner/repo/pull/10",
+				"user": {"login": "octocat"},
+				"head": "fix-crash",
+				"head_sha": "abc123",
+				"base": "main",
+				"requested_reviewers": ["hubot"],
+				"commits": 2,
+				"additions": 10,
+				"deletions": 3,
+				"changed_files": 1,
+				"merged_at": "2025-01-02T03:04:05Z"
+			}`,
+		},
+		{
+			name: "pull request review",
+			minimal: convertToMinimalPullRequestReview(&github.PullRequestReview{
+				ID:                github.Ptr(int64(80)),
+				State:             github.Ptr("APPROVED"),
+				Body:              github.Ptr("LGTM"),
+				User:              user,
+				CommitID:          github.Ptr("abc123"),
+				AuthorAssociation: github.Ptr("MEMBER"),
+				SubmittedAt:       minimalTestTime,
+			}),
+			expected: `{
+				"id": 80,
+				"state": "APPROVED",
+				"body": "LGTM",
+				"user": {"login": "octocat"},
+				"commit_id": "abc123",
+				"submitted_at": "2025-01-02T03:04:05Z"
+			}`,
+		},
+		{
+			name: "pull request review comment",
+			minimal: convertToMinimalPullRequestComment(&github.PullRequestComment{
+				ID:        github.Ptr(int64(90)),
+				Path:      github.Ptr("main.go"),
+				Line:      github.Ptr(12),
+				Body:      github.Ptr("Nit: rename this"),
+				DiffHunk:  github.Ptr("@@ -1,3 +1,4 @@"),
+				User:      user,
+				InReplyTo: github.Ptr(int64(89)),
+			}),
+			expected: `{
+				"id": 90,
+				"path": "main.go",
+				"line": 12,
+				"body": "Nit: rename this",
+				"user": {"login": "octocat"},
+				"in_reply_to_id": 89
+			}`,
+		},
+		{
+			name: "code scanning alert",
+			minimal: convertToMinimalCodeScanningAlert(&github.Alert{
+				Number: github.Ptr(3),
+				State:  github.Ptr("dismissed"),
+				Rule: &github.Rule{
+					ID:                    github.Ptr("go/sql-injection"),
+					Severity:              github.Ptr("error"),
+					SecuritySeverityLevel: github.Ptr("high"),
+					Description:           github.Ptr("Database query built from user-controlled sources"),
+					Help:                  github.Ptr("Long help text"),
+				},
+				Tool: &github.Tool{Name: github.Ptr("CodeQL"), Version: github.Ptr("2.20.0")},
+				MostRecentInstance: &github.MostRecentInstance{
+					Location: &github.Location{Path: github.Ptr("db.go"), StartLine: github.Ptr(27)},
+				},
+				HTMLURL:         github.Ptr("https://github.com/owner/repo/security/code-scanning/3"),
+				DismissedReason: github.Ptr("false positive"),
+			}),
+			expected: `{
+				"number": 3,
+				"state": "dismissed",
+				"rule_id": "go/sql-injection",
+				"severity": "error",
+				"security_severity_level": "high",
+				"description": "Database query built from user-controlled sources",
+				"tool": "CodeQL",
+				"path": "db.go",
+				"start_line": 27,
+				"html_url": "https://github.com/owner/repo/security/code-scanning/3",
+				"dismissed_reason": "false positive"
+			}`,
+		},
+		{
+			name: "secret scanning alert leaves out the secret",
+			minimal: convertToMinimalSecretScanningAlert(&github.SecretScanningAlert{
+				Number:                github.Ptr(2),
+				State:                 github.Ptr("open"),
+				SecretType:            github.Ptr("github_personal_access_token"),
+				SecretTypeDisplayName: github.Ptr("GitHub Personal Access Token"),
+				Secret:                github.Ptr("ghp_secret"),
+				Validity:              github.Ptr("active"),
+				HTMLURL:               github.Ptr("https://github.com/owner/repo/security/secret-scanning/2"),
+			}),
+			expected: `{
+				"number": 2,
+				"state": "open",
+				"secret_type": "github_personal_access_token",
+				"secret_type_display_name": "GitHub Personal Access Token",
+				"validity": "active",
+				"html_url": "https://github.com/owner/repo/security/secret-scanning/2"
+			}`,
+		},
+		{
+			name: "dependabot alert",
+			minimal: convertToMinimalDependabotAlert(&github.DependabotAlert{
+				Number: github.Ptr(4),
+				State:  github.Ptr("open"),
+				Dependency: &github.Dependency{
+					Package:      &github.VulnerabilityPackage{Ecosystem: github.Ptr("npm"), Name: github.Ptr("lodash")},
+					ManifestPath: github.Ptr("package-lock.json"),
+				},
+				SecurityAdvisory: &github.DependabotSecurityAdvisory{
+					GHSAID:      github.Ptr("GHSA-xxxx-yyyy-zzzz"),
+					CVEID:       github.Ptr("CVE-2021-23337"),
+					Summary:     github.Ptr("Command injection in lodash"),
+					Description: github.Ptr("Long description"),
+					Severity:    github.Ptr("high"),
+				},
+				SecurityVulnerability: &github.AdvisoryVulnerability{
+					VulnerableVersionRange: github.Ptr("< 4.17.21"),
+					FirstPatchedVersion:    &github.FirstPatchedVersion{Identifier: github.Ptr("4.17.21")},
+				},
+				HTMLURL: github.Ptr("https://github.com/owner/repo/security/dependabot/4"),
+			}),
+			expected: `{
+				"number": 4,
+				"state": "open",
+				"package": "lodash",
+				"ecosystem": "npm",
+				"manifest_path": "package-lock.json",
+				"severity": "high",
+				"summary": "Command injection in lodash",
+				"ghsa_id": "GHSA-xxxx-yyyy-zzzz",
+				"cve_id": "CVE-2021-23337",
+				"vulnerable_version_range": "< 4.17.21",
+				"first_patched_version": "4.17.21",
+				"html_url": "https://github.com/owner/repo/security/dependabot/4"
+			}`,
+		},
+		{
+			name: "notification",
+			minimal: convertToMinimalNotification(&github.Notification{
+				ID:         github.Ptr("123"),
+				Repository: &github.Repository{FullName: github.Ptr("owner/repo"), Name: github.Ptr("repo")},
+				Subject: &github.NotificationSubject{
+					Title:            github.Ptr("Crash on start"),
+					Type:             github.Ptr("Issue"),
+					URL:              github.Ptr("https://api.github.com/repos/owner/repo/issues/42"),
+					LatestCommentURL: github.Ptr("https://api.github.com/repos/owner/repo/issues/comments/5"),
+				},
+				Reason:    github.Ptr("mention"),
+				Unread:    github.Ptr(true),
+				UpdatedAt: minimalTestTime,
+			}),
+			expected: `{
+				"id": "123",
+				"repository": "owner/repo",
+				"reason": "mention",
+				"unread": true,
+				"type": "Issue",
+				"title": "Crash on start",
+				"url": "https://api.github.com/repos/owner/repo/issues/42",
+				"updated_at": "2025-01-02T03:04:05Z"
+			}`,
+		},
+		{
+			name: "discussion",
+			minimal: convertToMinimalDiscussion(&github.Discussion{
+				Number:             github.Ptr(1),
+				Title:              github.Ptr("Roadmap"),
+				Body:               github.Ptr("What's next?"),
+				HTMLURL:            github.Ptr("https://github.com/owner/repo/discussions/1"),
+				User:               user,
+				DiscussionCategory: &github.DiscussionCategory{Name: github.Ptr("Ideas"), ID: github.Ptr(int64(9))},
+				CreatedAt:          minimalTestTime,
+			}, true),
+			expected: `{
+				"number": 1,
+				"title": "Roadmap",
+				"body": "What's next?",
+				"category": "Ideas",
+				"user": {"login": "octocat"},
+				"html_url": "https://github.com/owner/repo/discussions/1",
+				"created_at": "2025-01-02T03:04:05Z"
+			}`,
+		},
+		{
+			name: "workflow job lists its failed steps",
+			minimal: convertToMinimalWorkflowJob(&github.WorkflowJob{
+				ID:         github.Ptr(int64(399444496)),
+				RunID:      github.Ptr(int64(29679449)),
+				Name:       github.Ptr("build"),
+				Status:     github.Ptr("completed"),
+				Conclusion: github.Ptr("failure"),
+				HeadSHA:    github.Ptr("f83a356604ae3c5d03e1b46ef4d1ca77d64a90b0"),
+				HTMLURL:    github.Ptr("https://github.com/owner/repo/actions/runs/29679449/job/399444496"),
+				Steps: []*github.TaskStep{
+					{Name: github.Ptr("Checkout"), Conclusion: github.Ptr("success")},
+					{Name: github.Ptr("Run tests"), Conclusion: github.Ptr("failure")},
+					{Name: github.Ptr("Upload results"), Conclusion: github.Ptr("skipped")},
+				},
+				StartedAt:   minimalTestTime,
+				CompletedAt: minimalTestTime,
+			}),
+			expected: `{
+				"id": 399444496,
+				"run_id": 29679449,
+				"name": "build",
+				"status": "completed",
+				"conclusion": "failure",
+				"failed_steps": ["Run tests"],
+				"html_url": "https://github.com/owner/repo/actions/runs/29679449/job/399444496",
+				"started_at": "2025-01-02T03:04:05Z",
+				"completed_at": "2025-01-02T03:04:05Z"
+			}`,
+		},
+		{
+			name: "release in a list leaves out the body",
+			minimal: convertToMinimalRelease(&github.RepositoryRelease{
+				ID:          github.Ptr(int64(11)),
+				TagName:     github.Ptr("v1.0.0"),
+				Name:        github.Ptr("First release"),
+				Body:        github.Ptr("Release notes"),
+				HTMLURL:     github.Ptr("https://github.com/owner/repo/releases/tag/v1.0.0"),
+				Assets:      []*github.ReleaseAsset{{Name: github.Ptr("binary.zip")}},
+				Author:      user,
+				PublishedAt: minimalTestTime,
+			}, false),
+			expected: `{
+				"id": 11,
+				"tag_name": "v1.0.0",
+				"name": "First release",
+				"html_url": "https://github.com/owner/repo/releases/tag/v1.0.0",
+				"published_at": "2025-01-02T03:04:05Z",
+				"prerelease": false,
+				"draft": false,
+				"author": {"login": "octocat"}
+			}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := json.Marshal(tc.minimal)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(actual))
+		})
+	}
+}
+
+func Test_WithMinimalOutput(t *testing.T) {
+	tool := mcp.NewTool("tool",
+		WithOutputSchema[*github.Issue](),
+		WithMinimalOutput[MinimalIssue](),
+	)
+	assert.Contains(t, tool.InputSchema.Properties, "minimal_output")
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(tool.RawOutputSchema, &schema))
+	assert.Equal(t, "object", schema["type"])
+	require.Len(t, schema["anyOf"], 2)
+
+	// Identical schemas are not repeated
+	tool = mcp.NewTool("tool",
+		WithOutputSchema[map[string]any](),
+		WithMinimalOutput[map[string]any](),
+	)
+	assert.JSONEq(t, string(OutputSchema[map[string]any]()), string(tool.RawOutputSchema))
+}
+
+func Test_MinimalOutputHandlers(t *testing.T) {
+	mockIssue := &github.Issue{
+		Number:  github.Ptr(42),
+		Title:   github.Ptr("Crash on start"),
+		Body:    github.Ptr("It crashes."),
+		State:   github.Ptr("open"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/issues/42"),
+		User:    &github.User{Login: github.Ptr("octocat"), ID: github.Ptr(int64(1))},
+	}
+
+	t.Run("get_issue", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepoByIssueNumber, mockIssue),
+		))
+		_, handler := GetIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":          "owner",
+			"repo":           "repo",
+			"issue_number":   float64(42),
+			"minimal_output": true,
+		}))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"number": 42,
+			"title": "Crash on start",
+			"body": "It crashes.",
+			"state": "open",
+			"html_url": "https://github.com/owner/repo/issues/42",
+			"user": {"login": "octocat"}
+		}`, getTextResult(t, result).Text)
+	})
+
+	t.Run("search_issues", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetSearchIssues, &github.IssuesSearchResult{
+				Total:             github.Ptr(1),
+				IncompleteResults: github.Ptr(false),
+				Issues:            []*github.Issue{mockIssue},
+			}),
+		))
+		_, handler := SearchIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"query":          "crash",
+			"minimal_output": true,
+		}))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"total_count": 1,
+			"incomplete_results": false,
+			"items": [{
+				"number": 42,
+				"title": "Crash on start",
+				"state": "open",
+				"html_url": "https://github.com/owner/repo/issues/42",
+				"user": {"login": "octocat"}
+			}]
+		}`, getTextResult(t, result).Text)
+	})
+
+	t.Run("list_pull_requests", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepo, []*github.PullRequest{{
+				Number:  github.Ptr(10),
+				Title:   github.Ptr("Fix crash"),
+				Body:    github.Ptr("Fixes #42"),
+				State:   github.Ptr("open"),
+				Draft:   github.Ptr(true),
+				HTMLURL: github.Ptr("https://github.com/owner/repo/pull/10"),
+				Head:    &github.PullRequestBranch{Ref: github.Ptr("fix-crash")},
+				Base:    &github.PullRequestBranch{Ref: github.Ptr("main")},
+			}}),
+		))
+		_, handler := ListPullRequests(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":          "owner",
+			"repo":           "repo",
+			"minimal_output": true,
+		}))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"items": [{
+				"number": 10,
+				"title": "Fix crash",
+				"state": "open",
+				"draft": true,
+				"merged": false,
+				"html_url": "https://github.com/owner/repo/pull/10",
+				"head": "fix-crash",
+				"base": "main"
+			}],
+			"page_info": {"has_next": false},
+			"truncated": false
+		}`, getTextResult(t, result).Text)
+	})
+
+	t.Run("list_secret_scanning_alerts", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposSecretScanningAlertsByOwnerByRepo, []*github.SecretScanningAlert{{
+				Number:     github.Ptr(2),
+				State:      github.Ptr("open"),
+				SecretType: github.Ptr("github_personal_access_token"),
+				Secret:     github.Ptr("ghp_secret"),
+				HTMLURL:    github.Ptr("https://github.com/owner/repo/security/secret-scanning/2"),
+			}}),
+		))
+		_, handler := ListSecretScanningAlerts(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":          "owner",
+			"repo":           "repo",
+			"minimal_output": true,
+		}))
+		require.NoError(t, err)
+		text := getTextResult(t, result).Text
+		assert.NotContains(t, text, "ghp_secret")
+		assert.JSONEq(t, `[{
+			"number": 2,
+			"state": "open",
+			"secret_type": "github_personal_access_token",
+			"html_url": "https://github.com/owner/repo/security/secret-scanning/2"
+		}]`, text)
+	})
+
+	t.Run("get_latest_release", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposReleasesLatestByOwnerByRepo, &github.RepositoryRelease{
+				ID:      github.Ptr(int64(11)),
+				TagName: github.Ptr("v1.0.0"),
+				Body:    github.Ptr("Release notes"),
+				HTMLURL: github.Ptr("https://github.com/owner/repo/releases/tag/v1.0.0"),
+			}),
+		))
+		_, handler := GetLatestRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":          "owner",
+			"repo":           "repo",
+			"minimal_output": true,
+		}))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"id": 11,
+			"tag_name": "v1.0.0",
+			"body": "Release notes",
+			"html_url": "https://github.com/owner/repo/releases/tag/v1.0.0",
+			"prerelease": false,
+			"draft": false
+		}`, getTextResult(t, result).Text)
+	})
+
+	t.Run("list_workflows", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposActionsWorkflowsByOwnerByRepo, &github.Workflows{
+				TotalCount: github.Ptr(1),
+				Workflows: []*github.Workflow{{
+					ID:        github.Ptr(int64(161335)),
+					NodeID:    github.Ptr("MDg6V29ya2Zsb3cxNjEzMzU="),
+					Name:      github.Ptr("CI"),
+					Path:      github.Ptr(".github/workflows/ci.yml"),
+					State:     github.Ptr("active"),
+					HTMLURL:   github.Ptr("https://github.com/owner/repo/blob/main/.github/workflows/ci.yml"),
+					BadgeURL:  github.Ptr("https://github.com/owner/repo/workflows/CI/badge.svg"),
+					CreatedAt: minimalTestTime,
+				}},
+			}),
+		))
+		_, handler := ListWorkflows(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":          "owner",
+			"repo":           "repo",
+			"minimal_output": true,
+		}))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"items": [{
+				"id": 161335,
+				"name": "CI",
+				"path": ".github/workflows/ci.yml",
+				"state": "active",
+				"html_url": "https://github.com/owner/repo/blob/main/.github/workflows/ci.yml",
+				"created_at": "2025-01-02T03:04:05Z"
+			}],
+			"page_info": {"has_next": false, "total_count": 1},
+			"truncated": false
+		}`, getTextResult(t, result).Text)
+	})
+
+	t.Run("list_workflow_jobs", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposActionsRunsJobsByOwnerByRepoByRunId, &github.Jobs{
+				TotalCount: github.Ptr(1),
+				Jobs: []*github.WorkflowJob{{
+					ID:         github.Ptr(int64(399444496)),
+					RunID:      github.Ptr(int64(12345)),
+					Name:       github.Ptr("build"),
+					Status:     github.Ptr("completed"),
+					Conclusion: github.Ptr("success"),
+					RunnerName: github.Ptr("GitHub Actions 2"),
+					Labels:     []string{"ubuntu-latest"},
+					HTMLURL:    github.Ptr("https://github.com/owner/repo/actions/runs/12345/job/399444496"),
+					Steps:      []*github.TaskStep{{Name: github.Ptr("Checkout"), Conclusion: github.Ptr("success")}},
+				}},
+			}),
+		))
+		_, handler := ListWorkflowJobs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":          "owner",
+			"repo":           "repo",
+			"run_id":         float64(12345),
+			"minimal_output": true,
+		}))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"items": [{
+				"id": 399444496,
+				"run_id": 12345,
+				"name": "build",
+				"status": "completed",
+				"conclusion": "success",
+				"html_url": "https://github.com/owner/repo/actions/runs/12345/job/399444496"
+			}],
+			"page_info": {"has_next": false, "total_count": 1},
+			"truncated": false,
+			"optimization_tip": "For debugging failed jobs, consider using get_job_logs with failed_only=true and run_id=12345 to get logs directly without needing to list jobs first"
+		}`, getTextResult(t, result).Text)
+	})
+}
@@ -47,8 +47,15 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 				mcp.Description("Optional repository name. If provided with owner, only notifications for this repository are listed."),
 			),
 			WithPagination(),
+			WithOutputSchema[ListResult[*github.Notification]](),
+			WithMinimalOutput[ListResult[MinimalNotification]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -136,7 +143,11 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 			}
 
 			// Marshal response to JSON
-			r, err := json.Marshal(notifications)
+			if minimalOutput {
+				return MarshalledTextResult(convertListResult(restListResult(notifications, resp), convertToMinimalNotification)), nil
+			}
+
+			r, err := json.Marshal(restListResult(notifications, resp))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -158,6 +169,7 @@ func DismissNotification(getclient GetClientFn, t translations.TranslationHelper
 				mcp.Description("The ID of the notification thread"),
 			),
 			mcp.WithString("state", mcp.Description("The new state of the notification (read/done)"), mcp.Enum("read", "done")),
+			WithOutputSchema[MessageResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := getclient(ctx)
@@ -208,7 +220,7 @@ func DismissNotification(getclient GetClientFn, t translations.TranslationHelper
 				return mcp.NewToolResultError(fmt.Sprintf("failed to mark notification as %s: %s", state, string(body))), nil
 			}
 
-			return mcp.NewToolResultText(fmt.Sprintf("Notification marked as %s", state)), nil
+			return MarshalledTextResult(MessageResponse{Message: fmt.Sprintf("Notification marked as %s", state)}), nil
 		}
 }
 
@@ -229,6 +241,7 @@ func MarkAllNotificationsRead(getClient GetClientFn, t translations.TranslationH
 			mcp.WithString("repo",
 				mcp.Description("Optional repository name. If provided with owner, only notifications for this repository are marked as read."),
 			),
+			WithOutputSchema[MessageResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := getClient(ctx)
@@ -287,7 +300,7 @@ func MarkAllNotificationsRead(getClient GetClientFn, t translations.TranslationH
 				return mcp.NewToolResultError(fmt.Sprintf("failed to mark all notifications as read: %s", string(body))), nil
 			}
 
-			return mcp.NewToolResultText("All notifications marked as read"), nil
+			return MarshalledTextResult(MessageResponse{Message: "All notifications marked as read"}), nil
 		}
 }
 
@@ -303,8 +316,15 @@ func GetNotificationDetails(getClient GetClientFn, t translations.TranslationHel
 				mcp.Required(),
 				mcp.Description("The ID of the notification"),
 			),
+			WithOutputSchema[*github.Notification](),
+			WithMinimalOutput[MinimalNotification](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -333,6 +353,10 @@ func GetNotificationDetails(getClient GetClientFn, t translations.TranslationHel
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get notification details: %s", string(body))), nil
 			}
 
+			if minimalOutput {
+				return MarshalledTextResult(convertToMinimalNotification(thread)), nil
+			}
+
 			r, err := json.Marshal(thread)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
@@ -366,6 +390,7 @@ func ManageNotificationSubscription(getClient GetClientFn, t translations.Transl
 				mcp.Description("Action to perform: ignore, watch, or delete the notification subscription."),
 				mcp.Enum(NotificationActionIgnore, NotificationActionWatch, NotificationActionDelete),
 			),
+			WithRawOutputSchema(OutputSchemaAnyOf(OutputSchema[*github.Subscription](), OutputSchema[MessageResponse]())),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := getClient(ctx)
@@ -417,7 +442,7 @@ func ManageNotificationSubscription(getClient GetClientFn, t translations.Transl
 
 			if action == NotificationActionDelete {
 				// Special case for delete as there is no response body
-				return mcp.NewToolResultText("Notification subscription deleted"), nil
+				return MarshalledTextResult(MessageResponse{Message: "Notification subscription deleted"}), nil
 			}
 
 			r, err := json.Marshal(result)
@@ -455,6 +480,7 @@ func ManageRepositoryNotificationSubscription(getClient GetClientFn, t translati
 				mcp.Description("Action to perform: ignore, watch, or delete the repository notification subscription."),
 				mcp.Enum(RepositorySubscriptionActionIgnore, RepositorySubscriptionActionWatch, RepositorySubscriptionActionDelete),
 			),
+			WithRawOutputSchema(OutputSchemaAnyOf(OutputSchema[*github.Subscription](), OutputSchema[MessageResponse]())),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := getClient(ctx)
@@ -513,7 +539,7 @@ func ManageRepositoryNotificationSubscription(getClient GetClientFn, t translati
 
 			if action == RepositorySubscriptionActionDelete {
 				// Special case for delete as there is no response body
-				return mcp.NewToolResultText("Repository subscription deleted"), nil
+				return MarshalledTextResult(MessageResponse{Message: "Repository subscription deleted"}), nil
 			}
 
 			r, err := json.Marshal(result)
@@ -140,9 +140,10 @@ func Test_ListNotifications(t *testing.T) {
 			require.False(t, result.IsError)
 			textContent := getTextResult(t, result)
 			t.Logf("textContent: %s", textContent.Text)
-			var returned []*github.Notification
-			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			var response ListResult[*github.Notification]
+			err = json.Unmarshal([]byte(textContent.Text), &response)
 			require.NoError(t, err)
+			returned := response.Items
 			require.NotEmpty(t, returned)
 			assert.Equal(t, *tc.expectedResult[0].ID, *returned[0].ID)
 		})
@@ -0,0 +1,402 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// GetIssueResource defines the resource template and handler for reading an issue and its comments as markdown.
+func GetIssueResource(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"repo://{owner}/{repo}/issues/{number}", // Resource template
+			t("RESOURCE_ISSUE_DESCRIPTION", "Issue"),
+			mcp.WithTemplateMIMEType("text/markdown"),
+		),
+		IssueResourceHandler(getClient)
+}
+
+// GetPullRequestResource defines the resource template and handler for reading a pull request and its comments as markdown.
+func GetPullRequestResource(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"repo://{owner}/{repo}/pulls/{number}", // Resource template
+			t("RESOURCE_PULL_REQUEST_DESCRIPTION", "Pull Request"),
+			mcp.WithTemplateMIMEType("text/markdown"),
+		),
+		PullRequestResourceHandler(getClient)
+}
+
+// GetPullRequestDiffResource defines the resource template and handler for reading the diff of a pull request.
+func GetPullRequestDiffResource(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"repo://{owner}/{repo}/pulls/{number}/diff", // Resource template
+			t("RESOURCE_PULL_REQUEST_DIFF_DESCRIPTION", "Pull Request Diff"),
+			mcp.WithTemplateMIMEType("text/x-diff"),
+		),
+		PullRequestDiffResourceHandler(getClient)
+}
+
+// GetDiscussionResource defines the resource template and handler for reading a discussion and its comments as markdown.
+func GetDiscussionResource(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"repo://{owner}/{repo}/discussions/{number}", // Resource template
+			t("RESOURCE_DISCUSSION_DESCRIPTION", "Discussion"),
+			mcp.WithTemplateMIMEType("text/markdown"),
+		),
+		DiscussionResourceHandler(getGQLClient)
+}
+
+// GetWorkflowRunLogsResource defines the resource template and handler for reading the logs of every job in a workflow run.
+func GetWorkflowRunLogsResource(getClient GetClientFn, t translations.TranslationHelperFunc, contentWindowSize int) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"repo://{owner}/{repo}/actions/runs/{id}/logs", // Resource template
+			t("RESOURCE_WORKFLOW_RUN_LOGS_DESCRIPTION", "Workflow Run Logs"),
+			mcp.WithTemplateMIMEType("text/plain"),
+		),
+		WorkflowRunLogsResourceHandler(getClient, contentWindowSize)
+}
+
+// resourceArg returns a single-valued URI template variable from the request.
+func resourceArg(request mcp.ReadResourceRequest, name string) (string, error) {
+	// the matcher will give []string with one element
+	// https://github.com/mark3labs/mcp-go/pull/54
+	v, ok := request.Params.Arguments[name].([]string)
+	if !ok || len(v) == 0 || v[0] == "" {
+		return "", fmt.Errorf("%s is required", name)
+	}
+	return v[0], nil
+}
+
+// resourceRepoArgs returns the owner, repo and the named number from the request.
+func resourceRepoArgs(request mcp.ReadResourceRequest, numberName string) (string, string, int64, error) {
+	owner, err := resourceArg(request, "owner")
+	if err != nil {
+		return "", "", 0, err
+	}
+	repo, err := resourceArg(request, "repo")
+	if err != nil {
+		return "", "", 0, err
+	}
+	n, err := resourceArg(request, numberName)
+	if err != nil {
+		return "", "", 0, err
+	}
+	number, err := strconv.ParseInt(n, 10, 64)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid %s: %w", numberName, err)
+	}
+	return owner, repo, number, nil
+}
+
+func markdownResource(uri, text string) []mcp.ResourceContents {
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "text/markdown",
+			Text:     text,
+		},
+	}
+}
+
+func formatResourceTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// writeMarkdownComments renders comments as a "Comments" section.
+func writeMarkdownComments(b *strings.Builder, comments []*github.IssueComment) {
+	fmt.Fprintf(b, "\n## Comments (%d)\n", len(comments))
+	for _, comment := range comments {
+		fmt.Fprintf(b, "\n### @%s commented on %s\n\n%s\n", comment.GetUser().GetLogin(), formatResourceTime(comment.GetCreatedAt().Time), comment.GetBody())
+	}
+}
+
+func labelNames(labels []*github.Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.GetName())
+	}
+	return names
+}
+
+func userLogins(users []*github.User) []string {
+	logins := make([]string, 0, len(users))
+	for _, user := range users {
+		logins = append(logins, "@"+user.GetLogin())
+	}
+	return logins
+}
+
+// IssueResourceHandler returns a handler function for issue resource requests.
+func IssueResourceHandler(getClient GetClientFn) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		owner, repo, number, err := resourceRepoArgs(request, "number")
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		issue, _, err := client.Issues.Get(ctx, owner, repo, int(number))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue: %w", err)
+		}
+
+		comments, _, err := client.Issues.ListComments(ctx, owner, repo, int(number), &github.IssueListCommentsOptions{
+			ListOptions: github.ListOptions{PerPage: 100},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue comments: %w", err)
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "# %s (#%d)\n\n", issue.GetTitle(), issue.GetNumber())
+		fmt.Fprintf(&b, "- **State:** %s\n", issue.GetState())
+		fmt.Fprintf(&b, "- **Author:** @%s\n", issue.GetUser().GetLogin())
+		fmt.Fprintf(&b, "- **Created:** %s\n", formatResourceTime(issue.GetCreatedAt().Time))
+		fmt.Fprintf(&b, "- **Updated:** %s\n", formatResourceTime(issue.GetUpdatedAt().Time))
+		if len(issue.Labels) > 0 {
+			fmt.Fprintf(&b, "- **Labels:** %s\n", strings.Join(labelNames(issue.Labels), ", "))
+		}
+		if len(issue.Assignees) > 0 {
+			fmt.Fprintf(&b, "- **Assignees:** %s\n", strings.Join(userLogins(issue.Assignees), ", "))
+		}
+		fmt.Fprintf(&b, "- **URL:** %s\n", issue.GetHTMLURL())
+		fmt.Fprintf(&b, "\n%s\n", issue.GetBody())
+		writeMarkdownComments(&b, comments)
+
+		return markdownResource(request.Params.URI, b.String()), nil
+	}
+}
+
+// PullRequestResourceHandler returns a handler function for pull request resource requests.
+func PullRequestResourceHandler(getClient GetClientFn) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		owner, repo, number, err := resourceRepoArgs(request, "number")
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		pr, _, err := client.PullRequests.Get(ctx, owner, repo, int(number))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request: %w", err)
+		}
+
+		comments, _, err := client.Issues.ListComments(ctx, owner, repo, int(number), &github.IssueListCommentsOptions{
+			ListOptions: github.ListOptions{PerPage: 100},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request comments: %w", err)
+		}
+
+		state := pr.GetState()
+		if pr.GetMerged() {
+			state = "merged"
+		} else if pr.GetDraft() {
+			state += " (draft)"
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "# %s (#%d)\n\n", pr.GetTitle(), pr.GetNumber())
+		fmt.Fprintf(&b, "- **State:** %s\n", state)
+		fmt.Fprintf(&b, "- **Author:** @%s\n", pr.GetUser().GetLogin())
+		fmt.Fprintf(&b, "- **Branches:** %s ← %s\n", pr.GetBase().GetRef(), pr.GetHead().GetRef())
+		fmt.Fprintf(&b, "- **Head SHA:** %s\n", pr.GetHead().GetSHA())
+		fmt.Fprintf(&b, "- **Changes:** %d files, +%d -%d\n", pr.GetChangedFiles(), pr.GetAdditions(), pr.GetDeletions())
+		fmt.Fprintf(&b, "- **Created:** %s\n", formatResourceTime(pr.GetCreatedAt().Time))
+		fmt.Fprintf(&b, "- **Updated:** %s\n", formatResourceTime(pr.GetUpdatedAt().Time))
+		if len(pr.Labels) > 0 {
+			fmt.Fprintf(&b, "- **Labels:** %s\n", strings.Join(labelNames(pr.Labels), ", "))
+		}
+		if len(pr.RequestedReviewers) > 0 {
+			fmt.Fprintf(&b, "- **Requested reviewers:** %s\n", strings.Join(userLogins(pr.RequestedReviewers), ", "))
+		}
+		fmt.Fprintf(&b, "- **URL:** %s\n", pr.GetHTMLURL())
+		fmt.Fprintf(&b, "\n%s\n", pr.GetBody())
+		writeMarkdownComments(&b, comments)
+
+		return markdownResource(request.Params.URI, b.String()), nil
+	}
+}
+
+// PullRequestDiffResourceHandler returns a handler function for pull request diff resource requests.
+func PullRequestDiffResourceHandler(getClient GetClientFn) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		owner, repo, number, err := resourceRepoArgs(request, "number")
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		diff, _, err := client.PullRequests.GetRaw(ctx, owner, repo, int(number), github.RawOptions{Type: github.Diff})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request diff: %w", err)
+		}
+
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "text/x-diff",
+				Text:     diff,
+			},
+		}, nil
+	}
+}
+
+// DiscussionResourceHandler returns a handler function for discussion resource requests.
+func DiscussionResourceHandler(getGQLClient GetGQLClientFn) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		owner, repo, number, err := resourceRepoArgs(request, "number")
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := getGQLClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+		}
+
+		var q struct {
+			Repository struct {
+				Discussion struct {
+					Number    githubv4.Int
+					Title     githubv4.String
+					Body      githubv4.String
+					CreatedAt githubv4.DateTime
+					URL       githubv4.String `graphql:"url"`
+					Author    struct {
+						Login githubv4.String
+					}
+					Category struct {
+						Name githubv4.String
+					} `graphql:"category"`
+					Comments struct {
+						TotalCount githubv4.Int
+						Nodes      []struct {
+							Body      githubv4.String
+							CreatedAt githubv4.DateTime
+							Author    struct {
+								Login githubv4.String
+							}
+						}
+					} `graphql:"comments(first: 100)"`
+				} `graphql:"discussion(number: $discussionNumber)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}
+		vars := map[string]interface{}{
+			"owner":            githubv4.String(owner),
+			"repo":             githubv4.String(repo),
+			"discussionNumber": githubv4.Int(number), // #nosec G115 - discussion numbers are always small positive integers
+		}
+		if err := client.Query(ctx, &q, vars); err != nil {
+			return nil, fmt.Errorf("failed to get discussion: %w", err)
+		}
+
+		d := q.Repository.Discussion
+		var b strings.Builder
+		fmt.Fprintf(&b, "# %s (#%d)\n\n", d.Title, d.Number)
+		fmt.Fprintf(&b, "- **Category:** %s\n", d.Category.Name)
+		fmt.Fprintf(&b, "- **Author:** @%s\n", d.Author.Login)
+		fmt.Fprintf(&b, "- **Created:** %s\n", formatResourceTime(d.CreatedAt.Time))
+		fmt.Fprintf(&b, "- **URL:** %s\n", d.URL)
+		fmt.Fprintf(&b, "\n%s\n", d.Body)
+		fmt.Fprintf(&b, "\n## Comments (%d)\n", d.Comments.TotalCount)
+		for _, comment := range d.Comments.Nodes {
+			fmt.Fprintf(&b, "\n### @%s commented on %s\n\n%s\n", comment.Author.Login, formatResourceTime(comment.CreatedAt.Time), comment.Body)
+		}
+
+		return markdownResource(request.Params.URI, b.String()), nil
+	}
+}
+
+// WorkflowRunLogsResourceHandler returns a handler function for workflow run log resource requests.
+// The logs of each job are concatenated, keeping the last contentWindowSize lines of every job.
+func WorkflowRunLogsResourceHandler(getClient GetClientFn, contentWindowSize int) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		owner, repo, runID, err := resourceRepoArgs(request, "id")
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		jobs, _, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, runID, &github.ListWorkflowJobsOptions{
+			Filter:      "latest",
+			ListOptions: github.ListOptions{PerPage: 100},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list workflow jobs: %w", err)
+		}
+		if len(jobs.Jobs) == 0 {
+			return nil, errors.New("workflow run has no jobs")
+		}
+
+		var b strings.Builder
+		for i, job := range jobs.Jobs {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "===== %s (job %d, %s) =====\n", job.GetName(), job.GetID(), jobStatus(job))
+
+			logURL, _, err := client.Actions.GetWorkflowJobLogs(ctx, owner, repo, job.GetID(), 1)
+			if err != nil {
+				fmt.Fprintf(&b, "failed to get job logs: %v\n", err)
+				continue
+			}
+			content, totalLines, _, err := downloadLogContent(ctx, logURL.String(), contentWindowSize, contentWindowSize) //nolint:bodyclose // Response body is closed in downloadLogContent
+			if err != nil {
+				fmt.Fprintf(&b, "failed to download job logs: %v\n", err)
+				continue
+			}
+			if totalLines > contentWindowSize {
+				fmt.Fprintf(&b, "[showing last %d of %d lines]\n", contentWindowSize, totalLines)
+			}
+			b.WriteString(content)
+			if !strings.HasSuffix(content, "\n") {
+				b.WriteString("\n")
+			}
+		}
+
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "text/plain",
+				Text:     b.String(),
+			},
+		}, nil
+	}
+}
+
+// jobStatus returns the conclusion of a finished job or the status of a running one.
+func jobStatus(job *github.WorkflowJob) string {
+	if job.GetConclusion() != "" {
+		return job.GetConclusion()
+	}
+	return job.GetStatus()
+}
@@ -0,0 +1,335 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getResourceText is a helper function that returns the single text content of a resource read.
+func getResourceText(t *testing.T, contents []mcp.ResourceContents) mcp.TextResourceContents {
+	t.Helper()
+	require.Len(t, contents, 1)
+	require.IsType(t, mcp.TextResourceContents{}, contents[0])
+	return contents[0].(mcp.TextResourceContents)
+}
+
+func Test_ObjectResourceTemplates(t *testing.T) {
+	tests := []struct {
+		template    mcp.ResourceTemplate
+		uri         string
+		expectMatch bool
+	}{
+		{template: first(GetIssueResource(nil, translations.NullTranslationHelper)), uri: "repo://owner/repo/issues/42", expectMatch: true},
+		{template: first(GetPullRequestResource(nil, translations.NullTranslationHelper)), uri: "repo://owner/repo/pulls/42", expectMatch: true},
+		{template: first(GetPullRequestResource(nil, translations.NullTranslationHelper)), uri: "repo://owner/repo/pulls/42/diff", expectMatch: false},
+		{template: first(GetPullRequestDiffResource(nil, translations.NullTranslationHelper)), uri: "repo://owner/repo/pulls/42/diff", expectMatch: true},
+		{template: first(GetDiscussionResource(nil, translations.NullTranslationHelper)), uri: "repo://owner/repo/discussions/7", expectMatch: true},
+		{template: first(GetWorkflowRunLogsResource(nil, translations.NullTranslationHelper, 100)), uri: "repo://owner/repo/actions/runs/123/logs", expectMatch: true},
+		{template: first(GetRepositoryResourceContent(nil, nil, translations.NullTranslationHelper)), uri: "repo://owner/repo/issues/42", expectMatch: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.uri, func(t *testing.T) {
+			assert.Equal(t, tc.expectMatch, tc.template.URITemplate.Regexp().MatchString(tc.uri))
+		})
+	}
+}
+
+func first[T, U any](v T, _ U) T {
+	return v
+}
+
+func Test_IssueResourceHandler(t *testing.T) {
+	mockIssue := &github.Issue{
+		Number:    github.Ptr(42),
+		Title:     github.Ptr("Crash on startup"),
+		Body:      github.Ptr("The server crashes."),
+		State:     github.Ptr("open"),
+		HTMLURL:   github.Ptr("https://github.com/owner/repo/issues/42"),
+		User:      &github.User{Login: github.Ptr("octocat")},
+		Labels:    []*github.Label{{Name: github.Ptr("bug")}},
+		Assignees: []*github.User{{Login: github.Ptr("hubot")}},
+		CreatedAt: &github.Timestamp{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+	}
+	mockComments := []*github.IssueComment{
+		{
+			Body:      github.Ptr("I can reproduce this."),
+			User:      &github.User{Login: github.Ptr("hubot")},
+			CreatedAt: &github.Timestamp{Time: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		mockedClient *http.Client
+		requestArgs  map[string]any
+		expectError  string
+		expectedText []string
+	}{
+		{
+			name: "successful issue read",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepoByIssueNumber, mockIssue),
+				mock.WithRequestMatch(mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber, mockComments),
+			),
+			requestArgs: map[string]any{
+				"owner":  []string{"owner"},
+				"repo":   []string{"repo"},
+				"number": []string{"42"},
+			},
+			expectedText: []string{
+				"# Crash on startup (#42)",
+				"- **Labels:** bug",
+				"- **Assignees:** @hubot",
+				"The server crashes.",
+				"## Comments (1)",
+				"### @hubot commented on 2025-01-03T00:00:00Z",
+			},
+		},
+		{
+			name:         "invalid issue number",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":  []string{"owner"},
+				"repo":   []string{"repo"},
+				"number": []string{"abc"},
+			},
+			expectError: "invalid number",
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  []string{"owner"},
+				"repo":   []string{"repo"},
+				"number": []string{"999"},
+			},
+			expectError: "failed to get issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			handler := IssueResourceHandler(stubGetClientFn(client))
+
+			request := mcp.ReadResourceRequest{
+				Params: struct {
+					URI       string         `json:"uri"`
+					Arguments map[string]any `json:"arguments,omitempty"`
+				}{
+					URI:       "repo://owner/repo/issues/42",
+					Arguments: tc.requestArgs,
+				},
+			}
+
+			contents, err := handler(context.Background(), request)
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+
+			text := getResourceText(t, contents)
+			assert.Equal(t, "text/markdown", text.MIMEType)
+			assert.Equal(t, "repo://owner/repo/issues/42", text.URI)
+			for _, expected := range tc.expectedText {
+				assert.Contains(t, text.Text, expected)
+			}
+		})
+	}
+}
+
+func Test_PullRequestResourceHandlers(t *testing.T) {
+	mockPR := &github.PullRequest{
+		Number:       github.Ptr(7),
+		Title:        github.Ptr("Add feature"),
+		Body:         github.Ptr("Implements the feature."),
+		State:        github.Ptr("closed"),
+		Merged:       github.Ptr(true),
+		User:         &github.User{Login: github.Ptr("octocat")},
+		Base:         &github.PullRequestBranch{Ref: github.Ptr("main")},
+		Head:         &github.PullRequestBranch{Ref: github.Ptr("feature"), SHA: github.Ptr("abc123")},
+		ChangedFiles: github.Ptr(2),
+		Additions:    github.Ptr(10),
+		Deletions:    github.Ptr(3),
+	}
+	stubbedDiff := "diff --git a/file.go b/file.go\n+added line\n"
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposPullsByOwnerByRepoByPullNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Accept") == "application/vnd.github.v3.diff" {
+					_, _ = w.Write([]byte(stubbedDiff))
+					return
+				}
+				mockResponse(t, http.StatusOK, mockPR)(w, r)
+			}),
+		),
+		mock.WithRequestMatch(mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber, []*github.IssueComment{}),
+	)
+	client := github.NewClient(mockedClient)
+
+	request := mcp.ReadResourceRequest{
+		Params: struct {
+			URI       string         `json:"uri"`
+			Arguments map[string]any `json:"arguments,omitempty"`
+		}{
+			URI: "repo://owner/repo/pulls/7",
+			Arguments: map[string]any{
+				"owner":  []string{"owner"},
+				"repo":   []string{"repo"},
+				"number": []string{"7"},
+			},
+		},
+	}
+
+	t.Run("pull request", func(t *testing.T) {
+		contents, err := PullRequestResourceHandler(stubGetClientFn(client))(context.Background(), request)
+		require.NoError(t, err)
+
+		text := getResourceText(t, contents)
+		assert.Equal(t, "text/markdown", text.MIMEType)
+		assert.Contains(t, text.Text, "# Add feature (#7)")
+		assert.Contains(t, text.Text, "- **State:** merged")
+		assert.Contains(t, text.Text, "- **Branches:** main ← feature")
+		assert.Contains(t, text.Text, "- **Changes:** 2 files, +10 -3")
+		assert.Contains(t, text.Text, "## Comments (0)")
+	})
+
+	t.Run("pull request diff", func(t *testing.T) {
+		contents, err := PullRequestDiffResourceHandler(stubGetClientFn(client))(context.Background(), request)
+		require.NoError(t, err)
+
+		text := getResourceText(t, contents)
+		assert.Equal(t, "text/x-diff", text.MIMEType)
+		assert.Equal(t, stubbedDiff, text.Text)
+	})
+}
+
+func Test_DiscussionResourceHandler(t *testing.T) {
+	qDiscussion := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){number,title,body,createdAt,url,author{login},category{name},comments(first: 100){totalCount,nodes{body,createdAt,author{login}}}}}}"
+	vars := map[string]interface{}{
+		"owner":            "owner",
+		"repo":             "repo",
+		"discussionNumber": float64(3),
+	}
+	response := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{"discussion": map[string]any{
+			"number":    3,
+			"title":     "Roadmap",
+			"body":      "What should we build next?",
+			"createdAt": "2025-04-25T12:00:00Z",
+			"url":       "https://github.com/owner/repo/discussions/3",
+			"author":    map[string]any{"login": "octocat"},
+			"category":  map[string]any{"name": "Ideas"},
+			"comments": map[string]any{
+				"totalCount": 1,
+				"nodes": []map[string]any{
+					{"body": "Resource templates!", "createdAt": "2025-04-26T12:00:00Z", "author": map[string]any{"login": "hubot"}},
+				},
+			},
+		}},
+	})
+
+	matcher := githubv4mock.NewQueryMatcher(qDiscussion, vars, response)
+	gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matcher))
+	handler := DiscussionResourceHandler(stubGetGQLClientFn(gqlClient))
+
+	request := mcp.ReadResourceRequest{
+		Params: struct {
+			URI       string         `json:"uri"`
+			Arguments map[string]any `json:"arguments,omitempty"`
+		}{
+			URI: "repo://owner/repo/discussions/3",
+			Arguments: map[string]any{
+				"owner":  []string{"owner"},
+				"repo":   []string{"repo"},
+				"number": []string{"3"},
+			},
+		},
+	}
+
+	contents, err := handler(context.Background(), request)
+	require.NoError(t, err)
+
+	text := getResourceText(t, contents)
+	assert.Equal(t, "text/markdown", text.MIMEType)
+	assert.Contains(t, text.Text, "# Roadmap (#3)")
+	assert.Contains(t, text.Text, "- **Category:** Ideas")
+	assert.Contains(t, text.Text, "## Comments (1)")
+	assert.Contains(t, text.Text, "### @hubot commented on 2025-04-26T12:00:00Z\n\nResource templates!")
+}
+
+func Test_WorkflowRunLogsResourceHandler(t *testing.T) {
+	logServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("line 1\nline 2\nline 3"))
+	}))
+	defer logServer.Close()
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+			&github.Jobs{
+				TotalCount: github.Ptr(2),
+				Jobs: []*github.WorkflowJob{
+					{ID: github.Ptr(int64(1)), Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+					{ID: github.Ptr(int64(2)), Name: github.Ptr("test"), Status: github.Ptr("in_progress")},
+				},
+			},
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Location", logServer.URL)
+				w.WriteHeader(http.StatusFound)
+			}),
+		),
+	)
+	client := github.NewClient(mockedClient)
+	handler := WorkflowRunLogsResourceHandler(stubGetClientFn(client), 2)
+
+	request := mcp.ReadResourceRequest{
+		Params: struct {
+			URI       string         `json:"uri"`
+			Arguments map[string]any `json:"arguments,omitempty"`
+		}{
+			URI: "repo://owner/repo/actions/runs/123/logs",
+			Arguments: map[string]any{
+				"owner": []string{"owner"},
+				"repo":  []string{"repo"},
+				"id":    []string{"123"},
+			},
+		},
+	}
+
+	contents, err := handler(context.Background(), request)
+	require.NoError(t, err)
+
+	text := getResourceText(t, contents)
+	assert.Equal(t, "text/plain", text.MIMEType)
+	assert.Contains(t, text.Text, "===== build (job 1, success) =====\n[showing last 2 of 3 lines]\nline 2\nline 3\n")
+	assert.Contains(t, text.Text, "===== test (job 2, in_progress) =====")
+}
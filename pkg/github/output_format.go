@@ -0,0 +1,495 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+// OutputFormat is how the text of JSON tool results is rendered.
+type OutputFormat string
+
+const (
+	// OutputFormatJSON returns results as the JSON the GitHub API returns them, the default.
+	OutputFormatJSON OutputFormat = "json"
+	// OutputFormatCompact returns results as JSON without null values and API links.
+	OutputFormatCompact OutputFormat = "compact"
+	// OutputFormatYAML returns compacted results as YAML.
+	OutputFormatYAML OutputFormat = "yaml"
+	// OutputFormatMarkdown returns compacted results as markdown, with lists rendered as tables.
+	OutputFormatMarkdown OutputFormat = "markdown"
+	// OutputFormatColumnar returns compacted results with lists rendered as tab separated columns,
+	// which repeats the field names once instead of for every item.
+	OutputFormatColumnar OutputFormat = "columnar"
+)
+
+// OutputFormats lists the supported output formats.
+var OutputFormats = []OutputFormat{OutputFormatJSON, OutputFormatCompact, OutputFormatYAML, OutputFormatMarkdown, OutputFormatColumnar}
+
+// ParseOutputFormat validates an output format name. An empty name selects the JSON format.
+func ParseOutputFormat(name string) (OutputFormat, error) {
+	if name == "" {
+		return OutputFormatJSON, nil
+	}
+	for _, format := range OutputFormats {
+		if string(format) == name {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown output format %q, must be one of %s", name, joinOutputFormats())
+}
+
+func joinOutputFormats() string {
+	names := make([]string, 0, len(OutputFormats))
+	for _, format := range OutputFormats {
+		names = append(names, string(format))
+	}
+	return strings.Join(names, ", ")
+}
+
+// withOutputFormatParam adds the output_format argument of tools returning JSON, see WithRawOutputSchema.
+func withOutputFormatParam() mcp.ToolOption {
+	enum := make([]string, 0, len(OutputFormats))
+	for _, format := range OutputFormats {
+		enum = append(enum, string(format))
+	}
+	return mcp.WithString("output_format",
+		mcp.Description("Format of the response. 'compact' drops null values and API links from the JSON, "+
+			"'yaml', 'markdown' and 'columnar' also render lists as tables. Defaults to the server's output format"),
+		mcp.Enum(enum...),
+	)
+}
+
+// WithOutputFormat renders the text of the results of every tool returning JSON, i.e. every tool
+// with an output schema, in the format its output_format argument asks for, falling back to
+// defaultFormat. Structured content is left as is.
+func WithOutputFormat(defaultFormat OutputFormat) func(server.ServerTool) server.ServerTool {
+	return func(tool server.ServerTool) server.ServerTool {
+		if tool.Tool.RawOutputSchema == nil {
+			return tool
+		}
+
+		handler := tool.Handler
+		tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := OptionalParam[string](request, "output_format")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			format := defaultFormat
+			if name != "" {
+				if format, err = ParseOutputFormat(name); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+
+			result, err := handler(ctx, request)
+			if err != nil || format == OutputFormatJSON || result == nil || result.IsError || len(result.Content) == 0 {
+				return result, err
+			}
+			// Only the result itself is formatted, not what follows it, like a continuation hint
+			text, ok := result.Content[0].(mcp.TextContent)
+			if !ok {
+				return result, nil
+			}
+
+			formatted, err := FormatJSON([]byte(text.Text), format)
+			if err != nil {
+				// Not JSON, e.g. a message that an operation is still in progress
+				return result, nil
+			}
+			text.Text = formatted
+			result.Content[0] = text
+			return result, nil
+		}
+		return tool
+	}
+}
+
+// FormatJSON renders JSON data in the given format.
+func FormatJSON(data []byte, format OutputFormat) (string, error) {
+	if format == OutputFormatJSON {
+		return string(data), nil
+	}
+
+	value, err := decodeOrderedJSON(data)
+	if err != nil {
+		return "", err
+	}
+	value, _ = compactValue(value)
+
+	switch format {
+	case OutputFormatCompact:
+		out, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(out), nil
+	case OutputFormatYAML:
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(yamlNode(value)); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	case OutputFormatMarkdown:
+		return renderTables(value, markdownFormat), nil
+	case OutputFormatColumnar:
+		return renderTables(value, columnarFormat), nil
+	default:
+		return "", fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+// jsonObject is a decoded JSON object that remembers the order of its keys,
+// so that rendered fields and columns appear in the order the API returns them.
+type jsonObject struct {
+	keys   []string
+	values map[string]any
+}
+
+func (o *jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func decodeOrderedJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	value, err := decodeOrderedValue(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	return value, nil
+}
+
+func decodeOrderedValue(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		object := &jsonObject{values: map[string]any{}}
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, ok := keyToken.(string)
+			if !ok {
+				return nil, fmt.Errorf("unexpected object key %v", keyToken)
+			}
+			value, err := decodeOrderedValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := object.values[key]; !exists {
+				object.keys = append(object.keys, key)
+			}
+			object.values[key] = value
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return object, nil
+	case json.Delim('['):
+		array := []any{}
+		for decoder.More() {
+			value, err := decodeOrderedValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return array, nil
+	default:
+		return token, nil
+	}
+}
+
+// compactValue drops null values and API links, and the objects left empty by doing so.
+// It reports whether anything is left of value.
+func compactValue(value any) (any, bool) {
+	switch v := value.(type) {
+	case nil:
+		return nil, false
+	case *jsonObject:
+		compacted := &jsonObject{values: map[string]any{}}
+		for _, key := range v.keys {
+			if isAPILink(key, v.values[key]) {
+				continue
+			}
+			value, ok := compactValue(v.values[key])
+			if !ok {
+				continue
+			}
+			compacted.keys = append(compacted.keys, key)
+			compacted.values[key] = value
+		}
+		return compacted, len(compacted.keys) > 0
+	case []any:
+		compacted := make([]any, 0, len(v))
+		for _, item := range v {
+			if item, ok := compactValue(item); ok {
+				compacted = append(compacted, item)
+			}
+		}
+		return compacted, true
+	default:
+		return value, true
+	}
+}
+
+// isAPILink reports whether a field is one of the hypermedia links to other API endpoints
+// GitHub includes in its objects, e.g. comments_url. Links meant for users, like html_url,
+// and download links are kept.
+func isAPILink(key string, value any) bool {
+	if key != "url" && !strings.HasSuffix(key, "_url") {
+		return false
+	}
+	link, ok := value.(string)
+	if !ok {
+		return false
+	}
+	if strings.Contains(link, "{") {
+		// URI templates like https://api.github.com/repos/o/r/issues{/number}
+		return true
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(u.Host, "api.") || strings.HasPrefix(u.Path, "/api/")
+}
+
+func yamlNode(value any) *yaml.Node {
+	switch v := value.(type) {
+	case *jsonObject:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		for _, key := range v.keys {
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, yamlNode(v.values[key]))
+		}
+		return node
+	case []any:
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, item := range v {
+			node.Content = append(node.Content, yamlNode(item))
+		}
+		return node
+	case string:
+		node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+		if strings.Contains(v, "\n") {
+			node.Style = yaml.LiteralStyle
+		}
+		return node
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: v.String()}
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: v.String()}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(v)}
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	}
+}
+
+// tableFormat renders results as lists of fields and tables of objects.
+type tableFormat struct {
+	// field renders a field of an object
+	field func(buf *strings.Builder, key string, value any)
+	// heading introduces a list of objects that is a field of an object
+	heading func(buf *strings.Builder, key string)
+	// table renders a list of objects as a table with the given columns
+	table func(buf *strings.Builder, columns []string, rows []*jsonObject)
+	// cell renders a single value
+	cell func(value any) string
+}
+
+var (
+	markdownFormat = tableFormat{
+		field: func(buf *strings.Builder, key string, value any) {
+			fmt.Fprintf(buf, "- **%s**: %s\n", key, markdownCell(value))
+		},
+		heading: func(buf *strings.Builder, key string) {
+			fmt.Fprintf(buf, "\n### %s\n\n", key)
+		},
+		table: markdownTable,
+		cell:  markdownCell,
+	}
+	columnarFormat = tableFormat{
+		field: func(buf *strings.Builder, key string, value any) {
+			fmt.Fprintf(buf, "%s: %s\n", key, columnarCell(value))
+		},
+		heading: func(buf *strings.Builder, key string) {
+			fmt.Fprintf(buf, "%s:\n", key)
+		},
+		table: columnarTable,
+		cell:  columnarCell,
+	}
+)
+
+// renderTables renders lists of objects as tables, and the fields of an object as
+// a list of key-value pairs followed by a table for every list of objects in it.
+func renderTables(value any, format tableFormat) string {
+	var buf strings.Builder
+	switch v := value.(type) {
+	case []any:
+		writeList(&buf, v, format)
+	case *jsonObject:
+		var lists []string
+		for _, key := range v.keys {
+			if list, ok := v.values[key].([]any); ok && isObjectList(list) {
+				lists = append(lists, key)
+				continue
+			}
+			format.field(&buf, key, v.values[key])
+		}
+		for _, key := range lists {
+			format.heading(&buf, key)
+			writeList(&buf, v.values[key].([]any), format)
+		}
+	default:
+		buf.WriteString(format.cell(v) + "\n")
+	}
+	return buf.String()
+}
+
+func writeList(buf *strings.Builder, list []any, format tableFormat) {
+	if len(list) == 0 {
+		buf.WriteString("No results.\n")
+		return
+	}
+	if !isObjectList(list) {
+		for _, item := range list {
+			fmt.Fprintf(buf, "- %s\n", format.cell(item))
+		}
+		return
+	}
+
+	rows := make([]*jsonObject, 0, len(list))
+	var columns []string
+	seen := map[string]bool{}
+	for _, item := range list {
+		row := item.(*jsonObject)
+		rows = append(rows, row)
+		for _, key := range row.keys {
+			if !seen[key] {
+				seen[key] = true
+				columns = append(columns, key)
+			}
+		}
+	}
+	format.table(buf, columns, rows)
+}
+
+func isObjectList(list []any) bool {
+	for _, item := range list {
+		if _, ok := item.(*jsonObject); !ok {
+			return false
+		}
+	}
+	return len(list) > 0
+}
+
+func markdownTable(buf *strings.Builder, columns []string, rows []*jsonObject) {
+	buf.WriteString("| " + strings.Join(columns, " | ") + " |\n")
+	buf.WriteString("|" + strings.Repeat(" --- |", len(columns)) + "\n")
+	for _, row := range rows {
+		cells := make([]string, 0, len(columns))
+		for _, column := range columns {
+			cells = append(cells, markdownCell(row.values[column]))
+		}
+		buf.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+}
+
+func columnarTable(buf *strings.Builder, columns []string, rows []*jsonObject) {
+	buf.WriteString(strings.Join(columns, "\t") + "\n")
+	for _, row := range rows {
+		cells := make([]string, 0, len(columns))
+		for _, column := range columns {
+			cells = append(cells, columnarCell(row.values[column]))
+		}
+		buf.WriteString(strings.Join(cells, "\t") + "\n")
+	}
+}
+
+var (
+	markdownCellEscaper = strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>")
+	columnarCellEscaper = strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\r", "\\r", "\n", "\\n")
+)
+
+func markdownCell(value any) string {
+	return markdownCellEscaper.Replace(cellText(value))
+}
+
+func columnarCell(value any) string {
+	return columnarCellEscaper.Replace(cellText(value))
+}
+
+// summaryKeys name the field that best identifies an object nested in a table cell.
+var summaryKeys = []string{"login", "name", "title", "ref", "sha", "id"}
+
+// cellText renders a value as the text of a table cell. Nested objects are summarized
+// by their identifying field, e.g. the login of a user or the name of a label.
+func cellText(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case *jsonObject:
+		for _, key := range summaryKeys {
+			if summary, ok := v.values[key]; ok {
+				if _, nested := summary.(*jsonObject); !nested {
+					return cellText(summary)
+				}
+			}
+		}
+		out, _ := json.Marshal(v)
+		return string(out)
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, cellText(item))
+		}
+		return strings.Join(items, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,168 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const outputFormatTestIssues = `[
+	{"number":1,"title":"Fix | pipes","url":"https://api.github.com/repos/o/r/issues/1","html_url":"https://github.com/o/r/issues/1","labels_url":"https://api.github.com/repos/o/r/issues/1/labels{/name}","user":{"login":"octocat","id":1},"labels":[{"name":"bug"},{"name":"p1"}],"body":"line one\nline two","milestone":null},
+	{"number":2,"title":"Add docs","html_url":"https://github.com/o/r/issues/2","user":{"login":"hubot","id":2},"labels":[],"assignee":null,"comments":3}
+]`
+
+func Test_FormatJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		format   OutputFormat
+		expected string
+	}{
+		{
+			name:     "json is returned as is",
+			data:     `{"a":null,"url":"https://api.github.com/x"}`,
+			format:   OutputFormatJSON,
+			expected: `{"a":null,"url":"https://api.github.com/x"}`,
+		},
+		{
+			name:     "compact drops nulls and API links and keeps the key order",
+			data:     `{"z":1,"url":"https://api.github.com/repos/o/r","html_url":"https://github.com/o/r","a":null,"archive_url":"https://api.github.com/repos/o/r/{archive_format}{/ref}","download_url":"https://raw.githubusercontent.com/o/r/main/README.md","owner":{"avatar_url":null},"b":[null,1.5]}`,
+			format:   OutputFormatCompact,
+			expected: `{"z":1,"html_url":"https://github.com/o/r","download_url":"https://raw.githubusercontent.com/o/r/main/README.md","b":[1.5]}`,
+		},
+		{
+			name:   "yaml",
+			data:   `{"name":"main","protected":true,"count":2,"ratio":0.5,"sha":"123","message":"a\nb","labels":["x"],"commit":{"sha":"abc"}}`,
+			format: OutputFormatYAML,
+			expected: `name: main
+protected: true
+count: 2
+ratio: 0.5
+sha: "123"
+message: |-
+  a
+  b
+labels:
+  - x
+commit:
+  sha: abc
+`,
+		},
+		{
+			name:   "markdown renders lists as tables",
+			data:   outputFormatTestIssues,
+			format: OutputFormatMarkdown,
+			expected: `| number | title | html_url | user | labels | body | comments |
+| --- | --- | --- | --- | --- | --- | --- |
+| 1 | Fix \| pipes | https://github.com/o/r/issues/1 | octocat | bug, p1 | line one<br>line two |  |
+| 2 | Add docs | https://github.com/o/r/issues/2 | hubot |  |  | 3 |
+`,
+		},
+		{
+			name:   "markdown renders objects as fields followed by their lists",
+			data:   `{"total_count":1,"items":[{"name":"main"}],"incomplete_results":false}`,
+			format: OutputFormatMarkdown,
+			expected: `- **total_count**: 1
+- **incomplete_results**: false
+
+### items
+
+| name |
+| --- |
+| main |
+`,
+		},
+		{
+			name:     "markdown renders empty lists",
+			data:     `[]`,
+			format:   OutputFormatMarkdown,
+			expected: "No results.\n",
+		},
+		{
+			name:   "columnar renders lists as tab separated columns",
+			data:   outputFormatTestIssues,
+			format: OutputFormatColumnar,
+			expected: "number\ttitle\thtml_url\tuser\tlabels\tbody\tcomments\n" +
+				"1\tFix | pipes\thttps://github.com/o/r/issues/1\toctocat\tbug, p1\tline one\\nline two\t\n" +
+				"2\tAdd docs\thttps://github.com/o/r/issues/2\thubot\t\t\t3\n",
+		},
+		{
+			name:     "columnar renders objects as fields followed by their lists",
+			data:     `{"total_count":1,"items":[{"name":"main","sha":"abc"}]}`,
+			format:   OutputFormatColumnar,
+			expected: "total_count: 1\nitems:\nname\tsha\nmain\tabc\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			formatted, err := FormatJSON([]byte(tc.data), tc.format)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, formatted)
+		})
+	}
+
+	t.Run("invalid JSON is an error", func(t *testing.T) {
+		_, err := FormatJSON([]byte("Fork is in progress"), OutputFormatYAML)
+		assert.Error(t, err)
+	})
+}
+
+func Test_ParseOutputFormat(t *testing.T) {
+	format, err := ParseOutputFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, OutputFormatJSON, format)
+
+	format, err = ParseOutputFormat("yaml")
+	require.NoError(t, err)
+	assert.Equal(t, OutputFormatYAML, format)
+
+	_, err = ParseOutputFormat("xml")
+	assert.ErrorContains(t, err, `unknown output format "xml"`)
+}
+
+func Test_WithOutputFormat(t *testing.T) {
+	const text = `[{"name":"main","commit":null}]`
+	tool := StructuredContent(server.ServerTool{
+		Tool: mcp.NewTool("tool", mcp.WithString("owner"), WithOutputSchema[[]MinimalBranch]()),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(text), nil
+		},
+	})
+	tool = WithOutputFormat(OutputFormatCompact)(tool)
+
+	// The argument is part of the tool definition, so that it is documented
+	assert.Contains(t, tool.Tool.InputSchema.Properties, "owner")
+	require.Contains(t, tool.Tool.InputSchema.Properties, "output_format")
+	assert.Equal(t, []string{"json", "compact", "yaml", "markdown", "columnar"}, tool.Tool.InputSchema.Properties["output_format"].(map[string]any)["enum"])
+
+	t.Run("the server default is used", func(t *testing.T) {
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		assert.Equal(t, `[{"name":"main"}]`, getTextResult(t, result).Text)
+		// Structured content is not formatted
+		assert.Equal(t, map[string]any{"items": []any{map[string]any{"name": "main", "commit": nil}}}, result.StructuredContent)
+	})
+
+	t.Run("calls can ask for a format", func(t *testing.T) {
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{"output_format": "json"}))
+		require.NoError(t, err)
+		assert.Equal(t, text, getTextResult(t, result).Text)
+	})
+
+	t.Run("unknown formats are rejected", func(t *testing.T) {
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{"output_format": "xml"}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, `unknown output format "xml"`)
+	})
+
+	t.Run("tools without an output schema are not wrapped", func(t *testing.T) {
+		plain := WithOutputFormat(OutputFormatYAML)(server.ServerTool{Tool: mcp.NewTool("tool")})
+		assert.NotContains(t, plain.Tool.InputSchema.Properties, "output_format")
+		assert.Nil(t, plain.Handler)
+	})
+}
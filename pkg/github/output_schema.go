@@ -0,0 +1,327 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// apiTypeMaxDepth is how deeply output schemas describe GitHub API types. The API types nested in
+// a result, e.g. the user of an issue, are only described as objects so that the schemas of tools
+// returning them stay reasonably small. Our own output types are small and always fully described.
+const apiTypeMaxDepth = 0
+
+// structuredItemsKey holds results that are not JSON objects in structured content, since MCP
+// requires structured content, and the output schema describing it, to be an object.
+const structuredItemsKey = "items"
+
+var (
+	outputSchemasMu sync.Mutex
+	outputSchemas   = map[reflect.Type]json.RawMessage{}
+)
+
+// WithOutputSchema sets the output schema of a tool to the schema of the JSON that T marshals to.
+// Registered tools with an output schema also return their results as structured content, see StructuredContent.
+func WithOutputSchema[T any]() mcp.ToolOption {
+	return WithRawOutputSchema(OutputSchema[T]())
+}
+
+// WithRawOutputSchema sets the output schema of a tool, e.g. one combining several results with
+// OutputSchemaAnyOf. Since tools with an output schema return JSON, they also get the output_format
+// argument WithOutputFormat handles.
+func WithRawOutputSchema(schema json.RawMessage) mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithRawOutputSchema(schema)(tool)
+		withOutputFormatParam()(tool)
+	}
+}
+
+// OutputSchema generates the JSON schema of the JSON that T marshals to. Types that do not marshal
+// to an object are wrapped in an object under "items", the way StructuredContent returns them.
+func OutputSchema[T any]() json.RawMessage {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	outputSchemasMu.Lock()
+	defer outputSchemasMu.Unlock()
+	if schema, ok := outputSchemas[t]; ok {
+		return schema
+	}
+
+	g := &schemaGenerator{visiting: map[reflect.Type]bool{}}
+	schema := g.schema(t, 0)
+	if schema["type"] != "object" {
+		schema = map[string]any{
+			"type":       "object",
+			"properties": map[string]any{structuredItemsKey: schema},
+			"required":   []string{structuredItemsKey},
+		}
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		// Schemas only contain strings, slices and maps
+		panic(err)
+	}
+	outputSchemas[t] = data
+	return data
+}
+
+// OutputSchemaAnyOf combines the output schemas of the different results a tool can return, e.g. its
+// full and its minimal result. Since all of them describe objects, so does the combination. Nil
+// schemas are skipped and identical ones are only included once.
+func OutputSchemaAnyOf(schemas ...json.RawMessage) json.RawMessage {
+	anyOf := make([]json.RawMessage, 0, len(schemas))
+	for _, schema := range schemas {
+		if schema != nil && !slices.ContainsFunc(anyOf, func(s json.RawMessage) bool { return bytes.Equal(s, schema) }) {
+			anyOf = append(anyOf, schema)
+		}
+	}
+	if len(anyOf) == 1 {
+		return anyOf[0]
+	}
+
+	data, err := json.Marshal(map[string]any{"type": "object", "anyOf": anyOf})
+	if err != nil {
+		// The schemas were marshaled before
+		panic(err)
+	}
+	return data
+}
+
+var (
+	ownPkgPath        = reflect.TypeOf(MinimalResponse{}).PkgPath()
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+type schemaGenerator struct {
+	visiting map[reflect.Type]bool
+}
+
+func (g *schemaGenerator) schema(t reflect.Type, depth int) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType || embedsTime(t):
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		// We can't know what custom marshaling produces
+		return map[string]any{}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return map[string]any{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are marshaled as base64 strings
+			return map[string]any{"type": "string"}
+		}
+		return map[string]any{"type": "array", "items": g.schema(t.Elem(), depth)}
+	case reflect.Map:
+		schema := map[string]any{"type": "object"}
+		if elem := g.schema(t.Elem(), depth+1); len(elem) > 0 {
+			schema["additionalProperties"] = elem
+		}
+		return schema
+	case reflect.Struct:
+		return g.structSchema(t, depth)
+	default:
+		// Interfaces can hold anything
+		return map[string]any{}
+	}
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type, depth int) map[string]any {
+	if g.visiting[t] || (depth > apiTypeMaxDepth && t.PkgPath() != ownPkgPath) {
+		return map[string]any{"type": "object"}
+	}
+	g.visiting[t] = true
+	defer delete(g.visiting, t)
+
+	properties := map[string]any{}
+	var required []string
+	g.addFields(t, depth, properties, &required)
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func (g *schemaGenerator) addFields(t reflect.Type, depth int, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		fieldType := field.Type
+		if field.Anonymous && name == "" {
+			// Fields of embedded structs are promoted
+			for fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				g.addFields(fieldType, depth, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		var schema map[string]any
+		if strings.Contains(opts, "string") {
+			schema = map[string]any{"type": "string"}
+		} else {
+			schema = g.schema(fieldType, depth+1)
+		}
+
+		omitempty := strings.Contains(opts, "omitempty") || strings.Contains(opts, "omitzero")
+		switch fieldType.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+			if !omitempty {
+				schema = nullable(schema)
+			}
+		default:
+			if !omitempty {
+				*required = append(*required, name)
+			}
+		}
+		properties[name] = schema
+	}
+}
+
+// nullable allows null in addition to what schema describes.
+func nullable(schema map[string]any) map[string]any {
+	typ, ok := schema["type"].(string)
+	if !ok {
+		return schema
+	}
+	nullableSchema := make(map[string]any, len(schema))
+	for k, v := range schema {
+		nullableSchema[k] = v
+	}
+	nullableSchema["type"] = []string{typ, "null"}
+	return nullableSchema
+}
+
+// embedsTime reports whether t is a struct wrapping a time.Time, like github.Timestamp or githubv4.DateTime.
+func embedsTime(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t.NumField() != 1 {
+		return false
+	}
+	field := t.Field(0)
+	return field.Anonymous && field.Type == timeType
+}
+
+// EmbeddedResourceResult is the structured content of results returning a file as an embedded
+// resource, e.g. the results of get_file_contents. Text holds text files and Blob the base64
+// encoded content of binary files.
+type EmbeddedResourceResult struct {
+	Message  string `json:"message"`
+	URI      string `json:"uri"`
+	MIMEType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Blob     string `json:"blob,omitempty"`
+}
+
+// StructuredContent makes a tool that has an output schema return the JSON text of its results as
+// structured content as well. Results that are not JSON objects are wrapped under "items", results
+// returning an embedded resource are returned as EmbeddedResourceResult, and plain text results as a
+// MessageResponse. Other results are returned unchanged.
+func StructuredContent(tool server.ServerTool) server.ServerTool {
+	if tool.Tool.RawOutputSchema == nil {
+		return tool
+	}
+
+	handler := tool.Handler
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, request)
+		if err != nil || result == nil || result.IsError || result.StructuredContent != nil {
+			return result, err
+		}
+
+		if structured, ok := resourceStructuredContent(result.Content); ok {
+			result.StructuredContent = structured
+			return result, nil
+		}
+		if len(result.Content) == 1 {
+			if text, ok := result.Content[0].(mcp.TextContent); ok {
+				if structured, ok := structuredContent(text.Text); ok {
+					result.StructuredContent = structured
+				} else {
+					result.StructuredContent = MessageResponse{Message: text.Text}
+				}
+			}
+		}
+		return result, nil
+	}
+	return tool
+}
+
+// structuredContent parses the JSON text of a result as structured content. It reports false
+// for text that is not JSON.
+func structuredContent(text string) (any, bool) {
+	var structured any
+	if err := json.Unmarshal([]byte(text), &structured); err != nil {
+		return nil, false
+	}
+	if _, ok := structured.(map[string]any); !ok {
+		structured = map[string]any{structuredItemsKey: structured}
+	}
+	return structured, true
+}
+
+// resourceStructuredContent returns the message and embedded resource of results built with
+// mcp.NewToolResultResource as structured content.
+func resourceStructuredContent(content []mcp.Content) (any, bool) {
+	if len(content) != 2 {
+		return nil, false
+	}
+	message, ok := content[0].(mcp.TextContent)
+	if !ok {
+		return nil, false
+	}
+	resource, ok := content[1].(mcp.EmbeddedResource)
+	if !ok {
+		return nil, false
+	}
+
+	switch contents := resource.Resource.(type) {
+	case mcp.TextResourceContents:
+		return EmbeddedResourceResult{Message: message.Text, URI: contents.URI, MIMEType: contents.MIMEType, Text: contents.Text}, true
+	case mcp.BlobResourceContents:
+		return EmbeddedResourceResult{Message: message.Text, URI: contents.URI, MIMEType: contents.MIMEType, Blob: contents.Blob}, true
+	default:
+		return nil, false
+	}
+}
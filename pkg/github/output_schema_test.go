@@ -0,0 +1,180 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaTestNode struct {
+	Name      string            `json:"name"`
+	Count     int               `json:"count,omitempty"`
+	Parent    *schemaTestNode   `json:"parent,omitempty"`
+	Children  []schemaTestNode  `json:"children"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	Issue     *github.Issue     `json:"issue,omitempty"`
+	Ignored   string            `json:"-"`
+	internal  string            //nolint:unused // Unexported fields are not marshaled
+}
+
+func Test_OutputSchema(t *testing.T) {
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(OutputSchema[schemaTestNode](), &schema))
+
+	assert.Equal(t, "object", schema["type"])
+	assert.ElementsMatch(t, []any{"name", "created_at"}, schema["required"])
+
+	properties := schema["properties"].(map[string]any)
+	assert.Len(t, properties, 7)
+	assert.Equal(t, map[string]any{"type": "string"}, properties["name"])
+	assert.Equal(t, map[string]any{"type": "integer"}, properties["count"])
+	assert.Equal(t, map[string]any{"type": "string", "format": "date-time"}, properties["created_at"])
+	assert.Equal(t, map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}}, properties["labels"])
+	// Recursive types end in a plain object
+	assert.Equal(t, map[string]any{"type": "object"}, properties["parent"])
+	assert.Equal(t, map[string]any{"type": []any{"array", "null"}, "items": map[string]any{"type": "object"}}, properties["children"])
+	// Nested API types are not described in detail
+	assert.Equal(t, map[string]any{"type": "object"}, properties["issue"])
+
+	// API types are described at the top level
+	require.NoError(t, json.Unmarshal(OutputSchema[*github.Issue](), &schema))
+	properties = schema["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "integer"}, properties["number"])
+	assert.Equal(t, map[string]any{"type": "string", "format": "date-time"}, properties["created_at"])
+	assert.Equal(t, map[string]any{"type": "array", "items": map[string]any{"type": "object"}}, properties["labels"])
+}
+
+func Test_OutputSchemaWrapsNonObjects(t *testing.T) {
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(OutputSchema[[]MinimalBranch](), &schema))
+
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, []any{"items"}, schema["required"])
+	items := schema["properties"].(map[string]any)["items"].(map[string]any)
+	assert.Equal(t, "array", items["type"])
+	assert.Equal(t, "object", items["items"].(map[string]any)["type"])
+}
+
+func Test_StructuredContent(t *testing.T) {
+	newTool := func(text string, isError bool) server.ServerTool {
+		return StructuredContent(server.ServerTool{
+			Tool: mcp.NewTool("tool", WithOutputSchema[[]MinimalBranch]()),
+			Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				if isError {
+					return mcp.NewToolResultError(text), nil
+				}
+				return mcp.NewToolResultText(text), nil
+			},
+		})
+	}
+
+	tests := []struct {
+		name               string
+		text               string
+		isError            bool
+		expectedStructured any
+	}{
+		{
+			name:               "objects are returned as is",
+			text:               `{"name":"main","sha":"abc","protected":false}`,
+			expectedStructured: map[string]any{"name": "main", "sha": "abc", "protected": false},
+		},
+		{
+			name:               "arrays are wrapped",
+			text:               `[{"name":"main","sha":"abc","protected":true}]`,
+			expectedStructured: map[string]any{"items": []any{map[string]any{"name": "main", "sha": "abc", "protected": true}}},
+		},
+		{
+			name:    "errors are left alone",
+			text:    `{"message":"not found"}`,
+			isError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool := newTool(tc.text, tc.isError)
+			result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{}))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStructured, result.StructuredContent)
+			assert.Equal(t, tc.text, getTextResult(t, result).Text)
+		})
+	}
+
+	t.Run("plain text is returned as a message", func(t *testing.T) {
+		tool := newTool("Fork is in progress", false)
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		assert.Equal(t, MessageResponse{Message: "Fork is in progress"}, result.StructuredContent)
+		assert.Equal(t, "Fork is in progress", getTextResult(t, result).Text)
+	})
+
+	t.Run("embedded resources are returned with their message", func(t *testing.T) {
+		tool := StructuredContent(server.ServerTool{
+			Tool: mcp.NewTool("tool", WithOutputSchema[EmbeddedResourceResult]()),
+			Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return mcp.NewToolResultResource("successfully downloaded text file", mcp.TextResourceContents{
+					URI:      "repo://owner/repo/contents/README.md",
+					MIMEType: "text/markdown",
+					Text:     "# repo",
+				}), nil
+			},
+		})
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		assert.Equal(t, EmbeddedResourceResult{
+			Message:  "successfully downloaded text file",
+			URI:      "repo://owner/repo/contents/README.md",
+			MIMEType: "text/markdown",
+			Text:     "# repo",
+		}, result.StructuredContent)
+	})
+
+	t.Run("tools without an output schema are not wrapped", func(t *testing.T) {
+		tool := server.ServerTool{Tool: mcp.NewTool("tool")}
+		assert.Nil(t, StructuredContent(tool).Handler)
+	})
+}
+
+func Test_ToolOutputSchemasDescribeObjects(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, nil, nil, nil, nil, translations.NullTranslationHelper, 5000, nil, nil)
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			if tool.Tool.RawOutputSchema == nil {
+				continue
+			}
+			var schema map[string]any
+			require.NoError(t, json.Unmarshal(tool.Tool.RawOutputSchema, &schema), tool.Tool.Name)
+			assert.Equal(t, "object", schema["type"], "output schema of %s must describe an object", tool.Tool.Name)
+		}
+	}
+}
+
+func Test_ToolsHaveOutputSchemas(t *testing.T) {
+	withoutSchema := map[string]bool{
+		// These tools return whatever the GitHub API answers with
+		"graphql_query":       true,
+		"github_rest_request": true,
+	}
+
+	tsg := DefaultToolsetGroup(false, nil, nil, nil, nil, translations.NullTranslationHelper, 5000, nil, nil)
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			if withoutSchema[tool.Tool.Name] {
+				assert.Nil(t, tool.Tool.RawOutputSchema, "%s has an output schema now", tool.Tool.Name)
+				continue
+			}
+			assert.NotNil(t, tool.Tool.RawOutputSchema, "%s must have an output schema", tool.Tool.Name)
+			assert.Contains(t, tool.Tool.InputSchema.Properties, "output_format", "%s returns JSON, so it must have an output_format argument", tool.Tool.Name)
+		}
+	}
+}
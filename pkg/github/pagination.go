@@ -0,0 +1,523 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// MaxAutoPaginationItems is the most items a single call following pages can collect.
+	MaxAutoPaginationItems = 1000
+	// defaultAutoPaginationTimeout is how long a call keeps following pages if it doesn't say.
+	defaultAutoPaginationTimeout = 30 * time.Second
+	// autoPaginationPerPage is the page size used to follow pages, unless the call asks for one.
+	autoPaginationPerPage = 100
+)
+
+// withAutoPaginationParams adds the parameters to collect the items of several pages in one call.
+func withAutoPaginationParams(tool *mcp.Tool) {
+	mcp.WithNumber("max_items",
+		mcp.Description(fmt.Sprintf("Follow the next pages, starting at the requested one, until this many items are collected (max %d). The result holds the items of all pages, followed by how to resume where it stops", MaxAutoPaginationItems)),
+		mcp.Min(1),
+		mcp.Max(MaxAutoPaginationItems),
+	)(tool)
+
+	mcp.WithNumber("max_seconds",
+		mcp.Description(fmt.Sprintf("Stop following pages for max_items after about this many seconds (default %d)", int(defaultAutoPaginationTimeout.Seconds()))),
+		mcp.Min(1),
+	)(tool)
+}
+
+// PaginationSummary tells the model which pages a call with max_items collected, and how to resume.
+// It is returned as an extra JSON text content after the merged result.
+type PaginationSummary struct {
+	Pages    int  `json:"pages"`
+	Items    int  `json:"items"`
+	Complete bool `json:"complete"`
+	// StopReason is why no more pages were followed: complete, max_items, max_seconds, response_size or error
+	StopReason string `json:"stop_reason"`
+	// NextArguments are the arguments to pass, along with the original ones, to continue where the result ends
+	NextArguments map[string]any `json:"next_arguments,omitempty"`
+	// SkipItems is the number of items at the start of the page NextArguments get that the result already holds
+	SkipItems int    `json:"skip_items,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ListResult is the result of the list tools: a page of items, and whether and how to get the next one.
+type ListResult[T any] struct {
+	Items    []T      `json:"items"`
+	PageInfo PageInfo `json:"page_info"`
+	// Truncated is set when items of the page were left out, to fit the response size limit or max_items
+	Truncated bool `json:"truncated"`
+}
+
+// PageInfo tells whether a list has more pages after the returned one, and how to get the next one.
+type PageInfo struct {
+	HasNext bool `json:"has_next"`
+	// NextPage and LastPage are the pages of REST lists the Link header points to
+	NextPage int `json:"next_page,omitempty"`
+	LastPage int `json:"last_page,omitempty"`
+	// EndCursor is the cursor of GraphQL lists to pass as after for the next page
+	EndCursor string `json:"end_cursor,omitempty"`
+	// TotalCount is the number of items of all pages, when GitHub tells it
+	TotalCount *int `json:"total_count,omitempty"`
+}
+
+// restListResult returns a page of a REST list, with the next and last pages from the Link header of its response.
+func restListResult[T any](items []T, resp *github.Response) ListResult[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return ListResult[T]{
+		Items: items,
+		PageInfo: PageInfo{
+			HasNext:  resp.NextPage != 0,
+			NextPage: resp.NextPage,
+			LastPage: resp.LastPage,
+		},
+	}
+}
+
+// graphQLListResult returns a page of a GraphQL connection, with what its pageInfo and totalCount tell.
+func graphQLListResult[T any](items []T, hasNextPage bool, endCursor string, totalCount int) ListResult[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return ListResult[T]{
+		Items: items,
+		PageInfo: PageInfo{
+			HasNext:    hasNextPage,
+			EndCursor:  endCursor,
+			TotalCount: &totalCount,
+		},
+	}
+}
+
+// withTotalCount sets the number of items of all pages, for REST lists that tell it.
+func (r ListResult[T]) withTotalCount(totalCount int) ListResult[T] {
+	r.PageInfo.TotalCount = &totalCount
+	return r
+}
+
+// convertListResult converts the items of a page, e.g. to their minimal version.
+func convertListResult[T, M any](r ListResult[T], convert func(T) M) ListResult[M] {
+	return ListResult[M]{
+		Items:     convertToMinimalList(r.Items, convert),
+		PageInfo:  r.PageInfo,
+		Truncated: r.Truncated,
+	}
+}
+
+// pageLinks is what the Link header of a REST response says about the next page.
+type pageLinks struct {
+	mu       sync.Mutex
+	seen     bool
+	nextPage int
+}
+
+type pageLinksKey struct{}
+
+func contextWithPageLinks(ctx context.Context) (context.Context, *pageLinks) {
+	links := &pageLinks{}
+	return context.WithValue(ctx, pageLinksKey{}, links), links
+}
+
+func (l *pageLinks) record(header string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.seen = true
+	l.nextPage = nextPageFromLinkHeader(header)
+}
+
+func (l *pageLinks) next() (page int, seen bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.nextPage, l.seen
+}
+
+// PageLinksTransport records the Link headers of REST responses for the calls following pages, see
+// WithAutoPagination. The REST client of the server must use it for them to follow Link headers.
+type PageLinksTransport struct {
+	Transport http.RoundTripper
+}
+
+func (t *PageLinksTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.Transport.RoundTrip(req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	if links, ok := req.Context().Value(pageLinksKey{}).(*pageLinks); ok {
+		if header := resp.Header.Get("Link"); header != "" {
+			links.record(header)
+		}
+	}
+	return resp, nil
+}
+
+// nextPageFromLinkHeader returns the page the rel="next" link of a Link header points to, or 0 if there is none.
+// https://docs.github.com/en/rest/using-the-rest-api/using-pagination-in-the-rest-api#using-link-headers
+func nextPageFromLinkHeader(header string) int {
+	for _, link := range strings.Split(header, ",") {
+		segments := strings.Split(strings.TrimSpace(link), ";")
+		if len(segments) < 2 {
+			continue
+		}
+		target := strings.TrimSpace(segments[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		isNext := false
+		for _, param := range segments[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				isNext = true
+			}
+		}
+		if !isNext {
+			continue
+		}
+
+		u, err := url.Parse(target[1 : len(target)-1])
+		if err != nil {
+			return 0
+		}
+		page, err := strconv.Atoi(u.Query().Get("page"))
+		if err != nil {
+			return 0
+		}
+		return page
+	}
+	return 0
+}
+
+// WithAutoPagination makes paginated tools follow the next pages when called with max_items: list
+// tools follow the page_info of their results, see ListResult, and other REST tools, like search, the
+// Link headers of their responses.
+// The items of all pages are merged into the result of the first one, and a PaginationSummary is
+// appended telling how to resume. Pages are only followed while the merged result stays within
+// maxTokens, so that the response budget doesn't have to cut it. A maxTokens of zero or less means
+// no limit.
+func WithAutoPagination(maxTokens int) func(server.ServerTool) server.ServerTool {
+	return func(tool server.ServerTool) server.ServerTool {
+		if _, ok := tool.Tool.InputSchema.Properties["max_items"]; !ok {
+			return tool
+		}
+
+		handler := tool.Handler
+		tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			maxItems, err := OptionalIntParam(request, "max_items")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxSeconds, err := OptionalIntParam(request, "max_seconds")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxItems <= 0 {
+				return handler(ctx, request)
+			}
+
+			p := &paginator{
+				tool:     tool.Tool,
+				handler:  handler,
+				maxItems: min(maxItems, MaxAutoPaginationItems),
+				deadline: time.Now().Add(defaultAutoPaginationTimeout),
+			}
+			if maxSeconds > 0 {
+				p.deadline = time.Now().Add(time.Duration(maxSeconds) * time.Second)
+			}
+			if maxTokens > 0 {
+				p.maxChars = maxTokens * charsPerToken
+			}
+			return p.run(ctx, request)
+		}
+		return tool
+	}
+}
+
+type paginator struct {
+	tool     mcp.Tool
+	handler  server.ToolHandlerFunc
+	maxItems int
+	deadline time.Time
+	maxChars int
+}
+
+// page is the decoded result of one page of a paginated tool.
+type page struct {
+	result *mcp.CallToolResult
+	value  any
+	items  []any
+	// hasNext, nextPage and endCursor are what the page tells about the next one. GraphQL
+	// pages have an endCursor, REST pages a nextPage.
+	hasNext   bool
+	nextPage  int
+	endCursor string
+}
+
+func (p *paginator) run(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := maps.Clone(request.GetArguments())
+	delete(args, "max_items")
+	delete(args, "max_seconds")
+
+	pageNumber, err := OptionalIntParamWithDefault(request, "page", 1)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	perPage, err := OptionalIntParamWithDefault(request, "perPage", autoPaginationPerPage)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	perPage = min(perPage, p.maxItems)
+	args["perPage"] = float64(perPage)
+
+	first, listKey, err := p.fetch(ctx, request, args, pageNumber, perPage, nil)
+	if err != nil || first.value == nil {
+		// Errors and results without a list of items are returned as they are
+		return first.result, err
+	}
+
+	summary := PaginationSummary{Pages: 1}
+	items, last := first.items, first
+	size := len(mustMarshal(first.value))
+	p.progress(ctx, request, summary.Pages, len(items))
+	for {
+		if !last.hasNext {
+			summary.StopReason = "complete"
+			summary.Complete = true
+			break
+		}
+		if ctx.Err() != nil {
+			return cancelledResult(ctx), nil
+		}
+		if len(items) >= p.maxItems {
+			summary.StopReason = "max_items"
+			break
+		}
+		if time.Now().After(p.deadline) {
+			summary.StopReason = "max_seconds"
+			break
+		}
+
+		nextArgs := maps.Clone(args)
+		if last.endCursor != "" {
+			// GraphQL connections take any page size, so the last page is only as large as needed
+			nextArgs["after"] = last.endCursor
+			nextArgs["perPage"] = float64(min(perPage, p.maxItems-len(items)))
+		} else {
+			nextArgs["page"] = float64(last.nextPage)
+		}
+
+		next, _, err := p.fetch(ctx, request, nextArgs, last.nextPage, perPage, &listKey)
+		if err == nil && next.value == nil {
+			if next.result != nil && next.result.IsError {
+				err = errors.New(resultText(next.result))
+			} else {
+				err = errors.New("the next page has no list of items")
+			}
+		}
+		if ctx.Err() != nil {
+			return cancelledResult(ctx), nil
+		}
+		if err != nil {
+			summary.StopReason = "error"
+			summary.Error = err.Error()
+			break
+		}
+		pageSize := len(mustMarshal(next.items))
+		if p.maxChars > 0 && size+pageSize > p.maxChars {
+			summary.StopReason = "response_size"
+			break
+		}
+		size += pageSize
+
+		items = append(items, next.items...)
+		summary.Pages++
+		last = next
+		p.progress(ctx, request, summary.Pages, len(items))
+	}
+
+	// REST pages can't be cut short, so the items of the last page past max_items are dropped
+	dropped := len(items) > p.maxItems
+	if dropped {
+		items = items[:p.maxItems]
+		summary.StopReason = "max_items"
+		summary.Complete = false
+	}
+	merged := withList(first.value, listKey, items)
+	if object, ok := merged.(*jsonObject); ok {
+		if info, ok := last.value.(*jsonObject).values["page_info"]; ok {
+			// The merged result continues where the last page does
+			object.values["page_info"] = info
+		}
+		if _, ok := object.values["truncated"]; ok && dropped {
+			object.values["truncated"] = true
+		}
+	}
+	summary.Items = len(items)
+	if !summary.Complete {
+		summary.NextArguments, summary.SkipItems = resumeArguments(last, pageNumber, perPage, len(items))
+	}
+
+	text := string(mustMarshal(merged))
+	result := first.result
+	result.Content = []mcp.Content{mcp.NewTextContent(text)}
+	if result.StructuredContent != nil {
+		result.StructuredContent, _ = structuredContent(text)
+	}
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal pagination summary: %w", err)
+	}
+	result.Content = append(result.Content, mcp.NewTextContent(string(data)))
+	return result, nil
+}
+
+// progress notifies the client of the items collected so far, out of max_items.
+func (p *paginator) progress(ctx context.Context, request mcp.CallToolRequest, pages, items int) {
+	items = min(items, p.maxItems)
+	SendProgress(ctx, request, float64(items), float64(p.maxItems), fmt.Sprintf("Collected %d of at most %d items (page %d)", items, p.maxItems, pages))
+}
+
+// cancelledResult is the result of a call cancelled while following pages.
+func cancelledResult(ctx context.Context) *mcp.CallToolResult {
+	return mcp.NewToolResultError(fmt.Sprintf("stopped following pages: %s", ctx.Err()))
+}
+
+// fetch calls the tool for one page and decodes its result. The value of the page is nil if the
+// result is an error, or not a JSON list or an object with a list. The key of the list in the
+// result is looked up on the first page and passed to the following ones.
+func (p *paginator) fetch(ctx context.Context, request mcp.CallToolRequest, args map[string]any, pageNumber, perPage int, listKey *string) (*page, string, error) {
+	ctx, links := contextWithPageLinks(ctx)
+	request.Params.Arguments = args
+	result, err := p.handler(ctx, request)
+	if err != nil {
+		return &page{result: result}, "", err
+	}
+	if result == nil || result.IsError || len(result.Content) != 1 {
+		return &page{result: result}, "", nil
+	}
+	text, ok := result.Content[0].(mcp.TextContent)
+	if !ok {
+		return &page{result: result}, "", nil
+	}
+	value, err := decodeOrderedJSON([]byte(text.Text))
+	if err != nil {
+		return &page{result: result}, "", nil
+	}
+
+	var key string
+	if listKey != nil {
+		key = *listKey
+	} else if key, ok = itemsKey(value); !ok {
+		return &page{result: result}, "", nil
+	}
+	items, ok := listAt(value, key)
+	if !ok {
+		return &page{result: result}, "", nil
+	}
+
+	pg := &page{result: result, value: value, items: items}
+	if object, ok := value.(*jsonObject); ok {
+		if info, ok := object.values["page_info"].(*jsonObject); ok {
+			// List results tell about the next page themselves, see ListResult
+			hasNext, _ := info.values["has_next"].(bool)
+			pg.endCursor, _ = info.values["end_cursor"].(string)
+			if nextPage, ok := info.values["next_page"].(json.Number); ok {
+				n, _ := nextPage.Int64()
+				pg.nextPage = int(n)
+			}
+			pg.hasNext = hasNext && (pg.endCursor != "" || pg.nextPage > 0)
+			return pg, key, nil
+		}
+	}
+	if _, ok := p.tool.InputSchema.Properties["page"]; !ok {
+		// Without a page parameter or a cursor, there is no way to the next page
+		return pg, key, nil
+	}
+	if nextPage, seen := links.next(); seen {
+		pg.hasNext, pg.nextPage = nextPage > 0, nextPage
+	} else if len(items) >= perPage {
+		// No Link header was recorded, so a full page is taken to mean there might be more
+		pg.hasNext, pg.nextPage = true, pageNumber+1
+	}
+	return pg, key, nil
+}
+
+// resumeArguments returns the arguments that continue after the kept items, which start at the requested
+// page, and the number of items at the start of the page they get that were already kept.
+func resumeArguments(last *page, pageNumber, perPage, kept int) (map[string]any, int) {
+	if last.endCursor != "" {
+		return map[string]any{"after": last.endCursor, "perPage": perPage}, 0
+	}
+
+	// Resume from the page the next item is on, keeping the page size
+	offset := (pageNumber-1)*perPage + kept
+	return map[string]any{"page": offset/perPage + 1, "perPage": perPage}, offset % perPage
+}
+
+// itemsKey finds the list of items of a page: the page itself if it is a list, otherwise the largest
+// list among its fields, or the first one if they are all empty. "" stands for the page itself.
+func itemsKey(value any) (string, bool) {
+	switch v := value.(type) {
+	case []any:
+		return "", true
+	case *jsonObject:
+		key, size := "", -1
+		for _, k := range v.keys {
+			if list, ok := v.values[k].([]any); ok {
+				if s := len(list); s > size {
+					key, size = k, s
+				}
+			}
+		}
+		return key, size >= 0
+	default:
+		return "", false
+	}
+}
+
+func listAt(value any, key string) ([]any, bool) {
+	if key == "" {
+		list, ok := value.([]any)
+		return list, ok
+	}
+	object, ok := value.(*jsonObject)
+	if !ok {
+		return nil, false
+	}
+	list, ok := object.values[key].([]any)
+	return list, ok || object.values[key] == nil
+}
+
+// withList replaces the list of items of a page.
+func withList(value any, key string, list []any) any {
+	if key == "" {
+		return list
+	}
+	value.(*jsonObject).values[key] = list
+	return value
+}
+
+// resultText joins the texts of a result, like the message of an error result.
+func resultText(result *mcp.CallToolResult) string {
+	var texts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,393 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func paginationSummary(t *testing.T, result *mcp.CallToolResult) PaginationSummary {
+	t.Helper()
+	require.False(t, result.IsError)
+	require.Len(t, result.Content, 2)
+	var summary PaginationSummary
+	require.NoError(t, json.Unmarshal([]byte(result.Content[1].(mcp.TextContent).Text), &summary))
+	return summary
+}
+
+func mergedText(t *testing.T, result *mcp.CallToolResult) string {
+	t.Helper()
+	require.NotEmpty(t, result.Content)
+	text, ok := result.Content[0].(mcp.TextContent)
+	require.True(t, ok)
+	return text.Text
+}
+
+// numberedItems returns the items numbered from start to end, excluding end.
+func numberedItems(start, end int) []map[string]any {
+	items := []map[string]any{}
+	for i := start; i < end; i++ {
+		items = append(items, map[string]any{"number": i})
+	}
+	return items
+}
+
+// restPagesTool is a REST tool listing total items, which pretends not to send Link headers.
+func restPagesTool(total int, calls *[]map[string]any) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("list_items", WithPagination()),
+		Handler: func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			*calls = append(*calls, request.GetArguments())
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			start := (pagination.Page - 1) * pagination.PerPage
+			return MarshalledTextResult(numberedItems(start, min(start+pagination.PerPage, total))), nil
+		},
+	}
+}
+
+// graphQLPagesTool is a GraphQL list tool listing total items, using their number as cursor.
+func graphQLPagesTool(total int, calls *[]map[string]any) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("list_items", WithCursorPagination()),
+		Handler: func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			*calls = append(*calls, request.GetArguments())
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			start := 0
+			if pagination.After != "" {
+				start, _ = strconv.Atoi(pagination.After)
+			}
+			end := min(start+pagination.PerPage, total)
+			return MarshalledTextResult(graphQLListResult(numberedItems(start, end), end < total, strconv.Itoa(end), total)), nil
+		},
+	}
+}
+
+func Test_WithAutoPagination(t *testing.T) {
+	t.Run("calls without max_items are left alone", func(t *testing.T) {
+		var calls []map[string]any
+		tool := WithAutoPagination(0)(restPagesTool(100, &calls))
+
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{"perPage": float64(10)}))
+		require.NoError(t, err)
+		require.Len(t, result.Content, 1)
+		assert.Len(t, calls, 1)
+	})
+
+	t.Run("tools without pagination are left alone", func(t *testing.T) {
+		tool := WithAutoPagination(0)(budgetTestTool(mcp.NewToolResultText("[]")))
+
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{"max_items": float64(10)}))
+		require.NoError(t, err)
+		require.Len(t, result.Content, 1)
+	})
+
+	t.Run("list tools follow the page_info of their results", func(t *testing.T) {
+		var pages []string
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposCommitsByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+					perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+					pages = append(pages, r.URL.RawQuery)
+					if page < 3 {
+						w.Header().Set("Link", fmt.Sprintf(`<https://api.github.com/repositories/1/commits?page=%d&per_page=%d>; rel="next", <https://api.github.com/repositories/1/commits?page=3&per_page=%d>; rel="last"`, page+1, perPage, perPage))
+					} else {
+						w.Header().Set("Link", fmt.Sprintf(`<https://api.github.com/repositories/1/commits?page=1&per_page=%d>; rel="first"`, perPage))
+					}
+					commits := make([]*github.RepositoryCommit, perPage)
+					for i := range commits {
+						commits[i] = &github.RepositoryCommit{SHA: github.Ptr(fmt.Sprintf("sha%d", (page-1)*perPage+i))}
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(commits)
+				}),
+			),
+		)
+		tool, handler := ListCommits(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+		paginated := WithAutoPagination(0)(server.ServerTool{Tool: tool, Handler: handler})
+
+		result, err := paginated.Handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":     "owner",
+			"repo":      "repo",
+			"perPage":   float64(4),
+			"max_items": float64(10),
+		}))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"page=1&per_page=4", "page=2&per_page=4", "page=3&per_page=4"}, pages)
+		var commits ListResult[MinimalCommit]
+		require.NoError(t, json.Unmarshal([]byte(mergedText(t, result)), &commits))
+		require.Len(t, commits.Items, 10)
+		assert.Equal(t, "sha0", commits.Items[0].SHA)
+		assert.Equal(t, "sha9", commits.Items[9].SHA)
+		assert.False(t, commits.PageInfo.HasNext)
+		assert.True(t, commits.Truncated)
+
+		summary := paginationSummary(t, result)
+		assert.Equal(t, PaginationSummary{
+			Pages:      3,
+			Items:      10,
+			StopReason: "max_items",
+			// The 2 commits of the last page past max_items are dropped, so it is to be fetched again without the first 2
+			NextArguments: map[string]any{"page": float64(3), "perPage": float64(4)},
+			SkipItems:     2,
+		}, summary)
+	})
+
+	t.Run("other REST tools follow the Link headers", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetSearchRepositories,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+					if page == 1 {
+						w.Header().Set("Link", `<https://api.github.com/search/repositories?q=mcp&page=2&per_page=2>; rel="next"`)
+					}
+					result := &github.RepositoriesSearchResult{Total: github.Ptr(3)}
+					for i := (page - 1) * 2; i < min(page*2, 3); i++ {
+						result.Repositories = append(result.Repositories, &github.Repository{ID: github.Ptr(int64(i))})
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(result)
+				}),
+			),
+		)
+		mockedClient.Transport = &PageLinksTransport{Transport: mockedClient.Transport}
+		tool, handler := SearchRepositories(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+		paginated := WithAutoPagination(0)(server.ServerTool{Tool: tool, Handler: handler})
+
+		result, err := paginated.Handler(context.Background(), createMCPRequest(map[string]any{
+			"query":     "mcp",
+			"perPage":   float64(2),
+			"max_items": float64(10),
+		}))
+		require.NoError(t, err)
+
+		var repos MinimalSearchRepositoriesResult
+		require.NoError(t, json.Unmarshal([]byte(mergedText(t, result)), &repos))
+		assert.Equal(t, 3, repos.TotalCount)
+		require.Len(t, repos.Items, 3)
+		assert.Equal(t, int64(2), repos.Items[2].ID)
+		assert.Equal(t, PaginationSummary{Pages: 2, Items: 3, Complete: true, StopReason: "complete"}, paginationSummary(t, result))
+	})
+
+	t.Run("REST tools without Link headers follow full pages", func(t *testing.T) {
+		var calls []map[string]any
+		tool := WithAutoPagination(0)(restPagesTool(25, &calls))
+
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{
+			"page":      float64(2),
+			"perPage":   float64(10),
+			"max_items": float64(100),
+		}))
+		require.NoError(t, err)
+
+		require.Len(t, calls, 2)
+		assert.Equal(t, float64(3), calls[1]["page"])
+		assert.NotContains(t, calls[1], "max_items")
+		var items []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(mergedText(t, result)), &items))
+		assert.Len(t, items, 15)
+		assert.Equal(t, PaginationSummary{Pages: 2, Items: 15, Complete: true, StopReason: "complete"}, paginationSummary(t, result))
+	})
+
+	t.Run("GraphQL tools follow the end_cursor of their results", func(t *testing.T) {
+		var calls []map[string]any
+		tool := WithAutoPagination(0)(graphQLPagesTool(500, &calls))
+
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{"max_items": float64(250)}))
+		require.NoError(t, err)
+
+		require.Len(t, calls, 3)
+		assert.Equal(t, float64(100), calls[0]["perPage"])
+		// The last page is only as large as needed
+		assert.Equal(t, map[string]any{"after": "200", "perPage": float64(50)}, calls[2])
+
+		var merged ListResult[map[string]any]
+		require.NoError(t, json.Unmarshal([]byte(mergedText(t, result)), &merged))
+		assert.Len(t, merged.Items, 250)
+		assert.Equal(t, float64(249), merged.Items[249]["number"])
+		assert.Equal(t, PageInfo{HasNext: true, EndCursor: "250", TotalCount: github.Ptr(500)}, merged.PageInfo)
+		assert.False(t, merged.Truncated)
+
+		assert.Equal(t, PaginationSummary{
+			Pages:         3,
+			Items:         250,
+			StopReason:    "max_items",
+			NextArguments: map[string]any{"after": "250", "perPage": float64(100)},
+		}, paginationSummary(t, result))
+	})
+
+	t.Run("structured content holds the merged result", func(t *testing.T) {
+		var calls []map[string]any
+		tool := graphQLPagesTool(30, &calls)
+		tool.Tool.RawOutputSchema = OutputSchema[map[string]any]()
+		tool = WithAutoPagination(0)(StructuredContent(tool))
+
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{"perPage": float64(20), "max_items": float64(30)}))
+		require.NoError(t, err)
+
+		structured, ok := result.StructuredContent.(map[string]any)
+		require.True(t, ok)
+		assert.Len(t, structured["items"], 30)
+	})
+
+	t.Run("pages are only followed within the response budget", func(t *testing.T) {
+		var calls []map[string]any
+		tool := WithAutoPagination(100)(restPagesTool(1000, &calls))
+
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{"perPage": float64(10), "max_items": float64(1000)}))
+		require.NoError(t, err)
+
+		summary := paginationSummary(t, result)
+		assert.Equal(t, "response_size", summary.StopReason)
+		assert.LessOrEqual(t, len(mergedText(t, result)), 100*charsPerToken)
+		assert.Equal(t, map[string]any{"page": float64(summary.Items/10 + 1), "perPage": float64(10)}, summary.NextArguments)
+	})
+
+	t.Run("errors of the next pages end the collection", func(t *testing.T) {
+		tool := server.ServerTool{
+			Tool: mcp.NewTool("list_items", WithPagination()),
+			Handler: func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				if page, _ := OptionalIntParam(request, "page"); page > 1 {
+					return mcp.NewToolResultError("rate limit exceeded"), nil
+				}
+				return MarshalledTextResult(numberedItems(0, 10)), nil
+			},
+		}
+
+		result, err := WithAutoPagination(0)(tool).Handler(context.Background(), createMCPRequest(map[string]any{"perPage": float64(10), "max_items": float64(50)}))
+		require.NoError(t, err)
+
+		assert.Equal(t, PaginationSummary{
+			Pages:         1,
+			Items:         10,
+			StopReason:    "error",
+			NextArguments: map[string]any{"page": float64(2), "perPage": float64(10)},
+			Error:         "rate limit exceeded",
+		}, paginationSummary(t, result))
+	})
+
+	t.Run("errors of the first page are returned as they are", func(t *testing.T) {
+		tool := budgetTestTool(mcp.NewToolResultError("not found"), WithPagination())
+
+		result, err := WithAutoPagination(0)(tool).Handler(context.Background(), createMCPRequest(map[string]any{"max_items": float64(50)}))
+		require.NoError(t, err)
+		assert.Equal(t, "not found", getErrorResult(t, result).Text)
+	})
+
+	t.Run("progress is reported after every page", func(t *testing.T) {
+		var calls []map[string]any
+		tool := WithAutoPagination(0)(restPagesTool(25, &calls))
+		s := server.NewMCPServer("test", "1.0.0")
+		s.AddTool(tool.Tool, tool.Handler)
+		session := &fakeSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+		ctx := s.WithContext(context.Background(), session)
+
+		s.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"list_items","arguments":{"perPage":10,"max_items":50},"_meta":{"progressToken":"token"}}}`))
+
+		require.Len(t, calls, 3)
+		require.Len(t, session.notifications, 3)
+		for _, expected := range []float64{10, 20, 25} {
+			notification := <-session.notifications
+			assert.Equal(t, MethodNotificationProgress, notification.Method)
+			assert.Equal(t, expected, notification.Params.AdditionalFields["progress"])
+			assert.Equal(t, float64(50), notification.Params.AdditionalFields["total"])
+		}
+	})
+
+	t.Run("collecting pages stops when the call is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var calls []map[string]any
+		tool := restPagesTool(100, &calls)
+		handler := tool.Handler
+		tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if len(calls) == 1 {
+				cancel()
+			}
+			return handler(ctx, request)
+		}
+
+		result, err := WithAutoPagination(0)(tool).Handler(ctx, createMCPRequest(map[string]any{"perPage": float64(10), "max_items": float64(100)}))
+		require.NoError(t, err)
+
+		assert.Len(t, calls, 2)
+		assert.Equal(t, "stopped following pages: context canceled", getErrorResult(t, result).Text)
+	})
+
+	t.Run("pages are followed for max_seconds", func(t *testing.T) {
+		tool := server.ServerTool{
+			Tool: mcp.NewTool("list_items", WithPagination()),
+			Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				time.Sleep(1100 * time.Millisecond)
+				return MarshalledTextResult(numberedItems(0, 10)), nil
+			},
+		}
+
+		result, err := WithAutoPagination(0)(tool).Handler(context.Background(), createMCPRequest(map[string]any{
+			"perPage":     float64(10),
+			"max_items":   float64(50),
+			"max_seconds": float64(1),
+		}))
+		require.NoError(t, err)
+
+		summary := paginationSummary(t, result)
+		assert.Equal(t, "max_seconds", summary.StopReason)
+		assert.Equal(t, 1, summary.Pages)
+	})
+}
+
+func Test_NextPageFromLinkHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{
+			name:   "next and last",
+			header: `<https://api.github.com/repositories/1/issues?page=3&per_page=30>; rel="next", <https://api.github.com/repositories/1/issues?page=9&per_page=30>; rel="last"`,
+			want:   3,
+		},
+		{
+			name:   "last page",
+			header: `<https://api.github.com/repositories/1/issues?page=1>; rel="first", <https://api.github.com/repositories/1/issues?page=8>; rel="prev"`,
+			want:   0,
+		},
+		{
+			name:   "cursor pagination",
+			header: `<https://api.github.com/repositories/1/secret-scanning/alerts?after=abc>; rel="next"`,
+			want:   0,
+		},
+		{
+			name:   "malformed",
+			header: `https://api.github.com/repositories/1/issues?page=3; rel="next"`,
+			want:   0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, nextPageFromLinkHeader(tc.header))
+		})
+	}
+}
@@ -0,0 +1,674 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// applyPatchSteps is the number of stages apply_patch reports progress for.
+const applyPatchSteps = 3
+
+// applyPatchMaxFuzz is the most context lines apply_patch may ignore at each end of a hunk.
+const applyPatchMaxFuzz = 3
+
+var hunkHeaderRE = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// filePatch is the change a unified diff makes to one file.
+type filePatch struct {
+	OldPath string
+	NewPath string
+	NewMode string
+	New     bool
+	Deleted bool
+	Copy    bool
+	Binary  bool
+	Hunks   []hunk
+}
+
+// hunk is one @@ section of a file patch.
+type hunk struct {
+	Header   string
+	OldStart int
+	OldLines int
+	Lines    []hunkLine
+	OldNoEOL bool
+	NewNoEOL bool
+}
+
+// hunkLine is a line of a hunk. Op is ' ' for context, '-' for removed and '+' for
+// added lines.
+type hunkLine struct {
+	Op   byte
+	Text string
+}
+
+// hunkReject describes a hunk that couldn't be applied.
+type hunkReject struct {
+	Hunk   int
+	Header string
+	Reason string
+}
+
+// parsePatchPath parses the path of a ---/+++ line, dropping the a/ or b/ prefix.
+func parsePatchPath(s, prefix string) string {
+	if strings.HasPrefix(s, `"`) {
+		if quoted, err := strconv.QuotedPrefix(s); err == nil {
+			s, _ = strconv.Unquote(quoted)
+		}
+	} else if before, _, ok := strings.Cut(s, "\t"); ok {
+		// diff -u appends a timestamp after a tab
+		s = before
+	}
+	s = strings.TrimRight(s, " ")
+	if s == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(s, prefix)
+}
+
+// parseGitDiffPaths parses the paths of a "diff --git a/old b/new" line.
+func parseGitDiffPaths(s string) (string, string) {
+	if strings.HasPrefix(s, `"`) {
+		quoted, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return "", ""
+		}
+		return parsePatchPath(quoted, "a/"), parsePatchPath(strings.TrimSpace(s[len(quoted):]), "b/")
+	}
+	if i := strings.Index(s, " b/"); strings.HasPrefix(s, "a/") && i >= 0 {
+		return parsePatchPath(s[:i], "a/"), parsePatchPath(s[i+1:], "b/")
+	}
+	return "", ""
+}
+
+// isFileHeader reports whether lines[i] starts the ---/+++ header of a file patch.
+func isFileHeader(lines []string, i int) bool {
+	return strings.HasPrefix(lines[i], "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ ")
+}
+
+// parseUnifiedDiff parses a unified diff, as produced by git diff or diff -u, into the
+// patches of the files it changes. Hunk line counts are taken from the hunk body, as
+// hand-written diffs often get the ones in the header wrong.
+func parseUnifiedDiff(diff string) ([]*filePatch, error) {
+	lines := strings.Split(diff, "\n")
+	var patches []*filePatch
+	var cur *filePatch
+	// A git diff header starts a patch that the ---/+++ lines that follow belong to
+	inGitHeader := false
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			cur = &filePatch{}
+			cur.OldPath, cur.NewPath = parseGitDiffPaths(strings.TrimPrefix(line, "diff --git "))
+			patches = append(patches, cur)
+			inGitHeader = true
+
+		case isFileHeader(lines, i):
+			if !inGitHeader {
+				cur = &filePatch{}
+				patches = append(patches, cur)
+			}
+			inGitHeader = false
+			if oldPath := parsePatchPath(line[4:], "a/"); oldPath != "" {
+				cur.OldPath = oldPath
+			} else {
+				cur.New = true
+			}
+			if newPath := parsePatchPath(lines[i+1][4:], "b/"); newPath != "" {
+				cur.NewPath = newPath
+			} else {
+				cur.Deleted = true
+			}
+			i++
+
+		case strings.HasPrefix(line, "@@ "):
+			inGitHeader = false
+			m := hunkHeaderRE.FindStringSubmatch(line)
+			if m == nil {
+				return nil, fmt.Errorf("invalid hunk header on line %d: %s", i+1, line)
+			}
+			if cur == nil {
+				return nil, fmt.Errorf("hunk on line %d is not preceded by a file header", i+1)
+			}
+			h := hunk{Header: m[0]}
+			h.OldStart, _ = strconv.Atoi(m[1])
+			oldLeft, newLeft := 1, 1
+			if m[2] != "" {
+				oldLeft, _ = strconv.Atoi(m[2])
+			}
+			if m[4] != "" {
+				newLeft, _ = strconv.Atoi(m[4])
+			}
+			if oldLeft == 0 {
+				// Insertions at the top of the file have an old start of 0
+				h.OldStart++
+			}
+
+		body:
+			for i+1 < len(lines) {
+				next := lines[i+1]
+				counted := oldLeft > 0 || newLeft > 0
+				switch {
+				case next == "" && oldLeft > 0 && newLeft > 0:
+					// Blank context lines often lose their leading space
+					next = " "
+				case next == "" || isFileHeader(lines, i+1) && !counted:
+					break body
+				case strings.TrimRight(next, " ") == "--" && !counted:
+					// The signature of git format-patch
+					break body
+				}
+				switch next[0] {
+				case ' ', '-', '+':
+					h.Lines = append(h.Lines, hunkLine{Op: next[0], Text: next[1:]})
+					if next[0] != '+' {
+						oldLeft--
+						h.OldLines++
+					}
+					if next[0] != '-' {
+						newLeft--
+					}
+				case '\\':
+					if len(h.Lines) > 0 {
+						switch h.Lines[len(h.Lines)-1].Op {
+						case '-':
+							h.OldNoEOL = true
+						case '+':
+							h.NewNoEOL = true
+						default:
+							h.OldNoEOL, h.NewNoEOL = true, true
+						}
+					}
+				default:
+					break body
+				}
+				i++
+			}
+			if len(h.Lines) == 0 {
+				return nil, fmt.Errorf("hunk %s of %s is empty", h.Header, cur.displayPath())
+			}
+			cur.Hunks = append(cur.Hunks, h)
+
+		case cur != nil && inGitHeader:
+			switch {
+			case strings.HasPrefix(line, "new file mode "):
+				cur.New = true
+				cur.NewMode = strings.TrimPrefix(line, "new file mode ")
+			case strings.HasPrefix(line, "deleted file mode "):
+				cur.Deleted = true
+			case strings.HasPrefix(line, "new mode "):
+				cur.NewMode = strings.TrimPrefix(line, "new mode ")
+			case strings.HasPrefix(line, "rename from "):
+				cur.OldPath = parsePatchPath(strings.TrimPrefix(line, "rename from "), "")
+			case strings.HasPrefix(line, "rename to "):
+				cur.NewPath = parsePatchPath(strings.TrimPrefix(line, "rename to "), "")
+			case strings.HasPrefix(line, "copy from "):
+				cur.Copy = true
+				cur.OldPath = parsePatchPath(strings.TrimPrefix(line, "copy from "), "")
+			case strings.HasPrefix(line, "copy to "):
+				cur.NewPath = parsePatchPath(strings.TrimPrefix(line, "copy to "), "")
+			case strings.HasPrefix(line, "Binary files ") || line == "GIT binary patch":
+				cur.Binary = true
+			}
+
+		case strings.HasPrefix(line, "Binary files ") && strings.HasSuffix(line, " differ"):
+			// diff reports binary files without a header of their own
+			return nil, fmt.Errorf("binary patches are not supported: %s", line)
+		}
+	}
+
+	for _, p := range patches {
+		if p.New {
+			p.OldPath = ""
+		}
+		if p.Deleted {
+			p.NewPath = ""
+		}
+	}
+	return patches, nil
+}
+
+// displayPath is the path a file patch is reported under.
+func (p *filePatch) displayPath() string {
+	if p.NewPath != "" {
+		return p.NewPath
+	}
+	return p.OldPath
+}
+
+// splitLines splits content into lines, reporting whether it ends with a newline.
+func splitLines(content string) ([]string, bool) {
+	if content == "" {
+		return nil, true
+	}
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n"), strings.HasSuffix(content, "\n")
+}
+
+// linesMatch reports whether the old lines of body are at pos of lines, optionally
+// ignoring trailing whitespace.
+func linesMatch(lines []string, pos int, body []hunkLine, loose bool) bool {
+	for _, l := range body {
+		if l.Op == '+' {
+			continue
+		}
+		if pos >= len(lines) {
+			return false
+		}
+		a, b := lines[pos], l.Text
+		if loose {
+			a, b = strings.TrimRight(a, " \t\r"), strings.TrimRight(b, " \t\r")
+		}
+		if a != b {
+			return false
+		}
+		pos++
+	}
+	return true
+}
+
+// locateHunk finds where body applies to lines, searching outwards from expected but
+// not before minPos. It reports whether trailing whitespace had to be ignored.
+func locateHunk(lines []string, body []hunkLine, expected, minPos int) (int, bool, bool) {
+	oldLen := 0
+	for _, l := range body {
+		if l.Op != '+' {
+			oldLen++
+		}
+	}
+	maxPos := len(lines) - oldLen
+	for _, loose := range []bool{false, true} {
+		for d := 0; expected-d >= minPos || expected+d <= maxPos; d++ {
+			for _, pos := range []int{expected + d, expected - d} {
+				if pos >= minPos && pos <= maxPos && linesMatch(lines, pos, body, loose) {
+					return pos, loose, true
+				}
+			}
+		}
+	}
+	return 0, false, false
+}
+
+// contextLines returns the number of context lines at the start and end of body.
+func contextLines(body []hunkLine) (int, int) {
+	lead, trail := 0, 0
+	for lead < len(body) && body[lead].Op == ' ' {
+		lead++
+	}
+	for trail < len(body)-lead && body[len(body)-1-trail].Op == ' ' {
+		trail++
+	}
+	return lead, trail
+}
+
+// applyHunks applies hunks to content in order. Like patch, a hunk that isn't where its
+// header says is looked for elsewhere in the file, and up to maxFuzz of its leading and
+// trailing context lines are ignored if it isn't found with all of them. notes describe
+// the hunks that needed this.
+func applyHunks(content string, hunks []hunk, maxFuzz int) (string, []string, []hunkReject) {
+	lines, eofNewline := splitLines(content)
+	var notes []string
+	var rejects []hunkReject
+	// offset is the difference between where the last hunk was found and where its
+	// header put it, plus the lines it added or removed
+	offset, minPos := 0, 0
+
+	for n, h := range hunks {
+		start := h.OldStart - 1
+		lead, trail := contextLines(h.Lines)
+		applied := false
+		for fuzz := 0; fuzz <= maxFuzz && !applied; fuzz++ {
+			dropLead, dropTrail := min(fuzz, lead), min(fuzz, trail)
+			if fuzz > 0 && dropLead < fuzz && dropTrail < fuzz {
+				// Dropping more context than the hunk has changes nothing
+				break
+			}
+			body := h.Lines[dropLead : len(h.Lines)-dropTrail]
+			pos, loose, ok := locateHunk(lines, body, start+offset+dropLead, minPos)
+			if !ok {
+				continue
+			}
+			applied = true
+
+			var replacement []string
+			end := pos
+			for _, l := range body {
+				switch l.Op {
+				case ' ':
+					// Keep the file's version of context lines that matched loosely
+					replacement = append(replacement, lines[end])
+					end++
+				case '-':
+					end++
+				case '+':
+					replacement = append(replacement, l.Text)
+				}
+			}
+			lines = append(lines[:pos:pos], append(replacement, lines[end:]...)...)
+
+			var details []string
+			if found := pos - dropLead; found != start+offset {
+				details = append(details, fmt.Sprintf("at line %d (offset %d lines)", found+1, found-start))
+			}
+			if dropLead > 0 || dropTrail > 0 {
+				details = append(details, fmt.Sprintf("with fuzz %d", fuzz))
+			}
+			if loose {
+				details = append(details, "ignoring trailing whitespace")
+			}
+			if len(details) > 0 {
+				notes = append(notes, fmt.Sprintf("hunk %d %s applied %s", n+1, h.Header, strings.Join(details, ", ")))
+			}
+
+			offset = pos - dropLead - start + len(replacement) - (end - pos)
+			minPos = pos + len(replacement)
+			if h.NewNoEOL && minPos == len(lines) {
+				eofNewline = false
+			} else if h.OldNoEOL && !h.NewNoEOL {
+				eofNewline = true
+			}
+		}
+		if !applied {
+			var expected []string
+			for _, l := range h.Lines {
+				if l.Op != '+' {
+					expected = append(expected, "  "+l.Text)
+				}
+			}
+			rejects = append(rejects, hunkReject{
+				Hunk:   n + 1,
+				Header: h.Header,
+				Reason: fmt.Sprintf("the lines it changes were not found near line %d. Expected:\n%s", start+offset+1, strings.Join(expected, "\n")),
+			})
+		}
+	}
+
+	if len(lines) == 0 {
+		return "", notes, rejects
+	}
+	result := strings.Join(lines, "\n")
+	if eofNewline {
+		result += "\n"
+	}
+	return result, notes, rejects
+}
+
+// PatchedFile describes a file changed by apply_patch.
+type PatchedFile struct {
+	Path         string   `json:"path"`
+	PreviousPath string   `json:"previous_path,omitempty"`
+	Status       string   `json:"status"`
+	Notes        []string `json:"notes,omitempty"`
+}
+
+// ApplyPatchResult is the commit created by apply_patch.
+type ApplyPatchResult struct {
+	CommitChangesResult
+	Files []PatchedFile `json:"files"`
+}
+
+// ApplyPatch creates a tool to apply a unified diff to a branch as a single commit.
+func ApplyPatch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("apply_patch",
+			mcp.WithDescription(t("TOOL_APPLY_PATCH_DESCRIPTION", "Apply a unified diff, as produced by git diff or get_pull_request_diff, to a branch of a GitHub repository as a single commit. Hunks that moved are found elsewhere in the file. If any hunk can't be applied, nothing is committed and the hunks that didn't apply are reported. Prefer this over rewriting whole files to make changes to them.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_APPLY_PATCH_USER_TITLE", "Apply patch to repository"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to commit to"),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message"),
+			),
+			mcp.WithString("patch",
+				mcp.Required(),
+				mcp.Description("Unified diff to apply. Paths are relative to the repository root, with optional a/ and b/ prefixes"),
+			),
+			mcp.WithNumber("fuzz",
+				mcp.Description(fmt.Sprintf("Number of context lines at the start and end of a hunk that may be ignored if it doesn't apply with all of them (max %d). Default is 2.", applyPatchMaxFuzz)),
+				mcp.Min(0),
+				mcp.Max(applyPatchMaxFuzz),
+			),
+			mcp.WithString("expected_head_sha",
+				mcp.Description("SHA the branch must point to. If the branch has moved on, nothing is committed"),
+			),
+			WithOutputSchema[ApplyPatchResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			patch, err := RequiredParam[string](request, "patch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// 0 is a valid fuzz, so the default only applies when unset
+			fuzz := 2
+			if _, ok := request.GetArguments()["fuzz"]; ok {
+				if fuzz, err = OptionalIntParam(request, "fuzz"); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+			fuzz = min(max(fuzz, 0), applyPatchMaxFuzz)
+			expectedHeadSHA, err := OptionalParam[string](request, "expected_head_sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			patches, err := parseUnifiedDiff(patch)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid patch: %s", err)), nil
+			}
+			if len(patches) == 0 {
+				return mcp.NewToolResultError("invalid patch: it doesn't change any files"), nil
+			}
+			seen := make(map[string]bool)
+			for _, p := range patches {
+				if p.Binary {
+					return mcp.NewToolResultError(fmt.Sprintf("binary patch of %s is not supported, use commit_changes to change binary files", p.displayPath())), nil
+				}
+				if p.OldPath == "" && p.NewPath == "" {
+					return mcp.NewToolResultError("invalid patch: a file patch has no path"), nil
+				}
+				paths := []string{p.OldPath}
+				if p.NewPath != p.OldPath {
+					paths = append(paths, p.NewPath)
+				}
+				for _, filePath := range paths {
+					if filePath == "" {
+						continue
+					}
+					if !validRepoPath(filePath) {
+						return mcp.NewToolResultError(fmt.Sprintf("invalid patch: invalid path %q", filePath)), nil
+					}
+					if seen[filePath] {
+						return mcp.NewToolResultError(fmt.Sprintf("invalid patch: %s is changed more than once", filePath)), nil
+					}
+					seen[filePath] = true
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			SendProgress(ctx, request, 0, applyPatchSteps, "Reading branch")
+			ref, baseCommit, errResult := getBranchHead(ctx, client, owner, repo, branch, expectedHeadSHA)
+			if errResult != nil {
+				return errResult, nil
+			}
+
+			SendProgress(ctx, request, 1, applyPatchSteps, "Applying patch")
+			lookup := newTreeLookup(client, owner, repo, baseCommit.GetTree().GetSHA())
+			var entries []*github.TreeEntry
+			var files []PatchedFile
+			var failures []string
+			for _, p := range patches {
+				file, fileEntries, failure, errResult := applyFilePatch(ctx, client, lookup, owner, repo, p, fuzz)
+				if errResult != nil {
+					return errResult, nil
+				}
+				if failure != "" {
+					failures = append(failures, failure)
+					continue
+				}
+				entries = append(entries, fileEntries...)
+				files = append(files, file)
+			}
+			if len(failures) > 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("patch does not apply, nothing was committed:\n\n%s", strings.Join(failures, "\n\n"))), nil
+			}
+			if len(entries) == 0 {
+				return mcp.NewToolResultError("patch does not change any files, nothing was committed"), nil
+			}
+
+			SendProgress(ctx, request, 2, applyPatchSteps, "Creating commit")
+			result, errResult := commitTreeEntries(ctx, client, owner, repo, ref, baseCommit, entries, message)
+			if errResult != nil {
+				return errResult, nil
+			}
+			SendProgress(ctx, request, applyPatchSteps, applyPatchSteps, "")
+
+			return MarshalledTextResult(ApplyPatchResult{CommitChangesResult: result, Files: files}), nil
+		}
+}
+
+// applyFilePatch applies the patch of one file, returning the tree entries that make the
+// change. If the patch doesn't apply, it returns why instead.
+func applyFilePatch(ctx context.Context, client *github.Client, lookup *treeLookup, owner, repo string, p *filePatch, fuzz int) (PatchedFile, []*github.TreeEntry, string, *mcp.CallToolResult) {
+	file := PatchedFile{Path: p.displayPath()}
+	getEntry := func(filePath string) (*github.TreeEntry, *mcp.CallToolResult) {
+		entry, resp, err := lookup.get(ctx, filePath)
+		if err != nil {
+			return nil, ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get git tree", resp, err)
+		}
+		return entry, nil
+	}
+
+	var old *github.TreeEntry
+	var content string
+	if !p.New {
+		var errResult *mcp.CallToolResult
+		if old, errResult = getEntry(p.OldPath); errResult != nil {
+			return file, nil, "", errResult
+		}
+		if old == nil || old.GetType() != "blob" {
+			return file, nil, fmt.Sprintf("%s: no such file", p.OldPath), nil
+		}
+		blob, resp, err := client.Git.GetBlobRaw(ctx, owner, repo, old.GetSHA())
+		if err != nil {
+			return file, nil, "", ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get contents of %s", p.OldPath), resp, err)
+		}
+		_ = resp.Body.Close()
+		content = string(blob)
+	}
+	if p.NewPath != "" && p.NewPath != p.OldPath {
+		existing, errResult := getEntry(p.NewPath)
+		if errResult != nil {
+			return file, nil, "", errResult
+		}
+		if existing != nil {
+			return file, nil, fmt.Sprintf("%s: already exists", p.NewPath), nil
+		}
+	}
+
+	patched, notes, rejects := applyHunks(content, p.Hunks, fuzz)
+	if len(rejects) > 0 {
+		var failure strings.Builder
+		for i, reject := range rejects {
+			if i > 0 {
+				failure.WriteString("\n")
+			}
+			fmt.Fprintf(&failure, "%s: hunk %d %s failed, %s", file.Path, reject.Hunk, reject.Header, reject.Reason)
+		}
+		return file, nil, failure.String(), nil
+	}
+	file.Notes = notes
+
+	var entries []*github.TreeEntry
+	switch {
+	case p.Deleted:
+		if patched != "" {
+			return file, nil, fmt.Sprintf("%s: the patch deletes the file, but doesn't remove all of its lines", p.OldPath), nil
+		}
+		file.Status = "deleted"
+		// An entry with neither a SHA nor content removes the path
+		return file, append(entries, &github.TreeEntry{Path: github.Ptr(p.OldPath), Mode: old.Mode, Type: old.Type}), "", nil
+	case p.New:
+		file.Status = "added"
+	case p.Copy:
+		file.Status = "copied"
+		file.PreviousPath = p.OldPath
+	case p.OldPath != p.NewPath:
+		file.Status = "renamed"
+		file.PreviousPath = p.OldPath
+		entries = append(entries, &github.TreeEntry{Path: github.Ptr(p.OldPath), Mode: old.Mode, Type: old.Type})
+	default:
+		file.Status = "modified"
+	}
+
+	entry := &github.TreeEntry{Path: github.Ptr(p.NewPath), Type: github.Ptr("blob"), Mode: github.Ptr(fileModes["file"])}
+	if old != nil {
+		entry.Mode = old.Mode
+	}
+	if p.NewMode != "" {
+		entry.Mode = github.Ptr(p.NewMode)
+	}
+	switch {
+	case old != nil && patched == content:
+		if file.Status == "modified" && entry.GetMode() == old.GetMode() {
+			return file, nil, "", nil
+		}
+		entry.SHA = old.SHA
+	case utf8.ValidString(patched):
+		entry.Content = github.Ptr(patched)
+	default:
+		blob, resp, err := client.Git.CreateBlob(ctx, owner, repo, &github.Blob{
+			Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(patched))),
+			Encoding: github.Ptr("base64"),
+		})
+		if err != nil {
+			return file, nil, "", ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to create blob for %s", p.NewPath), resp, err)
+		}
+		_ = resp.Body.Close()
+		entry.SHA = blob.SHA
+	}
+	return file, append(entries, entry), "", nil
+}
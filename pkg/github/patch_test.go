@@ -0,0 +1,366 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ApplyPatch(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := ApplyPatch(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "apply_patch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint, "apply_patch tool should not be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.Contains(t, tool.InputSchema.Properties, "patch")
+	assert.Contains(t, tool.InputSchema.Properties, "fuzz")
+	assert.Contains(t, tool.InputSchema.Properties, "expected_head_sha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "message", "patch"})
+
+	blobs := map[string]string{
+		"sha-main": "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hello\")\n}\n",
+		"sha-old":  "old\n",
+		"sha-gone": "bye\n",
+	}
+	mockedClient := func(createTree http.HandlerFunc) *http.Client {
+		return mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, &github.Reference{
+				Ref:    github.Ptr("refs/heads/main"),
+				Object: &github.GitObject{SHA: github.Ptr("abc123")},
+			}),
+			mock.WithRequestMatch(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, &github.Commit{
+				SHA:  github.Ptr("abc123"),
+				Tree: &github.Tree{SHA: github.Ptr("tree-root")},
+			}),
+			mock.WithRequestMatch(mock.GetReposGitTreesByOwnerByRepoByTreeSha, &github.Tree{Entries: []*github.TreeEntry{
+				{Path: github.Ptr("main.go"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("sha-main")},
+				{Path: github.Ptr("old.txt"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("sha-old")},
+				{Path: github.Ptr("gone.txt"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("sha-gone")},
+			}}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = w.Write([]byte(blobs[r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]]))
+				}),
+			),
+			mock.WithRequestMatchHandler(mock.PostReposGitTreesByOwnerByRepo, createTree),
+			mock.WithRequestMatch(mock.PostReposGitCommitsByOwnerByRepo, &github.Commit{
+				SHA:     github.Ptr("new456"),
+				HTMLURL: github.Ptr("https://github.com/owner/repo/commit/new456"),
+			}),
+			mock.WithRequestMatch(mock.PatchReposGitRefsByOwnerByRepoByRef, &github.Reference{
+				Ref:    github.Ptr("refs/heads/main"),
+				Object: &github.GitObject{SHA: github.Ptr("new456")},
+			}),
+		)
+	}
+
+	tests := []struct {
+		name           string
+		createTree     http.HandlerFunc
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedResult ApplyPatchResult
+	}{
+		{
+			name: "applies a git diff with an offset hunk, a new file, a rename and a deletion",
+			createTree: expectRequestBody(t, map[string]any{
+				"base_tree": "tree-root",
+				"tree": []any{
+					map[string]any{"path": "main.go", "mode": "100644", "type": "blob", "content": "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hello, world\")\n}\n"},
+					map[string]any{"path": "run.sh", "mode": "100755", "type": "blob", "content": "#!/bin/sh\ngo run .\n"},
+					map[string]any{"path": "old.txt", "mode": "100644", "type": "blob", "sha": nil},
+					map[string]any{"path": "new.txt", "mode": "100644", "type": "blob", "sha": "sha-old"},
+					map[string]any{"path": "gone.txt", "mode": "100644", "type": "blob", "sha": nil},
+				},
+			}).andThen(
+				mockResponse(t, http.StatusCreated, &github.Tree{SHA: github.Ptr("tree-new")}),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Greet the world",
+				"patch": `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -3,3 +3,3 @@ import "fmt"
+ func main() {
+-	fmt.Println("hello")
++	fmt.Println("hello, world")
+ }
+diff --git a/run.sh b/run.sh
+new file mode 100755
+index 0000000..3333333
+--- /dev/null
++++ b/run.sh
+@@ -0,0 +1,2 @@
++#!/bin/sh
++go run .
+diff --git a/old.txt b/new.txt
+similarity index 100%
+rename from old.txt
+rename to new.txt
+diff --git a/gone.txt b/gone.txt
+deleted file mode 100644
+index 4444444..0000000
+--- a/gone.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-bye
+`,
+			},
+			expectedResult: ApplyPatchResult{
+				CommitChangesResult: CommitChangesResult{
+					SHA:       "new456",
+					HTMLURL:   "https://github.com/owner/repo/commit/new456",
+					TreeSHA:   "tree-new",
+					ParentSHA: "abc123",
+					Ref:       "refs/heads/main",
+				},
+				Files: []PatchedFile{
+					{Path: "main.go", Status: "modified", Notes: []string{"hunk 1 @@ -3,3 +3,3 @@ applied at line 5 (offset 2 lines)"}},
+					{Path: "run.sh", Status: "added"},
+					{Path: "new.txt", PreviousPath: "old.txt", Status: "renamed"},
+					{Path: "gone.txt", Status: "deleted"},
+				},
+			},
+		},
+		{
+			name: "reports hunks that don't apply without committing",
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Update",
+				"patch": `--- main.go
++++ main.go
+@@ -5,3 +5,3 @@
+ func main() {
+-	fmt.Println("goodbye")
++	fmt.Println("hello")
+ }
+--- missing.txt
++++ missing.txt
+@@ -1 +1 @@
+-a
++b
+`,
+			},
+			expectError:    true,
+			expectedErrMsg: "patch does not apply, nothing was committed:\n\nmain.go: hunk 1 @@ -5,3 +5,3 @@ failed, the lines it changes were not found near line 5. Expected:\n  func main() {\n  \tfmt.Println(\"goodbye\")\n  }\n\nmissing.txt: no such file",
+		},
+		{
+			name: "new file that already exists",
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Update",
+				"patch":   "--- /dev/null\n+++ b/old.txt\n@@ -0,0 +1 @@\n+new\n",
+			},
+			expectError:    true,
+			expectedErrMsg: "old.txt: already exists",
+		},
+		{
+			name: "binary patch",
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Update",
+				"patch":   "diff --git a/logo.png b/logo.png\nindex 1111111..2222222 100644\nBinary files a/logo.png and b/logo.png differ\n",
+			},
+			expectError:    true,
+			expectedErrMsg: "binary patch of logo.png is not supported",
+		},
+		{
+			name: "path outside the repository",
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Update",
+				"patch":   "--- a/../etc/passwd\n+++ b/../etc/passwd\n@@ -1 +1 @@\n-a\n+b\n",
+			},
+			expectError:    true,
+			expectedErrMsg: `invalid path "../etc/passwd"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			createTree := tc.createTree
+			if createTree == nil {
+				createTree = func(_ http.ResponseWriter, _ *http.Request) {
+					t.Fatal("no tree should be created")
+				}
+			}
+			client := github.NewClient(mockedClient(createTree))
+			_, handler := ApplyPatch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var patchResult ApplyPatchResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &patchResult))
+			assert.Equal(t, tc.expectedResult, patchResult)
+		})
+	}
+}
+
+func Test_ParseUnifiedDiff(t *testing.T) {
+	patches, err := parseUnifiedDiff(`From 1234 Mon Sep 17 00:00:00 2001
+Subject: [PATCH] Update
+
+diff --git "a/with space.txt" "b/with space.txt"
+old mode 100644
+new mode 100755
+diff --git a/a.txt b/a.txt
+--- a/a.txt
++++ b/a.txt
+@@ -1,3 +1,3 @@
+ one
+
+-three
+\ No newline at end of file
++3
+--
+2.40.0
+`)
+	require.NoError(t, err)
+	require.Len(t, patches, 2)
+
+	assert.Equal(t, &filePatch{OldPath: "with space.txt", NewPath: "with space.txt", NewMode: "100755"}, patches[0])
+	assert.Equal(t, &filePatch{
+		OldPath: "a.txt",
+		NewPath: "a.txt",
+		Hunks: []hunk{{
+			Header:   "@@ -1,3 +1,3 @@",
+			OldStart: 1,
+			OldLines: 3,
+			Lines: []hunkLine{
+				{Op: ' ', Text: "one"},
+				{Op: ' ', Text: ""},
+				{Op: '-', Text: "three"},
+				{Op: '+', Text: "3"},
+			},
+			OldNoEOL: true,
+		}},
+	}, patches[1])
+
+	_, err = parseUnifiedDiff("@@ -1 +1 @@\n-a\n+b\n")
+	assert.ErrorContains(t, err, "not preceded by a file header")
+	_, err = parseUnifiedDiff("--- a\n+++ b\n@@ -1 +1 @@\nnot a hunk line\n")
+	assert.ErrorContains(t, err, "is empty")
+}
+
+func Test_ApplyHunks(t *testing.T) {
+	hunkOf := func(oldStart int, lines ...string) hunk {
+		h := hunk{Header: "@@", OldStart: oldStart}
+		for _, l := range lines {
+			h.Lines = append(h.Lines, hunkLine{Op: l[0], Text: l[1:]})
+		}
+		return h
+	}
+	content := "a\nb\nc\nd\ne\nf\ng\n"
+
+	tests := []struct {
+		name            string
+		content         string
+		hunks           []hunk
+		fuzz            int
+		expected        string
+		expectedNotes   []string
+		expectedRejects int
+	}{
+		{
+			name:     "in place",
+			content:  content,
+			hunks:    []hunk{hunkOf(2, " b", "-c", "+C", " d"), hunkOf(5, " e", "+E", " f")},
+			expected: "a\nb\nC\nd\ne\nE\nf\ng\n",
+		},
+		{
+			name:          "with an offset carried to later hunks",
+			content:       "x\nx\n" + content,
+			hunks:         []hunk{hunkOf(2, " b", "-c", "+C", " d"), hunkOf(5, " e", "+E", " f")},
+			expected:      "x\nx\na\nb\nC\nd\ne\nE\nf\ng\n",
+			expectedNotes: []string{"hunk 1 @@ applied at line 4 (offset 2 lines)"},
+		},
+		{
+			name:            "context that changed is rejected without fuzz",
+			content:         content,
+			hunks:           []hunk{hunkOf(2, " B", "-c", "+C", " d")},
+			expected:        content,
+			expectedRejects: 1,
+		},
+		{
+			name:          "context that changed is ignored with fuzz",
+			content:       content,
+			hunks:         []hunk{hunkOf(2, " B", "-c", "+C", " d")},
+			fuzz:          1,
+			expected:      "a\nb\nC\nd\ne\nf\ng\n",
+			expectedNotes: []string{"hunk 1 @@ applied with fuzz 1"},
+		},
+		{
+			name:          "trailing whitespace",
+			content:       "a \nb\r\n",
+			hunks:         []hunk{hunkOf(1, " a", "-b", "+c")},
+			expected:      "a \nc\n",
+			expectedNotes: []string{"hunk 1 @@ applied ignoring trailing whitespace"},
+		},
+		{
+			name:     "new file without a trailing newline",
+			content:  "",
+			hunks:    []hunk{func() hunk { h := hunkOf(1, "+a", "+b"); h.NewNoEOL = true; return h }()},
+			expected: "a\nb",
+		},
+		{
+			name:     "adds a missing trailing newline",
+			content:  "a\nb",
+			hunks:    []hunk{func() hunk { h := hunkOf(1, " a", "-b", "+b"); h.OldNoEOL = true; return h }()},
+			expected: "a\nb\n",
+		},
+		{
+			name:     "removes all lines",
+			content:  "a\nb\n",
+			hunks:    []hunk{hunkOf(1, "-a", "-b")},
+			expected: "",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, notes, rejects := applyHunks(tc.content, tc.hunks, tc.fuzz)
+			assert.Len(t, rejects, tc.expectedRejects)
+			if tc.expectedRejects == 0 {
+				assert.Equal(t, tc.expected, result)
+			}
+			assert.Equal(t, tc.expectedNotes, notes)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package github
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// MethodNotificationProgress reports the progress of a long-running request.
+	MethodNotificationProgress = "notifications/progress"
+	// MethodNotificationCancelled is sent by the client to cancel a request it previously sent.
+	MethodNotificationCancelled = "notifications/cancelled"
+
+	// requestIDMetaKey is where the JSON-RPC request ID is stashed in the request metadata,
+	// since tool handlers and middlewares do not otherwise get to see it.
+	requestIDMetaKey = "github-mcp-server/requestId"
+)
+
+// SendProgress notifies the client about the progress of a tool call, if the client asked for
+// progress by including a progress token in the request. total may be zero when it is unknown.
+func SendProgress(ctx context.Context, request mcp.CallToolRequest, progress, total float64, message string) {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return
+	}
+	s := server.ServerFromContext(ctx)
+	if s == nil {
+		return
+	}
+
+	params := map[string]any{
+		"progressToken": request.Params.Meta.ProgressToken,
+		"progress":      progress,
+	}
+	if total > 0 {
+		params["total"] = total
+	}
+	if message != "" {
+		params["message"] = message
+	}
+	// Progress is best effort, a failure to deliver it must not fail the tool call
+	_ = s.SendNotificationToClient(ctx, MethodNotificationProgress, params)
+}
+
+// InFlightRequests tracks running tool calls so that a notifications/cancelled from the client
+// cancels the context of the call, and with it every outbound request the call is making.
+type InFlightRequests struct {
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+}
+
+// NewInFlightRequests creates an empty tracker.
+func NewInFlightRequests() *InFlightRequests {
+	return &InFlightRequests{
+		cancels: make(map[string]context.CancelFunc),
+	}
+}
+
+// requestKey normalizes JSON-RPC request IDs, which may be numbers or strings.
+func requestKey(id any) string {
+	if requestID, ok := id.(mcp.RequestId); ok {
+		return requestID.String()
+	}
+	return mcp.NewRequestId(id).String()
+}
+
+// BeforeCallTool is a server.OnBeforeCallToolFunc hook that records the request ID on the request.
+func (r *InFlightRequests) BeforeCallTool(_ context.Context, id any, message *mcp.CallToolRequest) {
+	if message.Params.Meta == nil {
+		message.Params.Meta = &mcp.Meta{}
+	}
+	if message.Params.Meta.AdditionalFields == nil {
+		message.Params.Meta.AdditionalFields = make(map[string]any)
+	}
+	message.Params.Meta.AdditionalFields[requestIDMetaKey] = requestKey(id)
+}
+
+// Middleware gives every tool call a context that is cancelled when the client cancels the request.
+func (r *InFlightRequests) Middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if request.Params.Meta == nil {
+			return next(ctx, request)
+		}
+		key, ok := request.Params.Meta.AdditionalFields[requestIDMetaKey].(string)
+		if !ok {
+			return next(ctx, request)
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		r.mu.Lock()
+		r.cancels[key] = cancel
+		r.mu.Unlock()
+
+		defer func() {
+			r.mu.Lock()
+			delete(r.cancels, key)
+			r.mu.Unlock()
+			cancel()
+		}()
+
+		return next(ctx, request)
+	}
+}
+
+// Cancel cancels the tool call with the given request ID, if it is still running.
+func (r *InFlightRequests) Cancel(id any) {
+	r.mu.Lock()
+	cancel, ok := r.cancels[requestKey(id)]
+	r.mu.Unlock()
+	if ok {
+		cancel()
+	}
+}
+
+// HandleCancelled is a server.NotificationHandlerFunc for notifications/cancelled.
+func (r *InFlightRequests) HandleCancelled(_ context.Context, notification mcp.JSONRPCNotification) {
+	if id, ok := notification.Params.AdditionalFields["requestId"]; ok {
+		r.Cancel(id)
+	}
+}
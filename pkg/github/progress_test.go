@@ -0,0 +1,91 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *fakeSession) Initialize()                                         {}
+func (s *fakeSession) Initialized() bool                                   { return true }
+func (s *fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s *fakeSession) SessionID() string                                   { return "session" }
+
+func Test_ProgressAndCancellation(t *testing.T) {
+	inFlight := NewInFlightRequests()
+	hooks := &server.Hooks{}
+	hooks.AddBeforeCallTool(inFlight.BeforeCallTool)
+	s := server.NewMCPServer("test", "1.0.0",
+		server.WithHooks(hooks),
+		server.WithToolHandlerMiddleware(inFlight.Middleware),
+	)
+	s.AddNotificationHandler(MethodNotificationCancelled, inFlight.HandleCancelled)
+
+	// The tool reports progress and then runs until it is cancelled
+	s.AddTool(mcp.NewTool("slow"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		SendProgress(ctx, request, 1, 2, "halfway")
+		<-ctx.Done()
+		return mcp.NewToolResultError(ctx.Err().Error()), nil
+	})
+
+	session := &fakeSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	ctx := s.WithContext(context.Background(), session)
+
+	done := make(chan mcp.JSONRPCMessage, 1)
+	go func() {
+		done <- s.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"slow","_meta":{"progressToken":"token"}}}`))
+	}()
+
+	select {
+	case notification := <-session.notifications:
+		assert.Equal(t, MethodNotificationProgress, notification.Method)
+		assert.Equal(t, "token", notification.Params.AdditionalFields["progressToken"])
+		assert.Equal(t, float64(1), notification.Params.AdditionalFields["progress"])
+		assert.Equal(t, float64(2), notification.Params.AdditionalFields["total"])
+		assert.Equal(t, "halfway", notification.Params.AdditionalFields["message"])
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a progress notification")
+	}
+
+	// Cancelling another request has no effect
+	s.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":8}}`))
+	select {
+	case <-done:
+		t.Fatal("tool call should still be running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	s.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":7,"reason":"user aborted"}}`))
+	select {
+	case msg := <-done:
+		response, ok := msg.(mcp.JSONRPCResponse)
+		require.True(t, ok)
+		result, ok := response.Result.(mcp.CallToolResult)
+		require.True(t, ok)
+		assert.True(t, result.IsError)
+	case <-time.After(5 * time.Second):
+		t.Fatal("tool call should have been cancelled")
+	}
+
+	// Finished calls are no longer tracked
+	assert.Empty(t, inFlight.cancels)
+}
+
+func Test_SendProgressWithoutToken(t *testing.T) {
+	s := server.NewMCPServer("test", "1.0.0")
+	session := &fakeSession{notifications: make(chan mcp.JSONRPCNotification, 1)}
+	ctx := s.WithContext(context.Background(), session)
+
+	SendProgress(ctx, createMCPRequest(map[string]any{}), 1, 1, "")
+	assert.Empty(t, session.notifications)
+}
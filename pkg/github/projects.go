@@ -25,6 +25,7 @@ func ListProjects(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			mcp.WithString("owner", mcp.Required(), mcp.Description("If owner_type == user it is the handle for the GitHub user account. If owner_type == org it is the name of the organization. The name is not case sensitive.")),
 			mcp.WithString("query", mcp.Description("Filter projects by a search query (matches title and description)")),
 			mcp.WithNumber("per_page", mcp.Description("Number of results per page (max 100, default: 30)")),
+			WithOutputSchema[[]github.ProjectV2](),
 		), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](req, "owner")
 			if err != nil {
@@ -106,6 +107,7 @@ func GetProject(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			mcp.WithNumber("project_number", mcp.Required(), mcp.Description("The project's number")),
 			mcp.WithString("owner_type", mcp.Required(), mcp.Description("Owner type"), mcp.Enum("user", "org")),
 			mcp.WithString("owner", mcp.Required(), mcp.Description("If owner_type == user it is the handle for the GitHub user account. If owner_type == org it is the name of the organization. The name is not case sensitive.")),
+			WithOutputSchema[github.ProjectV2](),
 		), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 
 			projectNumber, err := RequiredInt(req, "project_number")
@@ -176,6 +178,7 @@ func ListProjectFields(getClient GetClientFn, t translations.TranslationHelperFu
 			mcp.WithString("owner", mcp.Required(), mcp.Description("If owner_type == user it is the handle for the GitHub user account. If owner_type == org it is the name of the organization. The name is not case sensitive.")),
 			mcp.WithString("projectNumber", mcp.Required(), mcp.Description("The project's number.")),
 			mcp.WithNumber("per_page", mcp.Description("Number of results per page (max 100, default: 30)")),
+			WithOutputSchema[[]projectV2Field](),
 		), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](req, "owner")
 			if err != nil {
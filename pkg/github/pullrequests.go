@@ -37,6 +37,8 @@ func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc)
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			WithOutputSchema[*github.PullRequest](),
+			WithMinimalOutput[MinimalPullRequest](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -52,6 +54,11 @@ func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -74,6 +81,10 @@ func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
 			}
 
+			if minimalOutput {
+				return MarshalledTextResult(convertToMinimalPullRequest(pr, true)), nil
+			}
+
 			r, err := json.Marshal(pr)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
@@ -120,6 +131,7 @@ func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 			mcp.WithBoolean("maintainer_can_modify",
 				mcp.Description("Allow maintainer edits"),
 			),
+			WithOutputSchema[MinimalResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -253,6 +265,7 @@ func UpdatePullRequest(getClient GetClientFn, getGQLClient GetGQLClientFn, t tra
 					"type": "string",
 				}),
 			),
+			WithOutputSchema[MinimalResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -520,6 +533,8 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Enum("asc", "desc"),
 			),
 			WithPagination(),
+			WithOutputSchema[ListResult[*github.PullRequest]](),
+			WithMinimalOutput[ListResult[MinimalPullRequest]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -566,6 +581,11 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				},
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -588,7 +608,11 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list pull requests: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(prs)
+			if minimalOutput {
+				return MarshalledTextResult(convertListResult(restListResult(prs, resp), func(pr *github.PullRequest) MinimalPullRequest { return convertToMinimalPullRequest(pr, false) })), nil
+			}
+
+			r, err := json.Marshal(restListResult(prs, resp))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -627,6 +651,7 @@ func MergePullRequest(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Description("Merge method"),
 				mcp.Enum("merge", "squash", "rebase"),
 			),
+			WithOutputSchema[*github.PullRequestMergeResult](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -729,6 +754,8 @@ func SearchPullRequests(getClient GetClientFn, t translations.TranslationHelperF
 				mcp.Enum("asc", "desc"),
 			),
 			WithPagination(),
+			WithOutputSchema[*github.IssuesSearchResult](),
+			WithMinimalOutput[MinimalSearchIssuesResult](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			return searchHandler(ctx, getClient, request, "pr", "failed to search pull requests")
@@ -756,6 +783,7 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 				mcp.Description("Pull request number"),
 			),
 			WithPagination(),
+			WithOutputSchema[ListResult[*github.CommitFile]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -801,7 +829,7 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request files: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(files)
+			r, err := json.Marshal(restListResult(files, resp))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -830,6 +858,7 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			WithOutputSchema[*github.CombinedStatus](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -918,6 +947,7 @@ func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHe
 			mcp.WithString("expectedHeadSha",
 				mcp.Description("The expected SHA of the pull request's HEAD ref"),
 			),
+			WithRawOutputSchema(OutputSchemaAnyOf(OutputSchema[*github.PullRequestBranchUpdateResponse](), OutputSchema[MessageResponse]())),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -950,7 +980,7 @@ func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHe
 				// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
 				// and it's not a real error.
 				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
-					return mcp.NewToolResultText("Pull request branch update is in progress"), nil
+					return MarshalledTextResult(MessageResponse{Message: "Pull request branch update is in progress"}), nil
 				}
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to update pull request branch",
@@ -997,6 +1027,8 @@ func GetPullRequestReviewComments(getClient GetClientFn, t translations.Translat
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			WithOutputSchema[[]*github.PullRequestComment](),
+			WithMinimalOutput[[]MinimalPullRequestComment](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1018,6 +1050,11 @@ func GetPullRequestReviewComments(getClient GetClientFn, t translations.Translat
 				},
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -1040,6 +1077,10 @@ func GetPullRequestReviewComments(getClient GetClientFn, t translations.Translat
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request review comments: %s", string(body))), nil
 			}
 
+			if minimalOutput {
+				return MarshalledTextResult(convertToMinimalList(comments, convertToMinimalPullRequestComment)), nil
+			}
+
 			r, err := json.Marshal(comments)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
@@ -1069,6 +1110,8 @@ func GetPullRequestReviews(getClient GetClientFn, t translations.TranslationHelp
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			WithOutputSchema[[]*github.PullRequestReview](),
+			WithMinimalOutput[[]MinimalPullRequestReview](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1084,6 +1127,11 @@ func GetPullRequestReviews(getClient GetClientFn, t translations.TranslationHelp
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -1106,6 +1154,10 @@ func GetPullRequestReviews(getClient GetClientFn, t translations.TranslationHelp
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request reviews: %s", string(body))), nil
 			}
 
+			if minimalOutput {
+				return MarshalledTextResult(convertToMinimalList(reviews, convertToMinimalPullRequestReview)), nil
+			}
+
 			r, err := json.Marshal(reviews)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
@@ -1149,6 +1201,7 @@ func CreateAndSubmitPullRequestReview(getGQLClient GetGQLClientFn, t translation
 			mcp.WithString("commitID",
 				mcp.Description("SHA of commit to review"),
 			),
+			WithOutputSchema[MessageResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			var params struct {
@@ -1213,7 +1266,7 @@ func CreateAndSubmitPullRequestReview(getGQLClient GetGQLClientFn, t translation
 			// Return nothing interesting, just indicate success for the time being.
 			// In future, we may want to return the review ID, but for the moment, we're not leaking
 			// API implementation details to the LLM.
-			return mcp.NewToolResultText("pull request review submitted successfully"), nil
+			return MarshalledTextResult(MessageResponse{Message: "pull request review submitted successfully"}), nil
 		}
 }
 
@@ -1245,6 +1298,7 @@ func CreatePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 			),
 			// Event is omitted here because we always want to create a pending review.
 			// Threads are omitted for the moment, and we'll see if the LLM can use the appropriate tool.
+			WithOutputSchema[MessageResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			var params struct {
@@ -1305,7 +1359,7 @@ func CreatePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 			// Return nothing interesting, just indicate success for the time being.
 			// In future, we may want to return the review ID, but for the moment, we're not leaking
 			// API implementation details to the LLM.
-			return mcp.NewToolResultText("pending pull request created"), nil
+			return MarshalledTextResult(MessageResponse{Message: "pending pull request created"}), nil
 		}
 }
 
@@ -1365,6 +1419,7 @@ func AddCommentToPendingReview(getGQLClient GetGQLClientFn, t translations.Trans
 				mcp.Description("For multi-line comments, the starting side of the diff that the comment applies to. LEFT indicates the previous state, RIGHT indicates the new state"),
 				mcp.Enum("LEFT", "RIGHT"),
 			),
+			WithOutputSchema[MessageResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			var params struct {
@@ -1471,7 +1526,7 @@ func AddCommentToPendingReview(getGQLClient GetGQLClientFn, t translations.Trans
 			// Return nothing interesting, just indicate success for the time being.
 			// In future, we may want to return the review ID, but for the moment, we're not leaking
 			// API implementation details to the LLM.
-			return mcp.NewToolResultText("pull request review comment successfully added to pending review"), nil
+			return MarshalledTextResult(MessageResponse{Message: "pull request review comment successfully added to pending review"}), nil
 		}
 }
 
@@ -1507,6 +1562,7 @@ func SubmitPendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 			mcp.WithString("body",
 				mcp.Description("The text of the review comment"),
 			),
+			WithOutputSchema[MessageResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			var params struct {
@@ -1606,7 +1662,7 @@ func SubmitPendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 			// Return nothing interesting, just indicate success for the time being.
 			// In future, we may want to return the review ID, but for the moment, we're not leaking
 			// API implementation details to the LLM.
-			return mcp.NewToolResultText("pending pull request review successfully submitted"), nil
+			return MarshalledTextResult(MessageResponse{Message: "pending pull request review successfully submitted"}), nil
 		}
 }
 
@@ -1633,6 +1689,7 @@ func DeletePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			WithOutputSchema[MessageResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			var params struct {
@@ -1725,10 +1782,15 @@ func DeletePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 			// Return nothing interesting, just indicate success for the time being.
 			// In future, we may want to return the review ID, but for the moment, we're not leaking
 			// API implementation details to the LLM.
-			return mcp.NewToolResultText("pending pull request review successfully deleted"), nil
+			return MarshalledTextResult(MessageResponse{Message: "pending pull request review successfully deleted"}), nil
 		}
 }
 
+// PullRequestDiff is the structured content of get_pull_request_diff, whose text is the raw diff.
+type PullRequestDiff struct {
+	Diff string `json:"diff"`
+}
+
 func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("get_pull_request_diff",
 			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_DIFF_DESCRIPTION", "Get the diff of a pull request.")),
@@ -1748,6 +1810,7 @@ func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperF
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			WithOutputSchema[PullRequestDiff](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			var params struct {
@@ -1789,8 +1852,10 @@ func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperF
 
 			defer func() { _ = resp.Body.Close() }()
 
-			// Return the raw response
-			return mcp.NewToolResultText(string(raw)), nil
+			// The diff is returned as it is, rather than escaped in JSON, for models to read
+			result := mcp.NewToolResultText(string(raw))
+			result.StructuredContent = PullRequestDiff{Diff: string(raw)}
+			return result, nil
 		}
 }
 
@@ -1816,6 +1881,7 @@ func RequestCopilotReview(getClient GetClientFn, t translations.TranslationHelpe
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			WithOutputSchema[MessageResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1865,8 +1931,8 @@ func RequestCopilotReview(getClient GetClientFn, t translations.TranslationHelpe
 				return mcp.NewToolResultError(fmt.Sprintf("failed to request copilot review: %s", string(body))), nil
 			}
 
-			// Return nothing on success, as there's not much value in returning the Pull Request itself
-			return mcp.NewToolResultText(""), nil
+			// Return a message on success, as there's not much value in returning the Pull Request itself
+			return MarshalledTextResult(MessageResponse{Message: "Copilot review requested"}), nil
 		}
 }
 
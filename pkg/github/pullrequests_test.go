@@ -692,9 +692,10 @@ func Test_ListPullRequests(t *testing.T) {
 			textContent := getTextResult(t, result)
 
 			// Unmarshal and verify the result
-			var returnedPRs []*github.PullRequest
-			err = json.Unmarshal([]byte(textContent.Text), &returnedPRs)
+			var response ListResult[*github.PullRequest]
+			err = json.Unmarshal([]byte(textContent.Text), &response)
 			require.NoError(t, err)
+			returnedPRs := response.Items
 			assert.Len(t, returnedPRs, 2)
 			assert.Equal(t, *tc.expectedPRs[0].Number, *returnedPRs[0].Number)
 			assert.Equal(t, *tc.expectedPRs[0].Title, *returnedPRs[0].Title)
@@ -1253,9 +1254,10 @@ func Test_GetPullRequestFiles(t *testing.T) {
 			textContent := getTextResult(t, result)
 
 			// Unmarshal and verify the result
-			var returnedFiles []*github.CommitFile
-			err = json.Unmarshal([]byte(textContent.Text), &returnedFiles)
+			var response ListResult[*github.CommitFile]
+			err = json.Unmarshal([]byte(textContent.Text), &response)
 			require.NoError(t, err)
+			returnedFiles := response.Items
 			assert.Len(t, returnedFiles, len(tc.expectedFiles))
 			for i, file := range returnedFiles {
 				assert.Equal(t, *tc.expectedFiles[i].Filename, *file.Filename)
@@ -2151,7 +2153,7 @@ func TestCreateAndSubmitPullRequestReview(t *testing.T) {
 			}
 
 			// Parse the result and get the text content if no error
-			require.Equal(t, textContent.Text, "pull request review submitted successfully")
+			require.JSONEq(t, `{"message":"pull request review submitted successfully"}`, textContent.Text)
 		})
 	}
 }
@@ -2264,7 +2266,7 @@ func Test_RequestCopilotReview(t *testing.T) {
 			assert.Len(t, result.Content, 1)
 
 			textContent := getTextResult(t, result)
-			require.Equal(t, "", textContent.Text)
+			require.JSONEq(t, `{"message":"Copilot review requested"}`, textContent.Text)
 		})
 	}
 }
@@ -2447,7 +2449,7 @@ func TestCreatePendingPullRequestReview(t *testing.T) {
 			}
 
 			// Parse the result and get the text content if no error
-			require.Equal(t, textContent.Text, "pending pull request created")
+			require.JSONEq(t, `{"message":"pending pull request created"}`, textContent.Text)
 		})
 	}
 }
@@ -2560,7 +2562,7 @@ func TestAddPullRequestReviewCommentToPendingReview(t *testing.T) {
 			}
 
 			// Parse the result and get the text content if no error
-			require.Equal(t, textContent.Text, "pull request review comment successfully added to pending review")
+			require.JSONEq(t, `{"message":"pull request review comment successfully added to pending review"}`, textContent.Text)
 		})
 	}
 }
@@ -2658,7 +2660,7 @@ func TestSubmitPendingPullRequestReview(t *testing.T) {
 			}
 
 			// Parse the result and get the text content if no error
-			require.Equal(t, "pending pull request review successfully submitted", textContent.Text)
+			require.JSONEq(t, `{"message":"pending pull request review successfully submitted"}`, textContent.Text)
 		})
 	}
 }
@@ -2750,7 +2752,7 @@ func TestDeletePendingPullRequestReview(t *testing.T) {
 			}
 
 			// Parse the result and get the text content if no error
-			require.Equal(t, "pending pull request review successfully deleted", textContent.Text)
+			require.JSONEq(t, `{"message":"pending pull request review successfully deleted"}`, textContent.Text)
 		})
 	}
 }
@@ -2835,6 +2837,7 @@ index 5d6e7b2..8a4f5c3 100644
 
 			// Parse the result and get the text content if no error
 			require.Equal(t, stubbedDiff, textContent.Text)
+			require.Equal(t, PullRequestDiff{Diff: stubbedDiff}, result.StructuredContent)
 		})
 	}
 }
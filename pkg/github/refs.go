@@ -0,0 +1,418 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// CreatedTag is the output of create_tag.
+type CreatedTag struct {
+	Ref       string `json:"ref"`
+	SHA       string `json:"sha"`
+	CommitSHA string `json:"commit_sha"`
+	Annotated bool   `json:"annotated"`
+}
+
+// UpdatedRef is the output of update_ref.
+type UpdatedRef struct {
+	Ref         string `json:"ref"`
+	SHA         string `json:"sha"`
+	PreviousSHA string `json:"previous_sha"`
+	Forced      bool   `json:"forced"`
+}
+
+// DeletedRef is the output of delete_ref.
+type DeletedRef struct {
+	Ref         string `json:"ref"`
+	PreviousSHA string `json:"previous_sha"`
+}
+
+// normalizeRef returns ref without its refs/ prefix, requiring it to be a branch or tag.
+func normalizeRef(ref string) (string, error) {
+	ref = strings.TrimPrefix(ref, "refs/")
+	if !strings.HasPrefix(ref, "heads/") && !strings.HasPrefix(ref, "tags/") || strings.HasSuffix(ref, "/") {
+		return "", fmt.Errorf("ref %q must be a branch or tag reference, such as heads/main or tags/v1.0.0", ref)
+	}
+	return ref, nil
+}
+
+// refChangeErrorResponse returns the error result of a failed change to ref, explaining
+// when it was refused because ref is a protected branch.
+func refChangeErrorResponse(ctx context.Context, client *github.Client, owner, repo, ref, message string, resp *github.Response, err error) *mcp.CallToolResult {
+	branch, isBranch := strings.CutPrefix(ref, "heads/")
+	if isBranch && resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnprocessableEntity) {
+		if b, branchResp, branchErr := client.Repositories.GetBranch(ctx, owner, repo, branch, 1); branchErr == nil {
+			_ = branchResp.Body.Close()
+			if b.GetProtected() {
+				message = fmt.Sprintf("%s: branch %s is protected, and its protection rules don't allow this change", message, branch)
+			}
+		}
+	}
+	return ghErrors.NewGitHubAPIErrorResponse(ctx, message, resp, err)
+}
+
+// CreateTag creates a tool to create a lightweight or annotated tag in a GitHub repository.
+func CreateTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_tag",
+			mcp.WithDescription(t("TOOL_CREATE_TAG_DESCRIPTION", "Create a git tag in a GitHub repository. The tag is annotated if a message is given, and lightweight otherwise")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_TAG_USER_TITLE", "Create tag"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("tag",
+				mcp.Required(),
+				mcp.Description("Tag name (e.g., 'v1.0.0')"),
+			),
+			mcp.WithString("target",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch or tag to tag"),
+			),
+			mcp.WithString("message",
+				mcp.Description("Tag message. Creates an annotated tag"),
+			),
+			mcp.WithString("tagger_name",
+				mcp.Description("Name of the tagger of an annotated tag. Defaults to the authenticated user, and requires tagger_email"),
+			),
+			mcp.WithString("tagger_email",
+				mcp.Description("Email of the tagger of an annotated tag, required with tagger_name"),
+			),
+			mcp.WithString("tagger_date",
+				mcp.Description("Date of an annotated tag in ISO 8601 format, used with tagger_name and tagger_email. Defaults to now"),
+			),
+			WithOutputSchema[CreatedTag](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tag, err := RequiredParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			target, err := RequiredParam[string](request, "target")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := OptionalParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			taggerName, err := OptionalParam[string](request, "tagger_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			taggerEmail, err := OptionalParam[string](request, "tagger_email")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			taggerDate, err := OptionalParam[string](request, "tagger_date")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var tagger *github.CommitAuthor
+			if taggerName != "" || taggerEmail != "" || taggerDate != "" {
+				if message == "" {
+					return mcp.NewToolResultError("tagger_name, tagger_email and tagger_date require a message, as only annotated tags have a tagger"), nil
+				}
+				if taggerName == "" || taggerEmail == "" {
+					return mcp.NewToolResultError("tagger_name and tagger_email are both required to set the tagger"), nil
+				}
+				tagger = &github.CommitAuthor{Name: github.Ptr(taggerName), Email: github.Ptr(taggerEmail)}
+				if taggerDate != "" {
+					date, err := parseISOTimestamp(taggerDate)
+					if err != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("invalid tagger_date: %s", err)), nil
+					}
+					tagger.Date = &github.Timestamp{Time: date}
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Check the tag doesn't exist yet, so no tag object is left behind if it does
+			existing, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/tags/"+tag)
+			if err == nil {
+				_ = resp.Body.Close()
+				return mcp.NewToolResultError(fmt.Sprintf("tag %s already exists at %s, use update_ref with force to move it", tag, existing.GetObject().GetSHA())), nil
+			}
+			if resp == nil || resp.StatusCode != http.StatusNotFound {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get tag reference", resp, err), nil
+			}
+
+			commitSHA, resp, err := client.Repositories.GetCommitSHA1(ctx, owner, repo, target, "")
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to resolve target: %s", target),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			sha := commitSHA
+			if message != "" {
+				tagObj, resp, err := client.Git.CreateTag(ctx, owner, repo, &github.Tag{
+					Tag:     github.Ptr(tag),
+					Message: github.Ptr(message),
+					Tagger:  tagger,
+					Object:  &github.GitObject{SHA: github.Ptr(commitSHA), Type: github.Ptr("commit")},
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to create tag object",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				sha = tagObj.GetSHA()
+			}
+
+			ref, resp, err := client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+				Ref:    github.Ptr("refs/tags/" + tag),
+				Object: &github.GitObject{SHA: github.Ptr(sha)},
+			})
+			if err != nil {
+				return refChangeErrorResponse(ctx, client, owner, repo, "tags/"+tag, "failed to create tag reference", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(CreatedTag{
+				Ref:       ref.GetRef(),
+				SHA:       ref.GetObject().GetSHA(),
+				CommitSHA: commitSHA,
+				Annotated: message != "",
+			}), nil
+		}
+}
+
+// UpdateRef creates a tool to point a branch or tag at another commit.
+func UpdateRef(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_ref",
+			mcp.WithDescription(t("TOOL_UPDATE_REF_DESCRIPTION", "Point a branch or tag of a GitHub repository at another commit. Unless force is set, the new commit must be a descendant of the current one")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_UPDATE_REF_USER_TITLE", "Update git reference"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Reference to update, such as heads/main or tags/v1.0.0"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("Commit SHA to point the reference at"),
+			),
+			mcp.WithBoolean("force",
+				mcp.Description("Allow updates that aren't fast-forwards, discarding the commits only reachable from the current one. Defaults to false"),
+			),
+			mcp.WithString("expected_sha",
+				mcp.Description("SHA the reference must currently point to. If it has moved on, it isn't updated"),
+			),
+			WithOutputSchema[UpdatedRef](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			force, err := OptionalParam[bool](request, "force")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			expectedSHA, err := OptionalParam[string](request, "expected_sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if ref, err = normalizeRef(ref); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			current, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/"+ref)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get reference: %s", ref),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			previousSHA := current.GetObject().GetSHA()
+			if expectedSHA != "" && !strings.EqualFold(previousSHA, expectedSHA) {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is at %s, not at expected_sha %s", ref, previousSHA, expectedSHA)), nil
+			}
+
+			if !force {
+				comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, previousSHA, sha, &github.ListOptions{PerPage: 1})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to compare commits",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				if status := comparison.GetStatus(); status != "ahead" && status != "identical" {
+					return mcp.NewToolResultError(fmt.Sprintf("updating %s from %s to %s is not a fast-forward: %s is %d commits behind it. Set force to discard those commits", ref, previousSHA, sha, sha, comparison.GetBehindBy())), nil
+				}
+			}
+
+			updated, resp, err := client.Git.UpdateRef(ctx, owner, repo, &github.Reference{
+				Ref:    github.Ptr("refs/" + ref),
+				Object: &github.GitObject{SHA: github.Ptr(sha)},
+			}, force)
+			if err != nil {
+				return refChangeErrorResponse(ctx, client, owner, repo, ref, fmt.Sprintf("failed to update reference: %s", ref), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(UpdatedRef{
+				Ref:         updated.GetRef(),
+				SHA:         updated.GetObject().GetSHA(),
+				PreviousSHA: previousSHA,
+				Forced:      force,
+			}), nil
+		}
+}
+
+// DeleteRef creates a tool to delete a branch or tag.
+func DeleteRef(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_ref",
+			mcp.WithDescription(t("TOOL_DELETE_REF_DESCRIPTION", "Delete a branch or tag of a GitHub repository, for example a branch whose pull request was merged")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_REF_USER_TITLE", "Delete git reference"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Reference to delete, such as heads/feature or tags/v1.0.0"),
+			),
+			mcp.WithString("merged_into",
+				mcp.Description("Only delete the reference if all of its commits are in this branch"),
+			),
+			WithOutputSchema[DeletedRef](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			mergedInto, err := OptionalParam[string](request, "merged_into")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if ref, err = normalizeRef(ref); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			current, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/"+ref)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get reference: %s", ref),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			sha := current.GetObject().GetSHA()
+
+			if mergedInto != "" {
+				comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, mergedInto, sha, &github.ListOptions{PerPage: 1})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to compare commits",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				if comparison.GetAheadBy() > 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("%s has %d commits that are not in %s, so it was not deleted", ref, comparison.GetAheadBy(), mergedInto)), nil
+				}
+			}
+
+			resp, err = client.Git.DeleteRef(ctx, owner, repo, "refs/"+ref)
+			if err != nil {
+				return refChangeErrorResponse(ctx, client, owner, repo, ref, fmt.Sprintf("failed to delete reference: %s", ref), resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			return MarshalledTextResult(DeletedRef{
+				Ref:         "refs/" + ref,
+				PreviousSHA: sha,
+			}), nil
+		}
+}
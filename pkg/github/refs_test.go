@@ -0,0 +1,421 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// refHandler serves the refs in refs by path, and 404s for others.
+func refHandler(t *testing.T, refs map[string]string) mock.MockBackendOption {
+	return mock.WithRequestMatchHandler(
+		mock.GetReposGitRefByOwnerByRepoByRef,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ref := r.URL.Path[len("/repos/owner/repo/git/ref/"):]
+			sha, ok := refs[ref]
+			if !ok {
+				mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+				return
+			}
+			mockResponse(t, http.StatusOK, &github.Reference{Ref: github.Ptr("refs/" + ref), Object: &github.GitObject{SHA: github.Ptr(sha)}})(w, r)
+		}),
+	)
+}
+
+func Test_CreateTag(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := CreateTag(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_tag", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint, "create_tag tool should not be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.Contains(t, tool.InputSchema.Properties, "tagger_name")
+	assert.Contains(t, tool.InputSchema.Properties, "tagger_email")
+	assert.Contains(t, tool.InputSchema.Properties, "tagger_date")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag", "target"})
+
+	resolveTarget := mock.WithRequestMatchHandler(
+		mock.GetReposCommitsByOwnerByRepoByRef,
+		expectPath(t, "/repos/owner/repo/commits/main").andThen(
+			func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte("abc123")) },
+		),
+	)
+	createRef := func(sha string) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.PostReposGitRefsByOwnerByRepo,
+			expectRequestBody(t, map[string]any{
+				"ref": "refs/tags/v1.0.0",
+				"sha": sha,
+			}).andThen(
+				mockResponse(t, http.StatusCreated, &github.Reference{Ref: github.Ptr("refs/tags/v1.0.0"), Object: &github.GitObject{SHA: github.Ptr(sha)}}),
+			),
+		)
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedTag    CreatedTag
+	}{
+		{
+			name:         "lightweight tag",
+			mockedClient: mock.NewMockedHTTPClient(refHandler(t, nil), resolveTarget, createRef("abc123")),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"tag":    "v1.0.0",
+				"target": "main",
+			},
+			expectedTag: CreatedTag{Ref: "refs/tags/v1.0.0", SHA: "abc123", CommitSHA: "abc123"},
+		},
+		{
+			name: "annotated tag with tagger",
+			mockedClient: mock.NewMockedHTTPClient(
+				refHandler(t, nil),
+				resolveTarget,
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTagsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"tag":     "v1.0.0",
+						"message": "Release v1.0.0",
+						"object":  "abc123",
+						"type":    "commit",
+						"tagger": map[string]any{
+							"name":  "Release Bot",
+							"email": "bot@example.com",
+							"date":  "2025-01-02T03:04:05Z",
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Tag{SHA: github.Ptr("tag456")}),
+					),
+				),
+				createRef("tag456"),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"tag":          "v1.0.0",
+				"target":       "main",
+				"message":      "Release v1.0.0",
+				"tagger_name":  "Release Bot",
+				"tagger_email": "bot@example.com",
+				"tagger_date":  "2025-01-02T03:04:05Z",
+			},
+			expectedTag: CreatedTag{Ref: "refs/tags/v1.0.0", SHA: "tag456", CommitSHA: "abc123", Annotated: true},
+		},
+		{
+			name:         "tag already exists",
+			mockedClient: mock.NewMockedHTTPClient(refHandler(t, map[string]string{"tags/v1.0.0": "old789"})),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"tag":    "v1.0.0",
+				"target": "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "tag v1.0.0 already exists at old789",
+		},
+		{
+			name:         "tagger without a message",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"tag":         "v1.0.0",
+				"target":      "main",
+				"tagger_name": "Release Bot",
+			},
+			expectError:    true,
+			expectedErrMsg: "only annotated tags have a tagger",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateTag(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var tag CreatedTag
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &tag))
+			assert.Equal(t, tc.expectedTag, tag)
+		})
+	}
+}
+
+func Test_UpdateRef(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := UpdateRef(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_ref", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint, "update_ref tool should be destructive")
+	assert.Contains(t, tool.InputSchema.Properties, "force")
+	assert.Contains(t, tool.InputSchema.Properties, "expected_sha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref", "sha"})
+
+	refs := refHandler(t, map[string]string{"heads/main": "abc123"})
+	compare := func(status string, behindBy int) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.GetReposCompareByOwnerByRepoByBasehead,
+			expectPath(t, "/repos/owner/repo/compare/abc123...def456").andThen(
+				mockResponse(t, http.StatusOK, &github.CommitsComparison{Status: github.Ptr(status), BehindBy: github.Ptr(behindBy)}),
+			),
+		)
+	}
+	update := func(force bool) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.PatchReposGitRefsByOwnerByRepoByRef,
+			expectRequestBody(t, map[string]any{
+				"sha":   "def456",
+				"force": force,
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.Reference{Ref: github.Ptr("refs/heads/main"), Object: &github.GitObject{SHA: github.Ptr("def456")}}),
+			),
+		)
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedRef    UpdatedRef
+	}{
+		{
+			name:         "fast-forward",
+			mockedClient: mock.NewMockedHTTPClient(refs, compare("ahead", 0), update(false)),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"ref":          "refs/heads/main",
+				"sha":          "def456",
+				"expected_sha": "abc123",
+			},
+			expectedRef: UpdatedRef{Ref: "refs/heads/main", SHA: "def456", PreviousSHA: "abc123"},
+		},
+		{
+			name:         "not a fast-forward",
+			mockedClient: mock.NewMockedHTTPClient(refs, compare("diverged", 2)),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "heads/main",
+				"sha":   "def456",
+			},
+			expectError:    true,
+			expectedErrMsg: "updating heads/main from abc123 to def456 is not a fast-forward: def456 is 2 commits behind it",
+		},
+		{
+			name:         "forced",
+			mockedClient: mock.NewMockedHTTPClient(refs, update(true)),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "heads/main",
+				"sha":   "def456",
+				"force": true,
+			},
+			expectedRef: UpdatedRef{Ref: "refs/heads/main", SHA: "def456", PreviousSHA: "abc123", Forced: true},
+		},
+		{
+			name: "protected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				refs,
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Protected branch update failed"}),
+				),
+				mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepoByBranch, &github.Branch{Name: github.Ptr("main"), Protected: github.Ptr(true)}),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "heads/main",
+				"sha":   "def456",
+				"force": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update reference: heads/main: branch main is protected",
+		},
+		{
+			name:         "branch moved on",
+			mockedClient: mock.NewMockedHTTPClient(refs),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"ref":          "heads/main",
+				"sha":          "def456",
+				"expected_sha": "000000",
+			},
+			expectError:    true,
+			expectedErrMsg: "heads/main is at abc123, not at expected_sha 000000",
+		},
+		{
+			name:         "not a branch or tag",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+				"sha":   "def456",
+			},
+			expectError:    true,
+			expectedErrMsg: `ref "main" must be a branch or tag reference`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRef(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var ref UpdatedRef
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &ref))
+			assert.Equal(t, tc.expectedRef, ref)
+		})
+	}
+}
+
+func Test_DeleteRef(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := DeleteRef(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_ref", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint, "delete_ref tool should be destructive")
+	assert.Contains(t, tool.InputSchema.Properties, "merged_into")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	refs := refHandler(t, map[string]string{"heads/feature": "abc123"})
+	compare := func(aheadBy int) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.GetReposCompareByOwnerByRepoByBasehead,
+			expectPath(t, "/repos/owner/repo/compare/main...abc123").andThen(
+				mockResponse(t, http.StatusOK, &github.CommitsComparison{AheadBy: github.Ptr(aheadBy)}),
+			),
+		)
+	}
+	deleted := mock.WithRequestMatchHandler(
+		mock.DeleteReposGitRefsByOwnerByRepoByRef,
+		expectPath(t, "/repos/owner/repo/git/refs/heads/feature").andThen(
+			func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) },
+		),
+	)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name:         "deletes a merged branch",
+			mockedClient: mock.NewMockedHTTPClient(refs, compare(0), deleted),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"ref":         "heads/feature",
+				"merged_into": "main",
+			},
+			expectedText: `{"ref": "refs/heads/feature", "previous_sha": "abc123"}`,
+		},
+		{
+			name:         "keeps an unmerged branch",
+			mockedClient: mock.NewMockedHTTPClient(refs, compare(3)),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"ref":         "heads/feature",
+				"merged_into": "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "heads/feature has 3 commits that are not in main, so it was not deleted",
+		},
+		{
+			name: "protected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				refs,
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Cannot delete this protected branch"}),
+				),
+				mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepoByBranch, &github.Branch{Name: github.Ptr("feature"), Protected: github.Ptr(true)}),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "heads/feature",
+			},
+			expectError:    true,
+			expectedErrMsg: "branch feature is protected",
+		},
+		{
+			name:         "missing ref",
+			mockedClient: mock.NewMockedHTTPClient(refs),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "tags/v0.0.1",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get reference: tags/v0.0.1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteRef(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.JSONEq(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
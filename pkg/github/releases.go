@@ -0,0 +1,757 @@
+package github
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// releaseAssetMaxBytes is the largest release asset GitHub accepts.
+const releaseAssetMaxBytes = 2 << 30
+
+// ReleaseNotes is the name and body GitHub generates for a release.
+type ReleaseNotes struct {
+	Name string `json:"name"`
+	Body string `json:"body"`
+}
+
+// MinimalReleaseAsset is the output type of upload_release_asset.
+type MinimalReleaseAsset struct {
+	ID                 int64  `json:"id"`
+	Name               string `json:"name"`
+	Label              string `json:"label,omitempty"`
+	ContentType        string `json:"content_type"`
+	Size               int    `json:"size"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// DeletedRelease is the output type of delete_release.
+type DeletedRelease struct {
+	ReleaseID int64 `json:"release_id"`
+	// DeletedTag is the tag of the release, when it was deleted as well
+	DeletedTag string `json:"deleted_tag,omitempty"`
+}
+
+// withReleaseParams adds the release fields shared by create_release and update_release.
+func withReleaseParams() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("target_commitish",
+			mcp.Description("Branch or commit SHA the tag is created from if it doesn't exist yet. Defaults to the default branch"),
+		)(tool)
+		mcp.WithString("name",
+			mcp.Description("Release title"),
+		)(tool)
+		mcp.WithString("body",
+			mcp.Description("Release notes in Markdown"),
+		)(tool)
+		mcp.WithBoolean("draft",
+			mcp.Description("Whether the release is an unpublished draft"),
+		)(tool)
+		mcp.WithBoolean("prerelease",
+			mcp.Description("Whether the release is marked as a prerelease"),
+		)(tool)
+		mcp.WithString("make_latest",
+			mcp.Description("Whether the release is marked as the latest release. 'legacy' marks it by creation date and semantic version"),
+			mcp.Enum("true", "false", "legacy"),
+		)(tool)
+		mcp.WithString("discussion_category_name",
+			mcp.Description("Category of a discussion to create for the release"),
+		)(tool)
+	}
+}
+
+// releaseFromParams builds a release from the release fields set in request. It
+// reports whether any were set.
+func releaseFromParams(request mcp.CallToolRequest) (*github.RepositoryRelease, bool, error) {
+	release := &github.RepositoryRelease{}
+	set := false
+	for param, field := range map[string]**string{
+		"target_commitish":         &release.TargetCommitish,
+		"name":                     &release.Name,
+		"body":                     &release.Body,
+		"make_latest":              &release.MakeLatest,
+		"discussion_category_name": &release.DiscussionCategoryName,
+	} {
+		value, ok, err := OptionalParamOK[string](request, param)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			*field = github.Ptr(value)
+			set = true
+		}
+	}
+	for param, field := range map[string]**bool{
+		"draft":      &release.Draft,
+		"prerelease": &release.Prerelease,
+	} {
+		value, ok, err := OptionalParamOK[bool](request, param)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			*field = github.Ptr(value)
+			set = true
+		}
+	}
+	return release, set, nil
+}
+
+// withReleaseIdentifier adds the release_id and tag parameters that pick a release.
+func withReleaseIdentifier() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithNumber("release_id",
+			mcp.Description("ID of the release. Either release_id or tag is required, and drafts can only be found by ID"),
+		)(tool)
+		mcp.WithString("tag",
+			mcp.Description("Tag name of the release (e.g., 'v1.0.0')"),
+		)(tool)
+	}
+}
+
+// getReleaseID returns the ID of the release picked by the release_id or tag parameter.
+func getReleaseID(ctx context.Context, client *github.Client, request mcp.CallToolRequest, owner, repo string) (int64, *mcp.CallToolResult, error) {
+	releaseID, err := OptionalIntParam(request, "release_id")
+	if err != nil {
+		return 0, mcp.NewToolResultError(err.Error()), nil
+	}
+	tag, err := OptionalParam[string](request, "tag")
+	if err != nil {
+		return 0, mcp.NewToolResultError(err.Error()), nil
+	}
+
+	switch {
+	case releaseID != 0 && tag != "":
+		return 0, mcp.NewToolResultError("only one of release_id and tag can be given"), nil
+	case releaseID != 0:
+		return int64(releaseID), nil, nil
+	case tag == "":
+		return 0, mcp.NewToolResultError("either release_id or tag is required"), nil
+	}
+
+	release, resp, err := client.Repositories.GetReleaseByTag(ctx, owner, repo, tag)
+	if err != nil {
+		return 0, ghErrors.NewGitHubAPIErrorResponse(ctx,
+			fmt.Sprintf("failed to get release by tag: %s", tag),
+			resp,
+			err,
+		), nil
+	}
+	_ = resp.Body.Close()
+	return release.GetID(), nil, nil
+}
+
+// CreateRelease creates a tool to create a release in a GitHub repository.
+func CreateRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_release",
+			mcp.WithDescription(t("TOOL_CREATE_RELEASE_DESCRIPTION", "Create a release in a GitHub repository, creating its tag if it doesn't exist")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_RELEASE_USER_TITLE", "Create release"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("tag",
+				mcp.Required(),
+				mcp.Description("Tag name of the release (e.g., 'v1.0.0')"),
+			),
+			withReleaseParams(),
+			mcp.WithBoolean("generate_release_notes",
+				mcp.Description("Generate the name and notes of the release from the changes since the previous release. A body given is prepended to the generated notes"),
+			),
+			WithOutputSchema[MinimalRelease](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tag, err := RequiredParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			release, _, err := releaseFromParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			generateNotes, err := OptionalParam[bool](request, "generate_release_notes")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			release.TagName = github.Ptr(tag)
+			if generateNotes {
+				release.GenerateReleaseNotes = github.Ptr(true)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Repositories.CreateRelease(ctx, owner, repo, release)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create release: %s", tag),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalRelease(created, true)), nil
+		}
+}
+
+// UpdateRelease creates a tool to update a release in a GitHub repository.
+func UpdateRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_release",
+			mcp.WithDescription(t("TOOL_UPDATE_RELEASE_DESCRIPTION", "Update a release in a GitHub repository, for example to publish a draft. Only the fields given are changed")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_RELEASE_USER_TITLE", "Update release"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			withReleaseIdentifier(),
+			mcp.WithString("tag_name",
+				mcp.Description("New tag name of the release"),
+			),
+			withReleaseParams(),
+			WithOutputSchema[MinimalRelease](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			release, set, err := releaseFromParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if tagName, ok, err := OptionalParamOK[string](request, "tag_name"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				release.TagName = github.Ptr(tagName)
+				set = true
+			}
+			if !set {
+				return mcp.NewToolResultError("no release fields to update were given"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			releaseID, errResult, err := getReleaseID(ctx, client, request, owner, repo)
+			if errResult != nil || err != nil {
+				return errResult, err
+			}
+
+			updated, resp, err := client.Repositories.EditRelease(ctx, owner, repo, releaseID, release)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update release: %d", releaseID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalRelease(updated, true)), nil
+		}
+}
+
+// DeleteRelease creates a tool to delete a release in a GitHub repository.
+func DeleteRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_release",
+			mcp.WithDescription(t("TOOL_DELETE_RELEASE_DESCRIPTION", "Delete a release in a GitHub repository, and optionally its tag")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_RELEASE_USER_TITLE", "Delete release"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			withReleaseIdentifier(),
+			mcp.WithBoolean("delete_tag",
+				mcp.Description("Also delete the tag of the release. Defaults to false"),
+			),
+			WithOutputSchema[DeletedRelease](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			deleteTag, err := OptionalParam[bool](request, "delete_tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			releaseID, errResult, err := getReleaseID(ctx, client, request, owner, repo)
+			if errResult != nil || err != nil {
+				return errResult, err
+			}
+
+			// The tag name is needed to delete the tag once the release is gone
+			var tag string
+			if deleteTag {
+				release, resp, err := client.Repositories.GetRelease(ctx, owner, repo, releaseID)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get release: %d", releaseID),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				tag = release.GetTagName()
+			}
+
+			resp, err := client.Repositories.DeleteRelease(ctx, owner, repo, releaseID)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to delete release: %d", releaseID),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			if !deleteTag {
+				return MarshalledTextResult(DeletedRelease{ReleaseID: releaseID}), nil
+			}
+
+			resp, err = client.Git.DeleteRef(ctx, owner, repo, "tags/"+tag)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("release %d deleted, but failed to delete tag: %s", releaseID, tag),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			return MarshalledTextResult(DeletedRelease{ReleaseID: releaseID, DeletedTag: tag}), nil
+		}
+}
+
+// GenerateReleaseNotes creates a tool to generate the notes of a release without creating it.
+func GenerateReleaseNotes(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("generate_release_notes",
+			mcp.WithDescription(t("TOOL_GENERATE_RELEASE_NOTES_DESCRIPTION", "Generate the name and Markdown notes of a release from the pull requests and contributors since the previous release, without creating the release")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GENERATE_RELEASE_NOTES_USER_TITLE", "Generate release notes"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("tag",
+				mcp.Required(),
+				mcp.Description("Tag name of the release, which doesn't need to exist yet"),
+			),
+			mcp.WithString("previous_tag",
+				mcp.Description("Tag of the previous release to list changes from. Defaults to the latest release"),
+			),
+			mcp.WithString("target_commitish",
+				mcp.Description("Branch or commit SHA the tag is created from if it doesn't exist yet. Defaults to the default branch"),
+			),
+			WithOutputSchema[ReleaseNotes](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tag, err := RequiredParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			previousTag, err := OptionalParam[string](request, "previous_tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			targetCommitish, err := OptionalParam[string](request, "target_commitish")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.GenerateNotesOptions{TagName: tag}
+			if previousTag != "" {
+				opts.PreviousTagName = github.Ptr(previousTag)
+			}
+			if targetCommitish != "" {
+				opts.TargetCommitish = github.Ptr(targetCommitish)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			notes, resp, err := client.Repositories.GenerateReleaseNotes(ctx, owner, repo, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to generate release notes: %s", tag),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(ReleaseNotes{Name: notes.Name, Body: notes.Body}), nil
+		}
+}
+
+// UploadReleaseAsset creates a tool to upload an asset to a release in a GitHub repository.
+func UploadReleaseAsset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("upload_release_asset",
+			mcp.WithDescription(t("TOOL_UPLOAD_RELEASE_ASSET_DESCRIPTION", "Upload an asset to a release in a GitHub repository. The content is either given base64 encoded, or taken from a workflow run artifact of the repository: the artifact's ZIP archive, or a single file in it")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPLOAD_RELEASE_ASSET_USER_TITLE", "Upload release asset"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			withReleaseIdentifier(),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("File name of the asset"),
+			),
+			mcp.WithString("label",
+				mcp.Description("Label shown instead of the file name"),
+			),
+			mcp.WithString("content",
+				mcp.Description("Base64 encoded content of the asset. Either content or artifact_id is required"),
+			),
+			mcp.WithNumber("artifact_id",
+				mcp.Description("ID of a workflow run artifact to upload"),
+			),
+			mcp.WithString("artifact_path",
+				mcp.Description("Path of the file in the artifact to upload. If omitted, the artifact's ZIP archive is uploaded"),
+			),
+			mcp.WithString("content_type",
+				mcp.Description("Media type of the asset. Defaults to one based on the file name"),
+			),
+			mcp.WithBoolean("replace",
+				mcp.Description("Replace an existing asset of the same name. The existing asset is only deleted once the new one is uploaded. Defaults to false"),
+			),
+			WithOutputSchema[MinimalReleaseAsset](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			label, err := OptionalParam[string](request, "label")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := OptionalParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			artifactID, err := OptionalIntParam(request, "artifact_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			artifactPath, err := OptionalParam[string](request, "artifact_path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			contentType, err := OptionalParam[string](request, "content_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			replace, err := OptionalParam[bool](request, "replace")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if (content == "") == (artifactID == 0) {
+				return mcp.NewToolResultError("exactly one of content and artifact_id is required"), nil
+			}
+			if artifactPath != "" && artifactID == 0 {
+				return mcp.NewToolResultError("artifact_path requires artifact_id"), nil
+			}
+			if contentType == "" {
+				contentType = mime.TypeByExtension(path.Ext(name))
+			}
+			if contentType == "" {
+				contentType = "application/octet-stream"
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			releaseID, errResult, err := getReleaseID(ctx, client, request, owner, repo)
+			if errResult != nil || err != nil {
+				return errResult, err
+			}
+
+			var body io.Reader
+			var size int64
+			if content != "" {
+				decoded, err := base64.StdEncoding.DecodeString(content)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("content is not valid base64: %s", err)), nil
+				}
+				body, size = bytes.NewReader(decoded), int64(len(decoded))
+			} else {
+				SendProgress(ctx, request, 0, 2, "Downloading artifact")
+				archive, errResult, err := downloadArtifact(ctx, client, owner, repo, int64(artifactID))
+				if errResult != nil || err != nil {
+					return errResult, err
+				}
+				defer func() {
+					_ = archive.Close()
+					_ = os.Remove(archive.Name())
+				}()
+
+				body, size, errResult, err = artifactContent(archive, artifactPath)
+				if errResult != nil || err != nil {
+					return errResult, err
+				}
+				if closer, ok := body.(io.Closer); ok {
+					defer func() { _ = closer.Close() }()
+				}
+			}
+			if size > releaseAssetMaxBytes {
+				return mcp.NewToolResultError(fmt.Sprintf("asset is %d bytes, larger than the %d bytes GitHub accepts", size, int64(releaseAssetMaxBytes))), nil
+			}
+
+			// An existing asset is replaced by uploading the new one under a temporary name, so
+			// that it is kept if the upload fails, and renaming it once the existing one is deleted
+			var existing *github.ReleaseAsset
+			if replace {
+				existing, errResult = findReleaseAssetNamed(ctx, client, owner, repo, releaseID, name)
+				if errResult != nil {
+					return errResult, nil
+				}
+			}
+			uploadName := name
+			if existing != nil {
+				uploadName = fmt.Sprintf("%s.%d.upload", name, existing.GetID())
+			}
+
+			SendProgress(ctx, request, 1, 2, "Uploading asset")
+			query := url.Values{"name": {uploadName}}
+			if label != "" {
+				query.Set("label", label)
+			}
+			req, err := client.NewUploadRequest(fmt.Sprintf("repos/%s/%s/releases/%d/assets?%s", owner, repo, releaseID, query.Encode()), body, size, contentType)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create upload request: %w", err)
+			}
+			asset := new(github.ReleaseAsset)
+			resp, err := client.Do(ctx, req, asset)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to upload release asset: %s", name),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			if existing != nil {
+				resp, err := client.Repositories.DeleteReleaseAsset(ctx, owner, repo, existing.GetID())
+				if err != nil {
+					// Leave the existing asset as the only one of the name
+					if resp, err := client.Repositories.DeleteReleaseAsset(ctx, owner, repo, asset.GetID()); err == nil {
+						_ = resp.Body.Close()
+					}
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to delete release asset: %s", name),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				asset, resp, err = client.Repositories.EditReleaseAsset(ctx, owner, repo, asset.GetID(), &github.ReleaseAsset{Name: github.Ptr(name)})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("release asset uploaded as %s, but failed to rename it to %s", uploadName, name),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+			}
+			SendProgress(ctx, request, 2, 2, "")
+
+			return MarshalledTextResult(MinimalReleaseAsset{
+				ID:                 asset.GetID(),
+				Name:               asset.GetName(),
+				Label:              asset.GetLabel(),
+				ContentType:        asset.GetContentType(),
+				Size:               asset.GetSize(),
+				BrowserDownloadURL: asset.GetBrowserDownloadURL(),
+			}), nil
+		}
+}
+
+// downloadArtifact downloads the ZIP archive of a workflow run artifact to a temporary
+// file, which the caller removes.
+func downloadArtifact(ctx context.Context, client *github.Client, owner, repo string, artifactID int64) (*os.File, *mcp.CallToolResult, error) {
+	downloadURL, resp, err := client.Actions.DownloadArtifact(ctx, owner, repo, artifactID, 1)
+	if err != nil {
+		return nil, ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get artifact download URL", resp, err), nil
+	}
+	_ = resp.Body.Close()
+
+	// The download URL is signed, so it is fetched without the GitHub credentials
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL.String(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	downloadResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, mcp.NewToolResultError(fmt.Sprintf("failed to download artifact %d: %s", artifactID, err)), nil
+	}
+	defer func() { _ = downloadResp.Body.Close() }()
+	if downloadResp.StatusCode != http.StatusOK {
+		return nil, mcp.NewToolResultError(fmt.Sprintf("failed to download artifact %d: %s", artifactID, downloadResp.Status)), nil
+	}
+
+	archive, err := os.CreateTemp("", "github-mcp-server-artifact-*.zip")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	if _, err := io.Copy(archive, io.LimitReader(downloadResp.Body, releaseAssetMaxBytes+1)); err != nil {
+		_ = archive.Close()
+		_ = os.Remove(archive.Name())
+		return nil, mcp.NewToolResultError(fmt.Sprintf("failed to download artifact %d: %s", artifactID, err)), nil
+	}
+	return archive, nil, nil
+}
+
+// artifactContent returns the content of a downloaded artifact archive, or of the file at
+// filePath in it if that is set.
+func artifactContent(archive *os.File, filePath string) (io.Reader, int64, *mcp.CallToolResult, error) {
+	stat, err := archive.Stat()
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to read artifact: %w", err)
+	}
+	if filePath == "" {
+		if _, err := archive.Seek(0, io.SeekStart); err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to read artifact: %w", err)
+		}
+		return archive, stat.Size(), nil, nil
+	}
+
+	zr, err := zip.NewReader(archive, stat.Size())
+	if err != nil {
+		return nil, 0, mcp.NewToolResultError(fmt.Sprintf("artifact is not a valid ZIP archive: %s", err)), nil
+	}
+	for _, f := range zr.File {
+		if f.Name != path.Clean("/" + filePath)[1:] {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, 0, mcp.NewToolResultError(fmt.Sprintf("failed to read %s from artifact: %s", filePath, err)), nil
+		}
+		return rc, int64(f.UncompressedSize64), nil, nil //nolint:gosec // the size is checked against releaseAssetMaxBytes
+	}
+	return nil, 0, mcp.NewToolResultError(fmt.Sprintf("%s not found in artifact", filePath)), nil
+}
+
+// findReleaseAssetNamed returns the asset of a release with the given name, or nil if there is none.
+func findReleaseAssetNamed(ctx context.Context, client *github.Client, owner, repo string, releaseID int64, name string) (*github.ReleaseAsset, *mcp.CallToolResult) {
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		assets, resp, err := client.Repositories.ListReleaseAssets(ctx, owner, repo, releaseID, opts)
+		if err != nil {
+			return nil, ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list release assets", resp, err)
+		}
+		_ = resp.Body.Close()
+
+		for _, asset := range assets {
+			if asset.GetName() == name {
+				return asset, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
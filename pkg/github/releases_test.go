@@ -0,0 +1,540 @@
+package github
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateRelease(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := CreateRelease(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_release", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint, "create_release tool should not be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "tag")
+	assert.Contains(t, tool.InputSchema.Properties, "target_commitish")
+	assert.Contains(t, tool.InputSchema.Properties, "make_latest")
+	assert.Contains(t, tool.InputSchema.Properties, "generate_release_notes")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag"})
+
+	mockRelease := &github.RepositoryRelease{
+		ID:      github.Ptr(int64(1)),
+		TagName: github.Ptr("v1.0.0"),
+		Name:    github.Ptr("v1.0.0"),
+		Body:    github.Ptr("Notes"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/releases/tag/v1.0.0"),
+		Draft:   github.Ptr(true),
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectedErrMsg  string
+		expectedRelease MinimalRelease
+	}{
+		{
+			name: "creates a draft release with generated notes",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"tag_name":               "v1.0.0",
+						"target_commitish":       "main",
+						"draft":                  true,
+						"make_latest":            "false",
+						"generate_release_notes": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRelease),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":                  "owner",
+				"repo":                   "repo",
+				"tag":                    "v1.0.0",
+				"target_commitish":       "main",
+				"draft":                  true,
+				"make_latest":            "false",
+				"generate_release_notes": true,
+			},
+			expectedRelease: convertToMinimalRelease(mockRelease, true),
+		},
+		{
+			name: "tag already has a release",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v1.0.0",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create release: v1.0.0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var release MinimalRelease
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &release))
+			assert.Equal(t, tc.expectedRelease, release)
+		})
+	}
+}
+
+func Test_UpdateRelease(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := UpdateRelease(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_release", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "release_id")
+	assert.Contains(t, tool.InputSchema.Properties, "tag")
+	assert.Contains(t, tool.InputSchema.Properties, "tag_name")
+	assert.Contains(t, tool.InputSchema.Properties, "draft")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockRelease := &github.RepositoryRelease{
+		ID:      github.Ptr(int64(7)),
+		TagName: github.Ptr("v1.0.0"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/releases/tag/v1.0.0"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "publishes a release found by tag, only sending the given fields",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesTagsByOwnerByRepoByTag,
+					expectPath(t, "/repos/owner/repo/releases/tags/v1.0.0").andThen(
+						mockResponse(t, http.StatusOK, mockRelease),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposReleasesByOwnerByRepoByReleaseId,
+					expectPath(t, "/repos/owner/repo/releases/7").andThen(
+						expectRequestBody(t, map[string]any{
+							"draft": false,
+							"body":  "",
+						}).andThen(
+							mockResponse(t, http.StatusOK, mockRelease),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v1.0.0",
+				"draft": false,
+				"body":  "",
+			},
+		},
+		{
+			name:         "nothing to update",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(7),
+			},
+			expectError:    true,
+			expectedErrMsg: "no release fields to update were given",
+		},
+		{
+			name:         "both release_id and tag",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(7),
+				"tag":        "v1.0.0",
+				"name":       "Release",
+			},
+			expectError:    true,
+			expectedErrMsg: "only one of release_id and tag can be given",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var release MinimalRelease
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &release))
+			assert.Equal(t, int64(7), release.ID)
+		})
+	}
+}
+
+func Test_DeleteRelease(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := DeleteRelease(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_release", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint, "delete_release tool should be destructive")
+	assert.Contains(t, tool.InputSchema.Properties, "delete_tag")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	deleted := mock.WithRequestMatchHandler(
+		mock.DeleteReposReleasesByOwnerByRepoByReleaseId,
+		expectPath(t, "/repos/owner/repo/releases/7").andThen(
+			func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) },
+		),
+	)
+
+	tests := []struct {
+		name         string
+		mockedClient *http.Client
+		requestArgs  map[string]any
+		expectedText string
+	}{
+		{
+			name:         "deletes the release",
+			mockedClient: mock.NewMockedHTTPClient(deleted),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(7),
+			},
+			expectedText: `{"release_id": 7}`,
+		},
+		{
+			name: "deletes the release and its tag",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposReleasesByOwnerByRepoByReleaseId, &github.RepositoryRelease{
+					ID:      github.Ptr(int64(7)),
+					TagName: github.Ptr("v1.0.0"),
+				}),
+				deleted,
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/git/refs/tags/v1.0.0").andThen(
+						func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) },
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(7),
+				"delete_tag": true,
+			},
+			expectedText: `{"release_id": 7, "deleted_tag": "v1.0.0"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			assert.JSONEq(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_GenerateReleaseNotes(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GenerateReleaseNotes(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "generate_release_notes", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "generate_release_notes tool should be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "previous_tag")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostReposReleasesGenerateNotesByOwnerByRepo,
+			expectRequestBody(t, map[string]any{
+				"tag_name":          "v1.1.0",
+				"previous_tag_name": "v1.0.0",
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.RepositoryReleaseNotes{Name: "v1.1.0", Body: "## What's Changed"}),
+			),
+		),
+	))
+	_, handler := GenerateReleaseNotes(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":        "owner",
+		"repo":         "repo",
+		"tag":          "v1.1.0",
+		"previous_tag": "v1.0.0",
+	}))
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+	var notes ReleaseNotes
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &notes))
+	assert.Equal(t, ReleaseNotes{Name: "v1.1.0", Body: "## What's Changed"}, notes)
+}
+
+func Test_UploadReleaseAsset(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := UploadReleaseAsset(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "upload_release_asset", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint, "upload_release_asset tool should not be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.Contains(t, tool.InputSchema.Properties, "artifact_id")
+	assert.Contains(t, tool.InputSchema.Properties, "artifact_path")
+	assert.Contains(t, tool.InputSchema.Properties, "replace")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name"})
+
+	var artifact bytes.Buffer
+	zw := zip.NewWriter(&artifact)
+	w, err := zw.Create("dist/app.tar.gz")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("archive"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	artifactServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(artifact.Bytes())
+	}))
+	defer artifactServer.Close()
+
+	artifactDownload := mock.WithRequestMatchHandler(
+		mock.GetReposActionsArtifactsByOwnerByRepoByArtifactIdByArchiveFormat,
+		expectPath(t, "/repos/owner/repo/actions/artifacts/42/zip").andThen(
+			func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Location", artifactServer.URL+"/artifact.zip")
+				w.WriteHeader(http.StatusFound)
+			},
+		),
+	)
+	upload := func(name, contentType, content string) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.PostReposReleasesAssetsByOwnerByRepoByReleaseId,
+			expectPath(t, "/repos/owner/repo/releases/7/assets").andThen(
+				func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, name, r.URL.Query().Get("name"))
+					assert.Equal(t, contentType, r.Header.Get("Content-Type"))
+					body, err := io.ReadAll(r.Body)
+					require.NoError(t, err)
+					assert.Equal(t, content, string(body))
+					mockResponse(t, http.StatusCreated, &github.ReleaseAsset{
+						ID:                 github.Ptr(int64(3)),
+						Name:               github.Ptr(name),
+						ContentType:        github.Ptr(contentType),
+						Size:               github.Ptr(len(body)),
+						BrowserDownloadURL: github.Ptr("https://github.com/owner/repo/releases/download/v1.0.0/" + name),
+					})(w, r)
+				},
+			),
+		)
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedAsset  MinimalReleaseAsset
+	}{
+		{
+			name: "uploads base64 content, replacing the existing asset",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposReleasesAssetsByOwnerByRepoByReleaseId, []*github.ReleaseAsset{
+					{ID: github.Ptr(int64(1)), Name: github.Ptr("other.txt")},
+					{ID: github.Ptr(int64(2)), Name: github.Ptr("checksums.txt")},
+				}),
+				upload("checksums.txt.2.upload", "text/plain; charset=utf-8", "abc  app.tar.gz\n"),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposReleasesAssetsByOwnerByRepoByAssetId,
+					expectPath(t, "/repos/owner/repo/releases/assets/2").andThen(
+						func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) },
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposReleasesAssetsByOwnerByRepoByAssetId,
+					expectPath(t, "/repos/owner/repo/releases/assets/3").andThen(
+						expectRequestBody(t, map[string]any{"name": "checksums.txt"}).andThen(
+							mockResponse(t, http.StatusOK, &github.ReleaseAsset{
+								ID:                 github.Ptr(int64(3)),
+								Name:               github.Ptr("checksums.txt"),
+								ContentType:        github.Ptr("text/plain; charset=utf-8"),
+								Size:               github.Ptr(16),
+								BrowserDownloadURL: github.Ptr("https://github.com/owner/repo/releases/download/v1.0.0/checksums.txt"),
+							}),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(7),
+				"name":       "checksums.txt",
+				"content":    "YWJjICBhcHAudGFyLmd6Cg==",
+				"replace":    true,
+			},
+			expectedAsset: MinimalReleaseAsset{
+				ID:                 3,
+				Name:               "checksums.txt",
+				ContentType:        "text/plain; charset=utf-8",
+				Size:               16,
+				BrowserDownloadURL: "https://github.com/owner/repo/releases/download/v1.0.0/checksums.txt",
+			},
+		},
+		{
+			name: "failed upload keeps the existing asset",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposReleasesAssetsByOwnerByRepoByReleaseId, []*github.ReleaseAsset{
+					{ID: github.Ptr(int64(2)), Name: github.Ptr("checksums.txt")},
+				}),
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesAssetsByOwnerByRepoByReleaseId,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposReleasesAssetsByOwnerByRepoByAssetId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						t.Error("the existing asset was deleted")
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(7),
+				"name":       "checksums.txt",
+				"content":    "YWJjICBhcHAudGFyLmd6Cg==",
+				"replace":    true,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to upload release asset: checksums.txt",
+		},
+		{
+			name: "uploads a file from a workflow artifact",
+			mockedClient: mock.NewMockedHTTPClient(
+				artifactDownload,
+				upload("app.tar.gz", "application/gzip", "archive"),
+			),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"release_id":    float64(7),
+				"name":          "app.tar.gz",
+				"artifact_id":   float64(42),
+				"artifact_path": "dist/app.tar.gz",
+				"content_type":  "application/gzip",
+			},
+			expectedAsset: MinimalReleaseAsset{
+				ID:                 3,
+				Name:               "app.tar.gz",
+				ContentType:        "application/gzip",
+				Size:               7,
+				BrowserDownloadURL: "https://github.com/owner/repo/releases/download/v1.0.0/app.tar.gz",
+			},
+		},
+		{
+			name:         "file missing from the artifact",
+			mockedClient: mock.NewMockedHTTPClient(artifactDownload),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"release_id":    float64(7),
+				"name":          "app.zip",
+				"artifact_id":   float64(42),
+				"artifact_path": "dist/app.zip",
+			},
+			expectError:    true,
+			expectedErrMsg: "dist/app.zip not found in artifact",
+		},
+		{
+			name:         "no content",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(7),
+				"name":       "app.zip",
+			},
+			expectError:    true,
+			expectedErrMsg: "exactly one of content and artifact_id is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UploadReleaseAsset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var asset MinimalReleaseAsset
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &asset))
+			assert.Equal(t, tc.expectedAsset, asset)
+		})
+	}
+}
@@ -0,0 +1,409 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// remotePreference is the order in which git remotes are considered when inferring the
+// repository. It mirrors the GitHub CLI, which prefers the upstream of a fork over the fork itself.
+var remotePreference = []string{"upstream", "github", "origin"}
+
+// RepoContext describes a GitHub repository inferred from the git remote of a workspace root.
+type RepoContext struct {
+	Owner     string `json:"owner"`
+	Repo      string `json:"repo"`
+	Remote    string `json:"remote"`
+	RemoteURL string `json:"remote_url"`
+	Root      string `json:"root"`
+}
+
+// RootsFn returns the workspace roots, either as local paths or file:// URIs,
+// that are searched for a git repository.
+type RootsFn func(context.Context) ([]string, error)
+
+// RepoContextResolver infers the default owner and repo from the git remotes of the workspace roots.
+type RepoContextResolver struct {
+	host     string
+	getRoots RootsFn
+}
+
+// NewRepoContextResolver creates a resolver that only considers remotes pointing at the given GitHub hostname.
+// An empty host defaults to github.com.
+func NewRepoContextResolver(host string, getRoots RootsFn) *RepoContextResolver {
+	if host == "" {
+		host = "github.com"
+	}
+	return &RepoContextResolver{
+		host:     strings.ToLower(host),
+		getRoots: getRoots,
+	}
+}
+
+// Roots returns the workspace roots known to the resolver as local paths.
+func (r *RepoContextResolver) Roots(ctx context.Context) ([]string, error) {
+	if r == nil || r.getRoots == nil {
+		return nil, nil
+	}
+	roots, err := r.getRoots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(roots))
+	for _, root := range roots {
+		if p := rootPath(root); p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
+
+// Resolve returns the repository of the first workspace root whose git remote points at the configured host.
+// It returns nil without an error when no repository can be inferred.
+func (r *RepoContextResolver) Resolve(ctx context.Context) (*RepoContext, error) {
+	roots, err := r.Roots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace roots: %w", err)
+	}
+	for _, root := range roots {
+		repoCtx, err := r.resolveRoot(root)
+		if err != nil {
+			return nil, err
+		}
+		if repoCtx != nil {
+			return repoCtx, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *RepoContextResolver) resolveRoot(root string) (*RepoContext, error) {
+	gitDir, err := findGitDir(root)
+	if err != nil || gitDir == "" {
+		return nil, err
+	}
+
+	config, err := os.ReadFile(filepath.Join(gitDir, "config")) //nolint:gosec // the path is derived from the workspace root shared by the client
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read git config: %w", err)
+	}
+
+	remotes := parseGitRemotes(config)
+	for _, name := range orderRemotes(remotes) {
+		owner, repo, ok := parseGitHubRemoteURL(remotes[name], r.host)
+		if !ok {
+			continue
+		}
+		return &RepoContext{
+			Owner:     owner,
+			Repo:      repo,
+			Remote:    name,
+			RemoteURL: remotes[name],
+			Root:      root,
+		}, nil
+	}
+	return nil, nil
+}
+
+// rootPath converts a root, which may be a file:// URI, to a local path.
+func rootPath(root string) string {
+	if !strings.HasPrefix(root, "file://") {
+		return root
+	}
+	u, err := url.Parse(root)
+	if err != nil {
+		return ""
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+// findGitDir walks up from dir until it finds a .git directory, following the
+// "gitdir:" indirection used by worktrees and submodules. It returns an empty string when dir is not inside a repository.
+func findGitDir(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve workspace root: %w", err)
+	}
+
+	for {
+		candidate := filepath.Join(dir, ".git")
+		info, err := os.Stat(candidate)
+		if err == nil {
+			if info.IsDir() {
+				return candidate, nil
+			}
+			return readGitDirFile(candidate)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+func readGitDirFile(path string) (string, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // the path is derived from the workspace root shared by the client
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return "", nil
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(path), gitDir)
+	}
+
+	// Worktrees keep their remotes in the config of the main repository
+	if commonDir, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil { //nolint:gosec // see above
+		common := strings.TrimSpace(string(commonDir))
+		if !filepath.IsAbs(common) {
+			common = filepath.Join(gitDir, common)
+		}
+		return filepath.Clean(common), nil
+	}
+	return gitDir, nil
+}
+
+// parseGitRemotes returns the url of every [remote "name"] section in a git config file.
+func parseGitRemotes(config []byte) map[string]string {
+	remotes := make(map[string]string)
+	current := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(config))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		if line[0] == '[' {
+			current = ""
+			section := strings.TrimSuffix(strings.TrimPrefix(line, "["), "]")
+			if name, ok := strings.CutPrefix(section, "remote "); ok {
+				current = strings.Trim(strings.TrimSpace(name), `"`)
+			}
+			continue
+		}
+
+		if current == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), "url") {
+			continue
+		}
+		// The first url of a remote is the one git fetches from
+		if _, exists := remotes[current]; !exists {
+			remotes[current] = strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+	return remotes
+}
+
+// orderRemotes returns the remote names in remotePreference order followed by the rest alphabetically.
+func orderRemotes(remotes map[string]string) []string {
+	ordered := make([]string, 0, len(remotes))
+	for _, name := range remotePreference {
+		if _, ok := remotes[name]; ok {
+			ordered = append(ordered, name)
+		}
+	}
+
+	var rest []string
+	for name := range remotes {
+		isPreferred := false
+		for _, preferred := range remotePreference {
+			if name == preferred {
+				isPreferred = true
+				break
+			}
+		}
+		if !isPreferred {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(ordered, rest...)
+}
+
+// parseGitHubRemoteURL extracts the owner and repo from a git remote URL if it points at host.
+// Supported forms are https://host/owner/repo(.git), ssh://git@host[:port]/owner/repo(.git)
+// and the scp-like git@host:owner/repo(.git).
+func parseGitHubRemoteURL(remoteURL, host string) (owner, repo string, ok bool) {
+	var hostname, path string
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return "", "", false
+		}
+		hostname, path = u.Hostname(), u.Path
+	} else {
+		// scp-like syntax: [user@]host:path
+		hostPart, pathPart, found := strings.Cut(remoteURL, ":")
+		if !found {
+			return "", "", false
+		}
+		if i := strings.LastIndex(hostPart, "@"); i >= 0 {
+			hostPart = hostPart[i+1:]
+		}
+		hostname, path = hostPart, pathPart
+	}
+
+	if !strings.EqualFold(hostname, host) {
+		return "", "", false
+	}
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], strings.TrimSuffix(parts[1], ".git"), true
+}
+
+// isRepoScoped reports whether the tool accepts both an owner and a repo argument.
+func isRepoScoped(tool mcp.Tool) bool {
+	_, hasOwner := tool.InputSchema.Properties["owner"]
+	_, hasRepo := tool.InputSchema.Properties["repo"]
+	return hasOwner && hasRepo
+}
+
+// relaxRepoParams makes owner and repo optional on a repository-scoped tool, since they can be inferred.
+func relaxRepoParams(tool mcp.Tool) mcp.Tool {
+	required := make([]string, 0, len(tool.InputSchema.Required))
+	for _, name := range tool.InputSchema.Required {
+		if name != "owner" && name != "repo" {
+			required = append(required, name)
+		}
+	}
+
+	properties := make(map[string]any, len(tool.InputSchema.Properties))
+	for name, prop := range tool.InputSchema.Properties {
+		properties[name] = prop
+		if name != "owner" && name != "repo" {
+			continue
+		}
+		schema, ok := prop.(map[string]any)
+		if !ok {
+			continue
+		}
+		relaxed := make(map[string]any, len(schema))
+		for k, v := range schema {
+			relaxed[k] = v
+		}
+		description, _ := relaxed["description"].(string)
+		relaxed["description"] = strings.TrimSpace(description + " Defaults to the repository of the current workspace when omitted.")
+		properties[name] = relaxed
+	}
+
+	tool.InputSchema.Required = required
+	tool.InputSchema.Properties = properties
+	return tool
+}
+
+// EnableRepoInference makes owner and repo optional on every repository-scoped tool in the group and
+// returns a middleware that fills in the omitted arguments from the repository inferred by resolver.
+// Tools still report a missing required parameter when nothing can be inferred.
+func EnableRepoInference(tsg *toolsets.ToolsetGroup, resolver *RepoContextResolver) server.ToolHandlerMiddleware {
+	repoScoped := make(map[string]bool)
+	tsg.UpdateTools(func(tool server.ServerTool) server.ServerTool {
+		if !isRepoScoped(tool.Tool) {
+			return tool
+		}
+		repoScoped[tool.Tool.Name] = true
+		tool.Tool = relaxRepoParams(tool.Tool)
+		return tool
+	})
+
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if !repoScoped[request.Params.Name] {
+				return next(ctx, request)
+			}
+
+			args := request.GetArguments()
+			owner, _ := args["owner"].(string)
+			repo, _ := args["repo"].(string)
+			if owner != "" && repo != "" {
+				return next(ctx, request)
+			}
+
+			repoCtx, err := resolver.Resolve(ctx)
+			if err != nil || repoCtx == nil {
+				// Let the tool report the missing parameter
+				return next(ctx, request)
+			}
+
+			filled := make(map[string]any, len(args)+2)
+			for k, v := range args {
+				filled[k] = v
+			}
+			if owner == "" {
+				filled["owner"] = repoCtx.Owner
+			}
+			// Only default the repo when it belongs to the owner being targeted
+			if repo == "" && (owner == "" || strings.EqualFold(owner, repoCtx.Owner)) {
+				filled["repo"] = repoCtx.Repo
+			}
+			request.Params.Arguments = filled
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// WorkspaceContext is the result of the get_context tool.
+type WorkspaceContext struct {
+	Roots      []string     `json:"roots"`
+	Repository *RepoContext `json:"repository"`
+}
+
+// GetContext creates a tool that reports the repository inferred from the workspace.
+func GetContext(resolver *RepoContextResolver, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_context",
+			mcp.WithDescription(t("TOOL_GET_CONTEXT_DESCRIPTION", "Get the GitHub repository inferred from the git remote of the current workspace. Tools that take owner and repo can use it as their default when those are omitted.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CONTEXT_USER_TITLE", "Get workspace context"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithOutputSchema[WorkspaceContext](),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			roots, err := resolver.Roots(ctx)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("failed to get workspace roots", err), nil
+			}
+
+			repoCtx, err := resolver.Resolve(ctx)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("failed to infer repository", err), nil
+			}
+
+			if roots == nil {
+				roots = []string{}
+			}
+			return MarshalledTextResult(WorkspaceContext{
+				Roots:      roots,
+				Repository: repoCtx,
+			}), nil
+		}
+}
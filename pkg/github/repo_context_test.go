@@ -0,0 +1,332 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeGitConfig creates a fake repository in dir with the given git config contents.
+func writeGitConfig(t *testing.T, dir, config string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".git", "config"), []byte(config), 0o600))
+}
+
+func staticRoots(roots ...string) RootsFn {
+	return func(_ context.Context) ([]string, error) {
+		return roots, nil
+	}
+}
+
+func Test_ParseGitHubRemoteURL(t *testing.T) {
+	tests := []struct {
+		name          string
+		remoteURL     string
+		host          string
+		expectedOwner string
+		expectedRepo  string
+		expectedOK    bool
+	}{
+		{
+			name:          "https url",
+			remoteURL:     "https://github.com/octocat/hello-world.git",
+			host:          "github.com",
+			expectedOwner: "octocat",
+			expectedRepo:  "hello-world",
+			expectedOK:    true,
+		},
+		{
+			name:          "https url without .git suffix",
+			remoteURL:     "https://github.com/octocat/hello-world",
+			host:          "github.com",
+			expectedOwner: "octocat",
+			expectedRepo:  "hello-world",
+			expectedOK:    true,
+		},
+		{
+			name:          "scp-like ssh url",
+			remoteURL:     "git@github.com:octocat/hello-world.git",
+			host:          "github.com",
+			expectedOwner: "octocat",
+			expectedRepo:  "hello-world",
+			expectedOK:    true,
+		},
+		{
+			name:          "ssh url with port",
+			remoteURL:     "ssh://git@ghes.example.com:2222/octocat/hello-world.git",
+			host:          "ghes.example.com",
+			expectedOwner: "octocat",
+			expectedRepo:  "hello-world",
+			expectedOK:    true,
+		},
+		{
+			name:       "different host",
+			remoteURL:  "https://gitlab.com/octocat/hello-world.git",
+			host:       "github.com",
+			expectedOK: false,
+		},
+		{
+			name:       "not a repository path",
+			remoteURL:  "https://github.com/octocat",
+			host:       "github.com",
+			expectedOK: false,
+		},
+		{
+			name:       "local path",
+			remoteURL:  "/srv/git/hello-world.git",
+			host:       "github.com",
+			expectedOK: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			owner, repo, ok := parseGitHubRemoteURL(tc.remoteURL, tc.host)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedOwner, owner)
+			assert.Equal(t, tc.expectedRepo, repo)
+		})
+	}
+}
+
+func Test_RepoContextResolver_Resolve(t *testing.T) {
+	t.Run("prefers upstream over origin", func(t *testing.T) {
+		dir := t.TempDir()
+		writeGitConfig(t, dir, `[core]
+	bare = false
+[remote "origin"]
+	url = git@github.com:me/hello-world.git
+	fetch = +refs/heads/*:refs/remotes/origin/*
+[remote "upstream"]
+	url = https://github.com/octocat/hello-world.git
+`)
+
+		resolver := NewRepoContextResolver("github.com", staticRoots(dir))
+		repoCtx, err := resolver.Resolve(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, repoCtx)
+		assert.Equal(t, "octocat", repoCtx.Owner)
+		assert.Equal(t, "hello-world", repoCtx.Repo)
+		assert.Equal(t, "upstream", repoCtx.Remote)
+		assert.Equal(t, dir, repoCtx.Root)
+	})
+
+	t.Run("skips remotes on other hosts", func(t *testing.T) {
+		dir := t.TempDir()
+		writeGitConfig(t, dir, `[remote "origin"]
+	url = https://gitlab.com/octocat/hello-world.git
+[remote "mirror"]
+	url = https://ghes.example.com/octocat/hello-world.git
+`)
+
+		resolver := NewRepoContextResolver("ghes.example.com", staticRoots(dir))
+		repoCtx, err := resolver.Resolve(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, repoCtx)
+		assert.Equal(t, "mirror", repoCtx.Remote)
+	})
+
+	t.Run("finds the repository from a subdirectory given as file uri", func(t *testing.T) {
+		dir := t.TempDir()
+		writeGitConfig(t, dir, `[remote "origin"]
+	url = https://github.com/octocat/hello-world
+`)
+		subdir := filepath.Join(dir, "pkg", "sub")
+		require.NoError(t, os.MkdirAll(subdir, 0o755))
+
+		resolver := NewRepoContextResolver("", staticRoots("file://"+filepath.ToSlash(subdir)))
+		repoCtx, err := resolver.Resolve(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, repoCtx)
+		assert.Equal(t, "octocat", repoCtx.Owner)
+	})
+
+	t.Run("follows gitdir files of worktrees", func(t *testing.T) {
+		main := t.TempDir()
+		writeGitConfig(t, main, `[remote "origin"]
+	url = https://github.com/octocat/hello-world.git
+`)
+		worktreeGitDir := filepath.Join(main, ".git", "worktrees", "feature")
+		require.NoError(t, os.MkdirAll(worktreeGitDir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(worktreeGitDir, "commondir"), []byte("../..\n"), 0o600))
+
+		worktree := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(worktree, ".git"), []byte("gitdir: "+worktreeGitDir+"\n"), 0o600))
+
+		resolver := NewRepoContextResolver("github.com", staticRoots(worktree))
+		repoCtx, err := resolver.Resolve(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, repoCtx)
+		assert.Equal(t, "hello-world", repoCtx.Repo)
+	})
+
+	t.Run("returns nil outside of a repository", func(t *testing.T) {
+		resolver := NewRepoContextResolver("github.com", staticRoots(t.TempDir()))
+		repoCtx, err := resolver.Resolve(context.Background())
+		require.NoError(t, err)
+		assert.Nil(t, repoCtx)
+	})
+}
+
+func Test_EnableRepoInference(t *testing.T) {
+	dir := t.TempDir()
+	writeGitConfig(t, dir, `[remote "origin"]
+	url = https://github.com/octocat/hello-world.git
+`)
+	resolver := NewRepoContextResolver("github.com", staticRoots(dir))
+
+	var received map[string]any
+	repoTool := mcp.NewTool("repo_tool",
+		mcp.WithString("owner", mcp.Required(), mcp.Description("Repository owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name")),
+		mcp.WithNumber("issue_number", mcp.Required()),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)}),
+	)
+	userTool := mcp.NewTool("user_tool",
+		mcp.WithString("owner", mcp.Required()),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)}),
+	)
+	handler := func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		received = request.GetArguments()
+		return mcp.NewToolResultText("ok"), nil
+	}
+
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(toolsets.NewToolset("test", "test").AddReadTools(
+		toolsets.NewServerTool(repoTool, handler),
+		toolsets.NewServerTool(userTool, handler),
+	))
+	middleware := EnableRepoInference(tsg, resolver)
+
+	// Verify the schema of repository-scoped tools is relaxed
+	tools := tsg.Toolsets["test"].GetAvailableTools()
+	assert.Equal(t, []string{"issue_number"}, tools[0].Tool.InputSchema.Required)
+	assert.Contains(t, tools[0].Tool.InputSchema.Properties["owner"].(map[string]any)["description"], "Defaults to the repository of the current workspace")
+	assert.Equal(t, []string{"owner"}, tools[1].Tool.InputSchema.Required)
+
+	tests := []struct {
+		name         string
+		toolName     string
+		args         map[string]any
+		expectedArgs map[string]any
+	}{
+		{
+			name:         "fills omitted owner and repo",
+			toolName:     "repo_tool",
+			args:         map[string]any{"issue_number": float64(1)},
+			expectedArgs: map[string]any{"owner": "octocat", "repo": "hello-world", "issue_number": float64(1)},
+		},
+		{
+			name:         "keeps explicit arguments",
+			toolName:     "repo_tool",
+			args:         map[string]any{"owner": "other", "repo": "project"},
+			expectedArgs: map[string]any{"owner": "other", "repo": "project"},
+		},
+		{
+			name:         "does not pair the inferred repo with another owner",
+			toolName:     "repo_tool",
+			args:         map[string]any{"owner": "other"},
+			expectedArgs: map[string]any{"owner": "other"},
+		},
+		{
+			name:         "ignores tools that are not repository-scoped",
+			toolName:     "user_tool",
+			args:         map[string]any{},
+			expectedArgs: map[string]any{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := createMCPRequest(tc.args)
+			request.Params.Name = tc.toolName
+
+			var next server.ToolHandlerFunc = handler
+			_, err := middleware(next)(context.Background(), request)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedArgs, received)
+		})
+	}
+}
+
+func Test_GetContextRegisteredWithRepoInference(t *testing.T) {
+	hasGetContext := func(resolver *RepoContextResolver) bool {
+		tsg := DefaultToolsetGroup(false, nil, nil, nil, nil, translations.NullTranslationHelper, 5000, resolver, nil)
+		for _, tool := range tsg.Toolsets["context"].GetAvailableTools() {
+			if tool.Tool.Name == "get_context" {
+				return true
+			}
+		}
+		return false
+	}
+
+	assert.False(t, hasGetContext(nil), "get_context should not be registered without repo inference")
+	assert.True(t, hasGetContext(NewRepoContextResolver("", nil)), "get_context should be registered with repo inference")
+}
+
+func Test_GetContext(t *testing.T) {
+	tool, _ := GetContext(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_context", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "get_context tool should be read-only")
+
+	dir := t.TempDir()
+	writeGitConfig(t, dir, `[remote "origin"]
+	url = git@github.com:octocat/hello-world.git
+`)
+
+	tests := []struct {
+		name         string
+		resolver     *RepoContextResolver
+		expectedRepo *RepoContext
+	}{
+		{
+			name:     "repository inferred",
+			resolver: NewRepoContextResolver("github.com", staticRoots(dir)),
+			expectedRepo: &RepoContext{
+				Owner:     "octocat",
+				Repo:      "hello-world",
+				Remote:    "origin",
+				RemoteURL: "git@github.com:octocat/hello-world.git",
+				Root:      dir,
+			},
+		},
+		{
+			name:         "nothing inferred",
+			resolver:     NewRepoContextResolver("github.com", staticRoots(t.TempDir())),
+			expectedRepo: nil,
+		},
+		{
+			name:         "no resolver",
+			resolver:     nil,
+			expectedRepo: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetContext(tc.resolver, translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned WorkspaceContext
+			textContent := getTextResult(t, result)
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedRepo, returned.Repository)
+		})
+	}
+}
@@ -7,8 +7,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
 	"strings"
+	"sync"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/raw"
@@ -42,6 +42,7 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				mcp.DefaultBool(true),
 			),
 			WithPagination(),
+			WithOutputSchema[MinimalCommit](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -104,6 +105,106 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 		}
 }
 
+// CompareRefs creates a tool to compare two commits, branches or tags of a repository.
+func CompareRefs(getClient GetClientFn, t translations.TranslationHelperFunc, contentWindowSize int) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("compare_refs",
+			mcp.WithDescription(t("TOOL_COMPARE_REFS_DESCRIPTION", "Compare two commits, branches or tags of a GitHub repository. Returns how far head is ahead of and behind base, the commits in head that aren't in base and the files changed, with stats. Lists at most 250 commits per page; total_commits has the full count.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_COMPARE_REFS_USER_TITLE", "Compare refs"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("base",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch or tag name to compare from, e.g. release/1.4"),
+			),
+			mcp.WithString("head",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch or tag name to compare to, e.g. main. Use owner:branch to compare with a branch of a fork"),
+			),
+			mcp.WithBoolean("include_patch",
+				mcp.Description(fmt.Sprintf("Whether to include the patch of each file, cut to %d lines. Default is false.", contentWindowSize)),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithNumber("page",
+				mcp.Description("Page of commits (min 1)"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("perPage",
+				mcp.Description("Commits per page (min 1, max 250)"),
+				mcp.Min(1),
+				mcp.Max(250),
+			),
+			WithOutputSchema[MinimalComparison](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := RequiredParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			head, err := RequiredParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includePatch, err := OptionalBoolParamWithDefault(request, "include_patch", false)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			page, err := OptionalIntParam(request, "page")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			perPage, err := OptionalIntParam(request, "perPage")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListOptions{
+				Page:    page,
+				PerPage: perPage,
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to compare %s...%s", base, head),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to compare refs: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(convertToMinimalComparison(comparison, includePatch, contentWindowSize)), nil
+		}
+}
+
 // ListCommits creates a tool to get commits of a branch in a repository.
 func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_commits",
@@ -127,6 +228,7 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				mcp.Description("Author username or email address to filter commits by"),
 			),
 			WithPagination(),
+			WithOutputSchema[ListResult[MinimalCommit]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -191,7 +293,7 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				minimalCommits[i] = convertToMinimalCommit(commit, false)
 			}
 
-			r, err := json.Marshal(minimalCommits)
+			r, err := json.Marshal(restListResult(minimalCommits, resp))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -217,6 +319,7 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				mcp.Description("Repository name"),
 			),
 			WithPagination(),
+			WithOutputSchema[ListResult[MinimalBranch]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -268,7 +371,7 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				minimalBranches = append(minimalBranches, convertToMinimalBranch(branch))
 			}
 
-			r, err := json.Marshal(minimalBranches)
+			r, err := json.Marshal(restListResult(minimalBranches, resp))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -312,6 +415,7 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 			mcp.WithString("sha",
 				mcp.Description("Required if updating an existing file. The blob SHA of the file being replaced."),
 			),
+			WithOutputSchema[*github.RepositoryContentResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -414,6 +518,7 @@ func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFun
 			mcp.WithBoolean("autoInit",
 				mcp.Description("Initialize with README"),
 			),
+			WithOutputSchema[MinimalResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			name, err := RequiredParam[string](request, "name")
@@ -507,6 +612,26 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 			mcp.WithString("sha",
 				mcp.Description("Accepts optional commit SHA. If specified, it will be used instead of ref"),
 			),
+			mcp.WithNumber("start_line",
+				mcp.Description("First line of a text file to return (min 1)"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("end_line",
+				mcp.Description("Last line of a text file to return (min 1)"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("max_bytes",
+				mcp.Description("Maximum number of bytes of a text file to return, cut at a line boundary"),
+				mcp.Min(1),
+			),
+			WithRawOutputSchema(OutputSchemaAnyOf(
+				OutputSchema[EmbeddedResourceResult](),
+				OutputSchema[[]*github.RepositoryContent](),
+				OutputSchema[SubmoduleFile](),
+				OutputSchema[SymlinkFile](),
+				OutputSchema[PathMatches](),
+				OutputSchema[MessageResponse](),
+			)),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -529,6 +654,21 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			startLine, err := OptionalIntParam(request, "start_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			endLine, err := OptionalIntParam(request, "end_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if startLine > 0 && endLine > 0 && endLine < startLine {
+				return mcp.NewToolResultError("end_line must not be before start_line"), nil
+			}
+			maxBytes, err := OptionalIntParam(request, "max_bytes")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -545,7 +685,10 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 			if path != "" && !strings.HasSuffix(path, "/") {
 				// First, get file info from Contents API to retrieve SHA
 				var fileSHA string
-				opts := &github.RepositoryContentGetOptions{Ref: ref}
+				opts := &github.RepositoryContentGetOptions{Ref: rawOpts.Ref}
+				if rawOpts.SHA != "" {
+					opts.Ref = rawOpts.SHA
+				}
 				fileContent, _, respContents, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
 				if respContents != nil {
 					defer func() { _ = respContents.Body.Close() }()
@@ -562,6 +705,26 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 				}
 				fileSHA = *fileContent.SHA
 
+				switch {
+				case fileContent.GetType() == "submodule":
+					return MarshalledTextResult(newSubmoduleFile(path, fileContent)), nil
+				case fileContent.GetType() == "symlink":
+					return MarshalledTextResult(SymlinkFile{Type: "symlink", Path: path, SHA: fileSHA, Target: fileContent.GetTarget()}), nil
+				case fileContent.GetPath() != "" && fileContent.GetPath() != strings.Trim(path, "/"):
+					// The Contents API returns the target of symlinks to normal files
+					return MarshalledTextResult(SymlinkFile{Type: "symlink", Path: strings.Trim(path, "/"), Target: fileContent.GetPath()}), nil
+				}
+
+				resourceURI, err := fileResourceURI(owner, repo, ref, sha, path)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create resource URI: %w", err)
+				}
+				lines := fileRange{startLine: startLine, endLine: endLine, maxBytes: maxBytes}
+
+				if fileContent.GetSize() > maxContentsAPIFileSize {
+					return getLargeFileContents(ctx, client, owner, repo, fileSHA, fileContent.GetSize(), resourceURI, lines)
+				}
+
 				rawClient, err := getRawClient(ctx)
 				if err != nil {
 					return mcp.NewToolResultError("failed to get GitHub raw content client"), nil
@@ -582,36 +745,25 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 					}
 					contentType := resp.Header.Get("Content-Type")
 
-					var resourceURI string
-					switch {
-					case sha != "":
-						resourceURI, err = url.JoinPath("repo://", owner, repo, "sha", sha, "contents", path)
-						if err != nil {
-							return nil, fmt.Errorf("failed to create resource URI: %w", err)
-						}
-					case ref != "":
-						resourceURI, err = url.JoinPath("repo://", owner, repo, ref, "contents", path)
-						if err != nil {
-							return nil, fmt.Errorf("failed to create resource URI: %w", err)
+					if isTextContentType(contentType) {
+						if pointer, ok := parseLFSPointer(body); ok {
+							return getLFSFileContents(ctx, client, owner, repo, pointer, resourceURI, lines)
 						}
-					default:
-						resourceURI, err = url.JoinPath("repo://", owner, repo, "contents", path)
+
+						slice, err := sliceTextFile(body, startLine, endLine, maxBytes)
 						if err != nil {
-							return nil, fmt.Errorf("failed to create resource URI: %w", err)
+							return mcp.NewToolResultError(err.Error()), nil
 						}
-					}
-
-					if strings.HasPrefix(contentType, "application") || strings.HasPrefix(contentType, "text") {
 						result := mcp.TextResourceContents{
 							URI:      resourceURI,
-							Text:     string(body),
+							Text:     slice.Text,
 							MIMEType: contentType,
 						}
-						// Include SHA in the result metadata
-						if fileSHA != "" {
-							return mcp.NewToolResultResource(fmt.Sprintf("successfully downloaded text file (SHA: %s)", fileSHA), result), nil
+						message := fmt.Sprintf("successfully downloaded text file (SHA: %s, %d lines)", fileSHA, slice.TotalLines)
+						if slice.Partial {
+							message = fmt.Sprintf("successfully downloaded lines %d-%d of text file (SHA: %s, %d lines)", slice.StartLine, slice.EndLine, fileSHA, slice.TotalLines)
 						}
-						return mcp.NewToolResultResource("successfully downloaded text file", result), nil
+						return mcp.NewToolResultResource(message, result), nil
 					}
 
 					result := mcp.BlobResourceContents{
@@ -619,12 +771,7 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 						Blob:     base64.StdEncoding.EncodeToString(body),
 						MIMEType: contentType,
 					}
-					// Include SHA in the result metadata
-					if fileSHA != "" {
-						return mcp.NewToolResultResource(fmt.Sprintf("successfully downloaded binary file (SHA: %s)", fileSHA), result), nil
-					}
-					return mcp.NewToolResultResource("successfully downloaded binary file", result), nil
-
+					return mcp.NewToolResultResource(fmt.Sprintf("successfully downloaded binary file (SHA: %s)", fileSHA), result), nil
 				}
 			}
 
@@ -662,21 +809,280 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 			const maxMatchingFiles = 3
 			matchingFiles := filterPaths(tree.Entries, path, maxMatchingFiles)
 			if len(matchingFiles) > 0 {
-				matchingFilesJSON, err := json.Marshal(matchingFiles)
-				if err != nil {
-					return mcp.NewToolResultError(fmt.Sprintf("failed to marshal matching files: %s", err)), nil
-				}
-				resolvedRefs, err := json.Marshal(rawOpts)
-				if err != nil {
-					return mcp.NewToolResultError(fmt.Sprintf("failed to marshal resolved refs: %s", err)), nil
-				}
-				return mcp.NewToolResultText(fmt.Sprintf("Path did not point to a file or directory, but resolved git ref to %s with possible path matches: %s", resolvedRefs, matchingFilesJSON)), nil
+				return MarshalledTextResult(PathMatches{
+					Message: "Path did not point to a file or directory, but these paths end with it",
+					Ref:     rawOpts.Ref,
+					SHA:     rawOpts.SHA,
+					Matches: matchingFiles,
+				}), nil
 			}
 
 			return mcp.NewToolResultError("Failed to get file contents. The path does not point to a file or directory, or the file does not exist in the repository."), nil
 		}
 }
 
+// getFilesMaxPaths is the maximum number of paths get_files fetches in one call.
+const getFilesMaxPaths = 50
+
+// getFilesConcurrency is the number of files get_files fetches at the same time.
+const getFilesConcurrency = 8
+
+// getFilesMaxFileBytes is the most get_files returns of each text file, and the default of max_bytes.
+const getFilesMaxFileBytes = 1 << 20
+
+// getFilesReadMargin is how much more than max_bytes get_files reads of each file, so that
+// files a little larger than that still get their SHA and line count.
+const getFilesReadMargin = 64 << 10
+
+// FileContents is a file fetched by get_files. Content is base64 encoded for binary files, and
+// files too large to be read whole have no line count. Type is only set for symlinks, which have
+// a Target instead of content, submodules, which have a GitURL, and Git LFS files, whose Size
+// and content are those of the LFS object.
+type FileContents struct {
+	Path       string `json:"path"`
+	Type       string `json:"type,omitempty"`
+	SHA        string `json:"sha,omitempty"`
+	Size       int    `json:"size"`
+	Target     string `json:"target,omitempty"`
+	GitURL     string `json:"git_url,omitempty"`
+	TotalLines int    `json:"total_lines,omitempty"`
+	Content    string `json:"content,omitempty"`
+	Encoding   string `json:"encoding,omitempty"`
+	Truncated  bool   `json:"truncated,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// FilesContents is the output type of get_files.
+type FilesContents struct {
+	SHA   string         `json:"sha"`
+	Files []FileContents `json:"files"`
+}
+
+// GetFiles creates a tool to get the contents of several files from a GitHub repository at once.
+func GetFiles(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_files",
+			mcp.WithDescription(t("TOOL_GET_FILES_DESCRIPTION", fmt.Sprintf("Get the contents of up to %d files from a GitHub repository in one call, with their blob SHAs and line counts. Prefer this over several get_file_contents calls.", getFilesMaxPaths))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_FILES_USER_TITLE", "Get multiple file contents"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("paths",
+				mcp.Required(),
+				mcp.Description("Paths of the files to get"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Accepts optional git refs such as `refs/tags/{tag}`, `refs/heads/{branch}` or `refs/pull/{pr_number}/head`"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Accepts optional commit SHA. If specified, it will be used instead of ref"),
+			),
+			mcp.WithNumber("max_bytes",
+				mcp.Description(fmt.Sprintf("Maximum number of bytes to return of each text file, cut at a line boundary. Defaults to and can't be more than %d", getFilesMaxFileBytes)),
+				mcp.Min(1),
+			),
+			WithOutputSchema[FilesContents](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paths, err := OptionalStringArrayParam(request, "paths")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(paths) == 0 {
+				return mcp.NewToolResultError("missing required parameter: paths"), nil
+			}
+			if len(paths) > getFilesMaxPaths {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d paths can be fetched at once, got %d", getFilesMaxPaths, len(paths))), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxBytes, err := OptionalIntParam(request, "max_bytes")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			rawClient, err := getRawClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub raw content client: %w", err)
+			}
+
+			// Resolve the ref once so that all files come from the same commit
+			rawOpts, err := resolveGitReference(ctx, client, owner, repo, ref, sha)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil
+			}
+
+			files := make([]FileContents, len(paths))
+			jobs := make(chan int)
+			var wg sync.WaitGroup
+			for range min(getFilesConcurrency, len(paths)) {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := range jobs {
+						files[i] = getFile(ctx, client, rawClient, owner, repo, strings.TrimPrefix(paths[i], "/"), rawOpts, maxBytes)
+					}
+				}()
+			}
+			for i := range paths {
+				jobs <- i
+			}
+			close(jobs)
+			wg.Wait()
+
+			return MarshalledTextResult(FilesContents{SHA: rawOpts.SHA, Files: files}), nil
+		}
+}
+
+// getFile fetches a single file for get_files. Errors are reported in the result so
+// that one missing file doesn't fail the others.
+func getFile(ctx context.Context, client *github.Client, rawClient *raw.Client, owner, repo, path string, rawOpts *raw.ContentOpts, maxBytes int) FileContents {
+	file := FileContents{Path: path}
+
+	// Look the path up like get_file_contents does, to tell symlinks and submodules apart
+	opts := &github.RepositoryContentGetOptions{Ref: rawOpts.Ref}
+	if rawOpts.SHA != "" {
+		opts.Ref = rawOpts.SHA
+	}
+	fileContent, _, contentsResp, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
+	if contentsResp != nil {
+		defer func() { _ = contentsResp.Body.Close() }()
+	}
+	if err != nil {
+		file.Error = fmt.Sprintf("failed to get file: %s", err)
+		if contentsResp != nil && contentsResp.StatusCode == http.StatusNotFound {
+			file.Error = "file not found"
+		}
+		return file
+	}
+	if fileContent == nil {
+		file.Error = "path is a directory"
+		return file
+	}
+	switch {
+	case fileContent.GetType() == "submodule":
+		submodule := newSubmoduleFile(path, fileContent)
+		file.Type, file.SHA, file.GitURL = submodule.Type, submodule.SHA, submodule.GitURL
+		return file
+	case fileContent.GetType() == "symlink":
+		file.Type, file.SHA, file.Target = "symlink", fileContent.GetSHA(), fileContent.GetTarget()
+		return file
+	case fileContent.GetPath() != "" && fileContent.GetPath() != path:
+		// The Contents API returns the target of symlinks to normal files
+		file.Type, file.Target = "symlink", fileContent.GetPath()
+		return file
+	}
+	file.SHA = fileContent.GetSHA()
+	file.Size = fileContent.GetSize()
+
+	resp, err := rawClient.GetRawContent(ctx, owner, repo, path, rawOpts)
+	if err != nil {
+		file.Error = fmt.Sprintf("failed to get raw content: %s", err)
+		return file
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		file.Error = fmt.Sprintf("failed to get raw content: %s", resp.Status)
+		if resp.StatusCode == http.StatusNotFound {
+			file.Error = "file not found"
+		}
+		return file
+	}
+
+	// Up to getFilesMaxPaths files are fetched at once, so only as much of each is read as can be returned
+	limit := getFilesMaxFileBytes
+	if maxBytes > 0 && maxBytes < limit {
+		limit = maxBytes
+	}
+	body, complete, err := readFileHead(resp.Body, limit+getFilesReadMargin)
+	if err != nil {
+		file.Error = fmt.Sprintf("failed to read content: %s", err)
+		return file
+	}
+	isText := isTextContentType(resp.Header.Get("Content-Type"))
+
+	if pointer, ok := parseLFSPointer(body); ok && isText {
+		file.Type = "lfs"
+		file.Size = pointer.Size
+		object, err := downloadLFSObject(ctx, client, owner, repo, pointer)
+		if err != nil {
+			file.Error = err.Error()
+			return file
+		}
+		defer func() { _ = object.Close() }()
+		body, complete, err = readFileHead(object, limit+getFilesReadMargin)
+		if err != nil {
+			file.Error = fmt.Sprintf("failed to read Git LFS object: %s", err)
+			return file
+		}
+		isText = strings.HasPrefix(http.DetectContentType(body), "text/")
+	}
+
+	if !isText {
+		if !complete {
+			file.Error = fmt.Sprintf("binary file is larger than %d bytes, download it with get_file_contents", limit+getFilesReadMargin)
+			return file
+		}
+		file.Content = base64.StdEncoding.EncodeToString(body)
+		file.Encoding = "base64"
+		return file
+	}
+	slice, err := sliceTextFile(body, 0, 0, limit)
+	if err != nil {
+		file.Error = err.Error()
+		return file
+	}
+	file.Content = slice.Text
+	file.Truncated = slice.Partial
+	if complete {
+		file.TotalLines = slice.TotalLines
+	}
+	return file
+}
+
+// readFileHead reads up to limit bytes from r, and reports whether that was all of it.
+func readFileHead(r io.Reader, limit int) ([]byte, bool, error) {
+	body, err := io.ReadAll(io.LimitReader(r, int64(limit)+1))
+	if err != nil {
+		return nil, false, err
+	}
+	if len(body) > limit {
+		return body[:limit], false, nil
+	}
+	return body, true, nil
+}
+
 // ForkRepository creates a tool to fork a repository.
 func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("fork_repository",
@@ -696,6 +1102,7 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 			mcp.WithString("organization",
 				mcp.Description("Organization to fork to"),
 			),
+			WithRawOutputSchema(OutputSchemaAnyOf(OutputSchema[MinimalResponse](), OutputSchema[MessageResponse]())),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -725,7 +1132,7 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 				// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
 				// and it's not a real error.
 				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
-					return mcp.NewToolResultText("Fork is in progress"), nil
+					return MarshalledTextResult(MessageResponse{Message: "Fork is in progress"}), nil
 				}
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to fork repository",
@@ -792,6 +1199,7 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				mcp.Required(),
 				mcp.Description("Branch to delete the file from"),
 			),
+			WithOutputSchema[map[string]any](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -957,6 +1365,7 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			mcp.WithString("from_branch",
 				mcp.Description("Source branch (defaults to repo default)"),
 			),
+			WithOutputSchema[*github.Reference](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1035,6 +1444,9 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 		}
 }
 
+// pushFilesSteps is the number of API calls push_files makes, used to report its progress.
+const pushFilesSteps = 5
+
 // PushFiles creates a tool to push multiple files in a single commit to a GitHub repository.
 func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("push_files",
@@ -1079,6 +1491,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				mcp.Required(),
 				mcp.Description("Commit message"),
 			),
+			WithOutputSchema[*github.Reference](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1109,6 +1522,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
+			SendProgress(ctx, request, 0, pushFilesSteps, "Reading branch reference")
 			// Get the reference for the branch
 			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
 			if err != nil {
@@ -1120,6 +1534,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			}
 			defer func() { _ = resp.Body.Close() }()
 
+			SendProgress(ctx, request, 1, pushFilesSteps, "Reading base commit")
 			// Get the commit object that the branch points to
 			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
 			if err != nil {
@@ -1159,6 +1574,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				})
 			}
 
+			SendProgress(ctx, request, 2, pushFilesSteps, "Creating tree")
 			// Create a new tree with the file entries
 			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, entries)
 			if err != nil {
@@ -1170,6 +1586,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			}
 			defer func() { _ = resp.Body.Close() }()
 
+			SendProgress(ctx, request, 3, pushFilesSteps, "Creating commit")
 			// Create a new commit
 			commit := &github.Commit{
 				Message: github.Ptr(message),
@@ -1186,6 +1603,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			}
 			defer func() { _ = resp.Body.Close() }()
 
+			SendProgress(ctx, request, 4, pushFilesSteps, "Updating branch reference")
 			// Update the reference to point to the new commit
 			ref.Object.SHA = newCommit.SHA
 			updatedRef, resp, err := client.Git.UpdateRef(ctx, owner, repo, ref, false)
@@ -1197,6 +1615,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
+			SendProgress(ctx, request, pushFilesSteps, pushFilesSteps, "")
 
 			r, err := json.Marshal(updatedRef)
 			if err != nil {
@@ -1224,6 +1643,7 @@ func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				mcp.Description("Repository name"),
 			),
 			WithPagination(),
+			WithOutputSchema[ListResult[*github.RepositoryTag]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1267,7 +1687,7 @@ func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list tags: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(tags)
+			r, err := json.Marshal(restListResult(tags, resp))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -1296,6 +1716,7 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 				mcp.Required(),
 				mcp.Description("Tag name"),
 			),
+			WithOutputSchema[*github.Tag](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1380,6 +1801,8 @@ func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				mcp.Description("Repository name"),
 			),
 			WithPagination(),
+			WithOutputSchema[ListResult[*github.RepositoryRelease]](),
+			WithMinimalOutput[ListResult[MinimalRelease]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1400,6 +1823,11 @@ func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				PerPage: pagination.PerPage,
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -1419,7 +1847,11 @@ func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list releases: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(releases)
+			if minimalOutput {
+				return MarshalledTextResult(convertListResult(restListResult(releases, resp), func(release *github.RepositoryRelease) MinimalRelease { return convertToMinimalRelease(release, false) })), nil
+			}
+
+			r, err := json.Marshal(restListResult(releases, resp))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -1444,6 +1876,8 @@ func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
+			WithOutputSchema[*github.RepositoryRelease](),
+			WithMinimalOutput[MinimalRelease](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1455,6 +1889,11 @@ func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -1474,6 +1913,10 @@ func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get latest release: %s", string(body))), nil
 			}
 
+			if minimalOutput {
+				return MarshalledTextResult(convertToMinimalRelease(release, true)), nil
+			}
+
 			r, err := json.Marshal(release)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
@@ -1502,6 +1945,8 @@ func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc
 				mcp.Required(),
 				mcp.Description("Tag name (e.g., 'v1.0.0')"),
 			),
+			WithOutputSchema[*github.RepositoryRelease](),
+			WithMinimalOutput[MinimalRelease](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1517,6 +1962,11 @@ func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			minimalOutput, err := OptionalMinimalOutput(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -1540,6 +1990,10 @@ func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get release by tag: %s", string(body))), nil
 			}
 
+			if minimalOutput {
+				return MarshalledTextResult(convertToMinimalRelease(release, true)), nil
+			}
+
 			r, err := json.Marshal(release)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
@@ -1549,6 +2003,57 @@ func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc
 		}
 }
 
+// isTextContentType reports whether raw content with the content type is returned as text.
+func isTextContentType(contentType string) bool {
+	return strings.HasPrefix(contentType, "application") || strings.HasPrefix(contentType, "text")
+}
+
+// textSlice is the part of a text file between two lines, cut to a maximum size.
+type textSlice struct {
+	Text       string
+	StartLine  int
+	EndLine    int
+	TotalLines int
+	// Partial is set when Text is not the whole file
+	Partial bool
+}
+
+// sliceTextFile returns the lines startLine to endLine of a file, or all of it if
+// they are 0. If the lines are longer than maxBytes, the text is cut after the last
+// line that fits, or within the first line if none does.
+func sliceTextFile(content []byte, startLine, endLine, maxBytes int) (textSlice, error) {
+	text := string(content)
+	total := strings.Count(text, "\n")
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		total++
+	}
+	slice := textSlice{Text: text, StartLine: 1, EndLine: total, TotalLines: total}
+
+	if startLine > total {
+		return textSlice{}, fmt.Errorf("start_line %d is past the end of the file, which has %d lines", startLine, total)
+	}
+	if startLine > 1 || (endLine > 0 && endLine < total) {
+		slice.StartLine = max(startLine, 1)
+		if endLine > 0 {
+			slice.EndLine = min(endLine, total)
+		}
+		lines := strings.SplitAfter(text, "\n")
+		slice.Text = strings.Join(lines[slice.StartLine-1:slice.EndLine], "")
+		slice.Partial = true
+	}
+
+	if maxBytes > 0 && len(slice.Text) > maxBytes {
+		cut := slice.Text[:runeBoundary(slice.Text, maxBytes)]
+		if i := strings.LastIndexByte(cut, '\n'); i >= 0 {
+			cut = cut[:i+1]
+		}
+		slice.Text = cut
+		slice.EndLine = slice.StartLine + max(strings.Count(cut, "\n")-1, 0)
+		slice.Partial = true
+	}
+	return slice, nil
+}
+
 // filterPaths filters the entries in a GitHub tree to find paths that
 // match the given suffix.
 // maxResults limits the number of results returned to first maxResults entries,
@@ -1704,6 +2209,7 @@ func ListStarredRepositories(getClient GetClientFn, t translations.TranslationHe
 				mcp.Enum("asc", "desc"),
 			),
 			WithPagination(),
+			WithOutputSchema[ListResult[MinimalRepository]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			username, err := OptionalParam[string](request, "username")
@@ -1795,7 +2301,7 @@ func ListStarredRepositories(getClient GetClientFn, t translations.TranslationHe
 				minimalRepos = append(minimalRepos, minimalRepo)
 			}
 
-			r, err := json.Marshal(minimalRepos)
+			r, err := json.Marshal(restListResult(minimalRepos, resp))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal starred repositories: %w", err)
 			}
@@ -1820,6 +2326,7 @@ func StarRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
+			WithOutputSchema[MessageResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1854,7 +2361,7 @@ func StarRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return mcp.NewToolResultError(fmt.Sprintf("failed to star repository: %s", string(body))), nil
 			}
 
-			return mcp.NewToolResultText(fmt.Sprintf("Successfully starred repository %s/%s", owner, repo)), nil
+			return MarshalledTextResult(MessageResponse{Message: fmt.Sprintf("Successfully starred repository %s/%s", owner, repo)}), nil
 		}
 }
 
@@ -1874,6 +2381,7 @@ func UnstarRepository(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
+			WithOutputSchema[MessageResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1908,6 +2416,6 @@ func UnstarRepository(getClient GetClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError(fmt.Sprintf("failed to unstar repository: %s", string(body))), nil
 			}
 
-			return mcp.NewToolResultText(fmt.Sprintf("Successfully unstarred repository %s/%s", owner, repo)), nil
+			return MarshalledTextResult(MessageResponse{Message: fmt.Sprintf("Successfully unstarred repository %s/%s", owner, repo)}), nil
 		}
 }
@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
@@ -112,6 +114,41 @@ func Test_GetFileContents(t *testing.T) {
 				MIMEType: "text/markdown",
 			},
 		},
+		{
+			name: "text content line range fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusOK, &github.RepositoryContent{
+						Name: github.Ptr("README.md"),
+						Path: github.Ptr("README.md"),
+						SHA:  github.Ptr("abc123"),
+						Type: github.Ptr("file"),
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoBySHAByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/markdown")
+						_, _ = w.Write(mockRawContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "README.md",
+				"sha":        "commit123",
+				"start_line": float64(2),
+				"end_line":   float64(10),
+			},
+			expectError: false,
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/sha/commit123/contents/README.md",
+				Text:     "\nThis is a test repository.",
+				MIMEType: "text/markdown",
+			},
+		},
 		{
 			name: "successful file blob content fetch",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -282,6 +319,282 @@ func Test_GetFileContents(t *testing.T) {
 	}
 }
 
+func Test_GetFiles(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	mockRawClient := raw.NewClient(mockClient, &url.URL{Scheme: "https", Host: "raw.githubusercontent.com", Path: "/"})
+	tool, _ := GetFiles(stubGetClientFn(mockClient), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_files", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "get_files tool should be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "paths")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "max_bytes")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "paths"})
+
+	largeFile := []byte(strings.Repeat("0123456789abcde\n", getFilesReadMargin/16+10))
+	largeBinary := make([]byte, getFilesReadMargin+100)
+	// The object is larger than its pointer says, so only the start of it is read
+	lfsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("first line\nsecond line\nthird line\nfourth line\n"))
+	}))
+	defer lfsServer.Close()
+
+	contents := map[string]any{
+		"README.md":    &github.RepositoryContent{Type: github.Ptr("file"), Path: github.Ptr("README.md"), SHA: github.Ptr("a92d664bc20a04b1621b1fc893d1196b41182fdf"), Size: github.Ptr(21)},
+		"img/logo.png": &github.RepositoryContent{Type: github.Ptr("file"), Path: github.Ptr("img/logo.png"), SHA: github.Ptr("2371c64537714260c401a05cc8d7ef411a1e5665"), Size: github.Ptr(4)},
+		"large.txt":    &github.RepositoryContent{Type: github.Ptr("file"), Path: github.Ptr("large.txt"), SHA: github.Ptr("b1"), Size: github.Ptr(len(largeFile))},
+		"large.bin":    &github.RepositoryContent{Type: github.Ptr("file"), Path: github.Ptr("large.bin"), SHA: github.Ptr("b2"), Size: github.Ptr(len(largeBinary))},
+		"data.csv":     &github.RepositoryContent{Type: github.Ptr("file"), Path: github.Ptr("data.csv"), SHA: github.Ptr("b3"), Size: github.Ptr(132)},
+		"link":         &github.RepositoryContent{Type: github.Ptr("symlink"), Path: github.Ptr("link"), SHA: github.Ptr("b4"), Target: github.Ptr("../outside")},
+		"docs/readme":  &github.RepositoryContent{Type: github.Ptr("file"), Path: github.Ptr("README.md"), SHA: github.Ptr("a92d664bc20a04b1621b1fc893d1196b41182fdf")},
+		"vendor/lib":   &github.RepositoryContent{Type: github.Ptr("submodule"), Path: github.Ptr("vendor/lib"), SHA: github.Ptr("c1"), SubmoduleGitURL: github.Ptr("https://github.com/other/lib.git")},
+		"docs":         []*github.RepositoryContent{{Type: github.Ptr("file"), Path: github.Ptr("docs/readme")}},
+	}
+	contentsHandler := mock.WithRequestMatchHandler(
+		mock.GetReposContentsByOwnerByRepoByPath,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			content, ok := contents[strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/contents/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				return
+			}
+			mockResponse(t, http.StatusOK, content)(w, r)
+		}),
+	)
+	rawHandler := mock.WithRequestMatchHandler(
+		raw.GetRawReposContentsByOwnerByRepoBySHAByPath,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/owner/repo/commit123/README.md":
+				w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+				_, _ = w.Write([]byte("line 1\nline 2\nline 3\n"))
+			case "/owner/repo/commit123/img/logo.png":
+				w.Header().Set("Content-Type", "image/png")
+				_, _ = w.Write([]byte{0x89, 'P', 'N', 'G'})
+			case "/owner/repo/commit123/large.txt":
+				w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+				w.Header().Set("Content-Length", fmt.Sprint(len(largeFile)))
+				_, _ = w.Write(largeFile)
+			case "/owner/repo/commit123/large.bin":
+				w.Header().Set("Content-Type", "image/png")
+				w.Header().Set("Content-Length", fmt.Sprint(len(largeBinary)))
+				_, _ = w.Write(largeBinary)
+			case "/owner/repo/commit123/data.csv":
+				w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+				_, _ = w.Write([]byte("version https://git-lfs.github.com/spec/v1\noid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393\nsize 34\n"))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}),
+	)
+
+	tooManyPaths := make([]any, getFilesMaxPaths+1)
+	for i := range tooManyPaths {
+		tooManyPaths[i] = fmt.Sprintf("file%d.txt", i)
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedFiles  FilesContents
+	}{
+		{
+			name:         "fetches text, binary and missing files",
+			mockedClient: mock.NewMockedHTTPClient(contentsHandler, rawHandler),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "commit123",
+				"paths": []any{"README.md", "/img/logo.png", "missing.txt"},
+			},
+			expectedFiles: FilesContents{
+				SHA: "commit123",
+				Files: []FileContents{
+					{Path: "README.md", SHA: "a92d664bc20a04b1621b1fc893d1196b41182fdf", Size: 21, TotalLines: 3, Content: "line 1\nline 2\nline 3\n"},
+					{Path: "img/logo.png", SHA: "2371c64537714260c401a05cc8d7ef411a1e5665", Size: 4, Content: base64.StdEncoding.EncodeToString([]byte{0x89, 'P', 'N', 'G'}), Encoding: "base64"},
+					{Path: "missing.txt", Error: "file not found"},
+				},
+			},
+		},
+		{
+			name:         "cuts text files to max_bytes",
+			mockedClient: mock.NewMockedHTTPClient(contentsHandler, rawHandler),
+			requestArgs: map[string]any{
+				"owner":     "owner",
+				"repo":      "repo",
+				"sha":       "commit123",
+				"paths":     []any{"README.md"},
+				"max_bytes": float64(10),
+			},
+			expectedFiles: FilesContents{
+				SHA: "commit123",
+				Files: []FileContents{
+					{Path: "README.md", SHA: "a92d664bc20a04b1621b1fc893d1196b41182fdf", Size: 21, TotalLines: 3, Content: "line 1\n", Truncated: true},
+				},
+			},
+		},
+		{
+			name:         "reads only a little more than max_bytes of each file",
+			mockedClient: mock.NewMockedHTTPClient(contentsHandler, rawHandler),
+			requestArgs: map[string]any{
+				"owner":     "owner",
+				"repo":      "repo",
+				"sha":       "commit123",
+				"paths":     []any{"large.txt", "large.bin"},
+				"max_bytes": float64(40),
+			},
+			expectedFiles: FilesContents{
+				SHA: "commit123",
+				Files: []FileContents{
+					{Path: "large.txt", SHA: "b1", Size: len(largeFile), Content: "0123456789abcde\n0123456789abcde\n", Truncated: true},
+					{Path: "large.bin", SHA: "b2", Size: len(largeBinary), Error: fmt.Sprintf("binary file is larger than %d bytes, download it with get_file_contents", 40+getFilesReadMargin)},
+				},
+			},
+		},
+		{
+			name: "reports symlinks, submodules, directories and Git LFS files",
+			mockedClient: mock.NewMockedHTTPClient(
+				contentsHandler,
+				rawHandler,
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{CloneURL: github.Ptr("https://github.com/owner/repo.git")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{Pattern: "/owner/repo.git/info/lfs/objects/batch", Method: "POST"},
+					mockResponse(t, http.StatusOK, map[string]any{"objects": []any{
+						map[string]any{
+							"oid":     "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393",
+							"actions": map[string]any{"download": map[string]any{"href": lfsServer.URL + "/objects/4d7a"}},
+						},
+					}}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "commit123",
+				"paths": []any{"link", "docs/readme", "vendor/lib", "docs", "data.csv"},
+			},
+			expectedFiles: FilesContents{
+				SHA: "commit123",
+				Files: []FileContents{
+					{Path: "link", Type: "symlink", SHA: "b4", Target: "../outside"},
+					{Path: "docs/readme", Type: "symlink", Target: "README.md"},
+					{Path: "vendor/lib", Type: "submodule", SHA: "c1", GitURL: "https://github.com/other/lib.git"},
+					{Path: "docs", Error: "path is a directory"},
+					{Path: "data.csv", Type: "lfs", SHA: "b3", Size: 34, TotalLines: 3, Content: "first line\nsecond line\nthird line\n"},
+				},
+			},
+		},
+		{
+			name:           "no paths",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "paths": []any{}},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: paths",
+		},
+		{
+			name:           "too many paths",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "paths": tooManyPaths},
+			expectError:    true,
+			expectedErrMsg: "at most 50 paths can be fetched at once",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			mockRawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+			_, handler := GetFiles(stubGetClientFn(client), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var files FilesContents
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &files))
+			assert.Equal(t, tc.expectedFiles, files)
+		})
+	}
+}
+
+func Test_SliceTextFile(t *testing.T) {
+	content := []byte("one\ntwo\nthree\nfour")
+
+	tests := []struct {
+		name          string
+		startLine     int
+		endLine       int
+		maxBytes      int
+		expectedSlice textSlice
+		expectedErr   string
+	}{
+		{
+			name:          "whole file",
+			expectedSlice: textSlice{Text: "one\ntwo\nthree\nfour", StartLine: 1, EndLine: 4, TotalLines: 4},
+		},
+		{
+			name:          "line range",
+			startLine:     2,
+			endLine:       3,
+			expectedSlice: textSlice{Text: "two\nthree\n", StartLine: 2, EndLine: 3, TotalLines: 4, Partial: true},
+		},
+		{
+			name:          "end line past the end",
+			startLine:     3,
+			endLine:       10,
+			expectedSlice: textSlice{Text: "three\nfour", StartLine: 3, EndLine: 4, TotalLines: 4, Partial: true},
+		},
+		{
+			name:          "cut at a line boundary",
+			maxBytes:      10,
+			expectedSlice: textSlice{Text: "one\ntwo\n", StartLine: 1, EndLine: 2, TotalLines: 4, Partial: true},
+		},
+		{
+			name:          "cut within the first line",
+			startLine:     3,
+			maxBytes:      3,
+			expectedSlice: textSlice{Text: "thr", StartLine: 3, EndLine: 3, TotalLines: 4, Partial: true},
+		},
+		{
+			name:        "start line past the end",
+			startLine:   5,
+			expectedErr: "start_line 5 is past the end of the file, which has 4 lines",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			slice, err := sliceTextFile(content, tc.startLine, tc.endLine, tc.maxBytes)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSlice, slice)
+		})
+	}
+}
+
 func Test_ForkRepository(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -708,6 +1021,204 @@ func Test_GetCommit(t *testing.T) {
 	}
 }
 
+func Test_CompareRefs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CompareRefs(stubGetClientFn(mockClient), translations.NullTranslationHelper, 5000)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "compare_refs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "compare_refs tool should be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "base")
+	assert.Contains(t, tool.InputSchema.Properties, "head")
+	assert.Contains(t, tool.InputSchema.Properties, "include_patch")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "base", "head"})
+
+	mockComparison := &github.CommitsComparison{
+		Status:          github.Ptr("diverged"),
+		AheadBy:         github.Ptr(2),
+		BehindBy:        github.Ptr(1),
+		TotalCommits:    github.Ptr(2),
+		HTMLURL:         github.Ptr("https://github.com/owner/repo/compare/v1.4...main"),
+		MergeBaseCommit: &github.RepositoryCommit{SHA: github.Ptr("base123")},
+		Commits: []*github.RepositoryCommit{
+			{
+				SHA:    github.Ptr("abc123"),
+				Commit: &github.Commit{Message: github.Ptr("Add feature")},
+				Author: &github.User{Login: github.Ptr("testuser")},
+			},
+			{
+				SHA:    github.Ptr("def456"),
+				Commit: &github.Commit{Message: github.Ptr("Fix bug")},
+			},
+		},
+		Files: []*github.CommitFile{
+			{
+				Filename:  github.Ptr("main.go"),
+				Status:    github.Ptr("modified"),
+				Additions: github.Ptr(3),
+				Deletions: github.Ptr(1),
+				Changes:   github.Ptr(4),
+				Patch:     github.Ptr("@@ -1,2 +1,4 @@\n line\n-old\n+new\n+more\n+lines"),
+			},
+			{
+				Filename:         github.Ptr("new.go"),
+				PreviousFilename: github.Ptr("old.go"),
+				Status:           github.Ptr("renamed"),
+				Patch:            github.Ptr("@@ -0,0 +0,0 @@"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		contentWindowSize  int
+		expectError        bool
+		expectedComparison MinimalComparison
+		expectedErrMsg     string
+	}{
+		{
+			name: "successful comparison without patches",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectPath(t, "/repos/owner/repo/compare/v1.4...main").andThen(
+						mockResponse(t, http.StatusOK, mockComparison),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v1.4",
+				"head":  "main",
+			},
+			contentWindowSize: 5000,
+			expectedComparison: MinimalComparison{
+				Status:       "diverged",
+				AheadBy:      2,
+				BehindBy:     1,
+				TotalCommits: 2,
+				MergeBaseSHA: "base123",
+				HTMLURL:      "https://github.com/owner/repo/compare/v1.4...main",
+				Commits: []MinimalCommit{
+					{SHA: "abc123", Commit: &MinimalCommitInfo{Message: "Add feature"}, Author: &MinimalUser{Login: "testuser"}},
+					{SHA: "def456", Commit: &MinimalCommitInfo{Message: "Fix bug"}},
+				},
+				Files: []MinimalComparisonFile{
+					{MinimalCommitFile: MinimalCommitFile{Filename: "main.go", Status: "modified", Additions: 3, Deletions: 1, Changes: 4}},
+					{MinimalCommitFile: MinimalCommitFile{Filename: "new.go", Status: "renamed"}, PreviousFilename: "old.go"},
+				},
+			},
+		},
+		{
+			name: "patches are cut to the content window",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectQueryParams(t, map[string]string{"page": "2", "per_page": "100"}).andThen(
+						mockResponse(t, http.StatusOK, mockComparison),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"base":          "v1.4",
+				"head":          "main",
+				"include_patch": true,
+				"page":          float64(2),
+				"perPage":       float64(100),
+			},
+			contentWindowSize: 3,
+			expectedComparison: MinimalComparison{
+				Status:       "diverged",
+				AheadBy:      2,
+				BehindBy:     1,
+				TotalCommits: 2,
+				MergeBaseSHA: "base123",
+				HTMLURL:      "https://github.com/owner/repo/compare/v1.4...main",
+				Commits: []MinimalCommit{
+					{SHA: "abc123", Commit: &MinimalCommitInfo{Message: "Add feature"}, Author: &MinimalUser{Login: "testuser"}},
+					{SHA: "def456", Commit: &MinimalCommitInfo{Message: "Fix bug"}},
+				},
+				Files: []MinimalComparisonFile{
+					{
+						MinimalCommitFile: MinimalCommitFile{Filename: "main.go", Status: "modified", Additions: 3, Deletions: 1, Changes: 4},
+						Patch:             "@@ -1,2 +1,4 @@\n line\n-old",
+						PatchTruncated:    true,
+					},
+					{
+						MinimalCommitFile: MinimalCommitFile{Filename: "new.go", Status: "renamed"},
+						PreviousFilename:  "old.go",
+						Patch:             "@@ -0,0 +0,0 @@",
+					},
+				},
+			},
+		},
+		{
+			name: "comparison fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v1.4",
+				"head":  "nonexistent",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to compare v1.4...nonexistent",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CompareRefs(stubGetClientFn(client), translations.NullTranslationHelper, tc.contentWindowSize)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returnedComparison MinimalComparison
+			err = json.Unmarshal([]byte(textContent.Text), &returnedComparison)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedComparison, returnedComparison)
+		})
+	}
+}
+
 func Test_ListCommits(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -916,9 +1427,10 @@ func Test_ListCommits(t *testing.T) {
 			textContent := getTextResult(t, result)
 
 			// Unmarshal and verify the result
-			var returnedCommits []MinimalCommit
-			err = json.Unmarshal([]byte(textContent.Text), &returnedCommits)
+			var response ListResult[MinimalCommit]
+			err = json.Unmarshal([]byte(textContent.Text), &response)
 			require.NoError(t, err)
+			returnedCommits := response.Items
 			assert.Len(t, returnedCommits, len(tc.expectedCommits))
 			for i, commit := range returnedCommits {
 				assert.Equal(t, tc.expectedCommits[i].GetSHA(), commit.SHA)
@@ -1721,9 +2233,10 @@ func Test_ListBranches(t *testing.T) {
 			require.NotEmpty(t, textContent.Text)
 
 			// Verify response
-			var branches []*github.Branch
-			err = json.Unmarshal([]byte(textContent.Text), &branches)
+			var response ListResult[*github.Branch]
+			err = json.Unmarshal([]byte(textContent.Text), &response)
 			require.NoError(t, err)
+			branches := response.Items
 			assert.Len(t, branches, 2)
 			assert.Equal(t, "main", *branches[0].Name)
 			assert.Equal(t, "develop", *branches[1].Name)
@@ -2019,9 +2532,10 @@ func Test_ListTags(t *testing.T) {
 			textContent := getTextResult(t, result)
 
 			// Parse and verify the result
-			var returnedTags []*github.RepositoryTag
-			err = json.Unmarshal([]byte(textContent.Text), &returnedTags)
+			var response ListResult[*github.RepositoryTag]
+			err = json.Unmarshal([]byte(textContent.Text), &response)
 			require.NoError(t, err)
+			returnedTags := response.Items
 
 			// Verify each tag
 			require.Equal(t, len(tc.expectedTags), len(returnedTags))
@@ -2189,6 +2703,7 @@ func Test_GetTag(t *testing.T) {
 func Test_ListReleases(t *testing.T) {
 	mockClient := github.NewClient(nil)
 	tool, _ := ListReleases(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "list_releases", tool.Name)
 	assert.NotEmpty(t, tool.Description)
@@ -2267,9 +2782,10 @@ func Test_ListReleases(t *testing.T) {
 
 			require.NoError(t, err)
 			textContent := getTextResult(t, result)
-			var returnedReleases []*github.RepositoryRelease
-			err = json.Unmarshal([]byte(textContent.Text), &returnedReleases)
+			var response ListResult[*github.RepositoryRelease]
+			err = json.Unmarshal([]byte(textContent.Text), &response)
 			require.NoError(t, err)
+			returnedReleases := response.Items
 			assert.Len(t, returnedReleases, len(tc.expectedResult))
 			for i, rel := range returnedReleases {
 				assert.Equal(t, *tc.expectedResult[i].TagName, *rel.TagName)
@@ -2280,6 +2796,7 @@ func Test_ListReleases(t *testing.T) {
 func Test_GetLatestRelease(t *testing.T) {
 	mockClient := github.NewClient(nil)
 	tool, _ := GetLatestRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "get_latest_release", tool.Name)
 	assert.NotEmpty(t, tool.Description)
@@ -3009,9 +3526,10 @@ func Test_ListStarredRepositories(t *testing.T) {
 				textContent := getTextResult(t, result)
 
 				// Unmarshal and verify the result
-				var returnedRepos []MinimalRepository
-				err = json.Unmarshal([]byte(textContent.Text), &returnedRepos)
+				var response ListResult[MinimalRepository]
+				err = json.Unmarshal([]byte(textContent.Text), &response)
 				require.NoError(t, err)
+				returnedRepos := response.Items
 
 				assert.Len(t, returnedRepos, tc.expectedCount)
 				if tc.expectedCount > 0 {
@@ -0,0 +1,620 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RepositorySettings is the output type of the tools that change repository settings.
+type RepositorySettings struct {
+	FullName            string   `json:"full_name"`
+	HTMLURL             string   `json:"html_url"`
+	Description         string   `json:"description"`
+	Homepage            string   `json:"homepage"`
+	Topics              []string `json:"topics"`
+	Visibility          string   `json:"visibility"`
+	DefaultBranch       string   `json:"default_branch"`
+	Archived            bool     `json:"archived"`
+	AllowMergeCommit    bool     `json:"allow_merge_commit"`
+	AllowSquashMerge    bool     `json:"allow_squash_merge"`
+	AllowRebaseMerge    bool     `json:"allow_rebase_merge"`
+	AllowAutoMerge      bool     `json:"allow_auto_merge"`
+	AllowUpdateBranch   bool     `json:"allow_update_branch"`
+	DeleteBranchOnMerge bool     `json:"delete_branch_on_merge"`
+	HasIssues           bool     `json:"has_issues"`
+	HasWiki             bool     `json:"has_wiki"`
+}
+
+// RepositoryTransfer is the output type of transfer_repository.
+type RepositoryTransfer struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	HTMLURL string `json:"html_url,omitempty"`
+	// Message tells that transfers to a user wait for them to accept
+	Message string `json:"message"`
+}
+
+// ForkSync is the output type of sync_fork.
+type ForkSync struct {
+	Branch string `json:"branch"`
+	// MergeType is how the branch was synced: fast-forward, merge or none
+	MergeType  string `json:"merge_type"`
+	BaseBranch string `json:"base_branch"`
+	Message    string `json:"message"`
+}
+
+func convertToRepositorySettings(repo *github.Repository) RepositorySettings {
+	topics := repo.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	return RepositorySettings{
+		FullName:            repo.GetFullName(),
+		HTMLURL:             repo.GetHTMLURL(),
+		Description:         repo.GetDescription(),
+		Homepage:            repo.GetHomepage(),
+		Topics:              topics,
+		Visibility:          repo.GetVisibility(),
+		DefaultBranch:       repo.GetDefaultBranch(),
+		Archived:            repo.GetArchived(),
+		AllowMergeCommit:    repo.GetAllowMergeCommit(),
+		AllowSquashMerge:    repo.GetAllowSquashMerge(),
+		AllowRebaseMerge:    repo.GetAllowRebaseMerge(),
+		AllowAutoMerge:      repo.GetAllowAutoMerge(),
+		AllowUpdateBranch:   repo.GetAllowUpdateBranch(),
+		DeleteBranchOnMerge: repo.GetDeleteBranchOnMerge(),
+		HasIssues:           repo.GetHasIssues(),
+		HasWiki:             repo.GetHasWiki(),
+	}
+}
+
+// repositoryStringSettings are the string parameters of update_repository, and the fields they set.
+var repositoryStringSettings = map[string]func(*github.Repository, string){
+	"description":    func(r *github.Repository, v string) { r.Description = github.Ptr(v) },
+	"homepage":       func(r *github.Repository, v string) { r.Homepage = github.Ptr(v) },
+	"visibility":     func(r *github.Repository, v string) { r.Visibility = github.Ptr(v) },
+	"default_branch": func(r *github.Repository, v string) { r.DefaultBranch = github.Ptr(v) },
+}
+
+// repositoryBoolSettings are the boolean parameters of update_repository, and the fields they set.
+var repositoryBoolSettings = map[string]func(*github.Repository, bool){
+	"allow_merge_commit":     func(r *github.Repository, v bool) { r.AllowMergeCommit = github.Ptr(v) },
+	"allow_squash_merge":     func(r *github.Repository, v bool) { r.AllowSquashMerge = github.Ptr(v) },
+	"allow_rebase_merge":     func(r *github.Repository, v bool) { r.AllowRebaseMerge = github.Ptr(v) },
+	"allow_auto_merge":       func(r *github.Repository, v bool) { r.AllowAutoMerge = github.Ptr(v) },
+	"allow_update_branch":    func(r *github.Repository, v bool) { r.AllowUpdateBranch = github.Ptr(v) },
+	"delete_branch_on_merge": func(r *github.Repository, v bool) { r.DeleteBranchOnMerge = github.Ptr(v) },
+	"has_issues":             func(r *github.Repository, v bool) { r.HasIssues = github.Ptr(v) },
+	"has_wiki":               func(r *github.Repository, v bool) { r.HasWiki = github.Ptr(v) },
+}
+
+// UpdateRepository creates a tool to update the settings of a repository.
+func UpdateRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_repository",
+			mcp.WithDescription(t("TOOL_UPDATE_REPOSITORY_DESCRIPTION", "Update the settings of a GitHub repository, such as its description, topics, visibility, default branch and merge options. Only the given settings change")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_UPDATE_REPOSITORY_USER_TITLE", "Update repository settings"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Repository description"),
+			),
+			mcp.WithString("homepage",
+				mcp.Description("URL of the repository's website"),
+			),
+			mcp.WithArray("topics",
+				mcp.Description("Topics of the repository, in lowercase. These replace all its topics, and an empty array removes them"),
+				mcp.WithStringItems(),
+			),
+			mcp.WithString("visibility",
+				mcp.Description("Repository visibility. internal is only available to organizations of an enterprise"),
+				mcp.Enum("public", "private", "internal"),
+			),
+			mcp.WithString("default_branch",
+				mcp.Description("Default branch of the repository, which must already exist"),
+			),
+			mcp.WithBoolean("allow_merge_commit",
+				mcp.Description("Allow merging pull requests with a merge commit"),
+			),
+			mcp.WithBoolean("allow_squash_merge",
+				mcp.Description("Allow squash-merging pull requests"),
+			),
+			mcp.WithBoolean("allow_rebase_merge",
+				mcp.Description("Allow rebase-merging pull requests"),
+			),
+			mcp.WithBoolean("allow_auto_merge",
+				mcp.Description("Allow auto-merge on pull requests"),
+			),
+			mcp.WithBoolean("allow_update_branch",
+				mcp.Description("Always suggest updating pull request branches that are behind their base branch"),
+			),
+			mcp.WithBoolean("delete_branch_on_merge",
+				mcp.Description("Delete head branches automatically when pull requests are merged"),
+			),
+			mcp.WithBoolean("has_issues",
+				mcp.Description("Enable issues"),
+			),
+			mcp.WithBoolean("has_wiki",
+				mcp.Description("Enable the wiki"),
+			),
+			WithOutputSchema[RepositorySettings](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			edit := &github.Repository{}
+			changed := false
+			for param, set := range repositoryStringSettings {
+				value, ok, err := OptionalParamOK[string](request, param)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					set(edit, value)
+					changed = true
+				}
+			}
+			for param, set := range repositoryBoolSettings {
+				value, ok, err := OptionalParamOK[bool](request, param)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					set(edit, value)
+					changed = true
+				}
+			}
+			_, topicsGiven := request.GetArguments()["topics"]
+			topics, err := OptionalStringArrayParam(request, "topics")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !changed && !topicsGiven {
+				return mcp.NewToolResultError("no settings to update were given"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var updated *github.Repository
+			var resp *github.Response
+			if changed {
+				updated, resp, err = client.Repositories.Edit(ctx, owner, repo, edit)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to update repository: %s/%s", owner, repo),
+						resp,
+						err,
+					), nil
+				}
+			} else {
+				updated, resp, err = client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get repository: %s/%s", owner, repo),
+						resp,
+						err,
+					), nil
+				}
+			}
+			_ = resp.Body.Close()
+
+			if topicsGiven {
+				replaced, resp, err := client.Repositories.ReplaceAllTopics(ctx, owner, repo, topics)
+				if err != nil {
+					message := "failed to replace topics"
+					if changed {
+						message = "repository settings updated, but failed to replace topics"
+					}
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						message,
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				updated.Topics = replaced
+			}
+
+			return MarshalledTextResult(convertToRepositorySettings(updated)), nil
+		}
+}
+
+// setArchivedHandler returns the handler of archive_repository or unarchive_repository.
+func setArchivedHandler(getClient GetClientFn, archived bool) server.ToolHandlerFunc {
+	action := "archive"
+	if !archived {
+		action = "unarchive"
+	}
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		owner, err := RequiredParam[string](request, "owner")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		repo, err := RequiredParam[string](request, "repo")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		current, resp, err := client.Repositories.Get(ctx, owner, repo)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				fmt.Sprintf("failed to get repository: %s/%s", owner, repo),
+				resp,
+				err,
+			), nil
+		}
+		_ = resp.Body.Close()
+
+		// Nothing to do, which keeps repeated runs over many repositories cheap
+		if current.GetArchived() == archived {
+			return MarshalledTextResult(convertToRepositorySettings(current)), nil
+		}
+
+		updated, resp, err := client.Repositories.Edit(ctx, owner, repo, &github.Repository{Archived: github.Ptr(archived)})
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				fmt.Sprintf("failed to %s repository: %s/%s", action, owner, repo),
+				resp,
+				err,
+			), nil
+		}
+		_ = resp.Body.Close()
+
+		return MarshalledTextResult(convertToRepositorySettings(updated)), nil
+	}
+}
+
+// ArchiveRepository creates a tool to archive a repository.
+func ArchiveRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("archive_repository",
+			mcp.WithDescription(t("TOOL_ARCHIVE_REPOSITORY_DESCRIPTION", "Archive a GitHub repository, making it read-only. An archived repository is left as it is")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ARCHIVE_REPOSITORY_USER_TITLE", "Archive repository"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithOutputSchema[RepositorySettings](),
+		),
+		setArchivedHandler(getClient, true)
+}
+
+// UnarchiveRepository creates a tool to unarchive a repository.
+func UnarchiveRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unarchive_repository",
+			mcp.WithDescription(t("TOOL_UNARCHIVE_REPOSITORY_DESCRIPTION", "Unarchive a GitHub repository, so it can be changed again. A repository that isn't archived is left as it is")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UNARCHIVE_REPOSITORY_USER_TITLE", "Unarchive repository"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithOutputSchema[RepositorySettings](),
+		),
+		setArchivedHandler(getClient, false)
+}
+
+// TransferRepository creates a tool to transfer a repository to another user or organization.
+func TransferRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("transfer_repository",
+			mcp.WithDescription(t("TOOL_TRANSFER_REPOSITORY_DESCRIPTION", "Transfer a GitHub repository to another user or organization. Transfers to a user only complete once they accept them")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_TRANSFER_REPOSITORY_USER_TITLE", "Transfer repository"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("new_owner",
+				mcp.Required(),
+				mcp.Description("User or organization to transfer the repository to"),
+			),
+			mcp.WithString("new_name",
+				mcp.Description("New name of the repository. Defaults to its current name"),
+			),
+			mcp.WithArray("team_ids",
+				mcp.Description("IDs of teams of the new owner organization to give access to the repository"),
+				mcp.Items(map[string]any{"type": "number"}),
+			),
+			WithOutputSchema[RepositoryTransfer](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			newOwner, err := RequiredParam[string](request, "new_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			newName, err := OptionalParam[string](request, "new_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamIDs, err := OptionalParam[[]any](request, "team_ids")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			transfer := github.TransferRequest{NewOwner: newOwner}
+			if newName != "" {
+				transfer.NewName = github.Ptr(newName)
+			}
+			for _, id := range teamIDs {
+				teamID, ok := id.(float64)
+				if !ok {
+					return mcp.NewToolResultError("team_ids must be an array of numbers"), nil
+				}
+				transfer.TeamID = append(transfer.TeamID, int64(teamID))
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			transferred, resp, err := client.Repositories.Transfer(ctx, owner, repo, transfer)
+			var acceptedErr *github.AcceptedError
+			switch {
+			case errors.As(err, &acceptedErr):
+				// GitHub transfers the repository in the background, and returns it as it will be
+				transferred = &github.Repository{}
+				if err := json.Unmarshal(acceptedErr.Raw, transferred); err != nil {
+					return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+				}
+			case err != nil:
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to transfer repository: %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			name := transferred.GetFullName()
+			if name == "" {
+				name = newOwner + "/" + repo
+				if newName != "" {
+					name = newOwner + "/" + newName
+				}
+			}
+			return MarshalledTextResult(RepositoryTransfer{
+				From:    owner + "/" + repo,
+				To:      name,
+				HTMLURL: transferred.GetHTMLURL(),
+				Message: fmt.Sprintf("transfer started; if %s is a user, the transfer completes once they accept it", newOwner),
+			}), nil
+		}
+}
+
+// CreateRepositoryFromTemplate creates a tool to create a repository from a template repository.
+func CreateRepositoryFromTemplate(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_repository_from_template",
+			mcp.WithDescription(t("TOOL_CREATE_REPOSITORY_FROM_TEMPLATE_DESCRIPTION", "Create a new GitHub repository from a template repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_REPOSITORY_FROM_TEMPLATE_USER_TITLE", "Create repository from template"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("template_owner",
+				mcp.Required(),
+				mcp.Description("Owner of the template repository"),
+			),
+			mcp.WithString("template_repo",
+				mcp.Required(),
+				mcp.Description("Name of the template repository"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the new repository"),
+			),
+			mcp.WithString("owner",
+				mcp.Description("User or organization to create the repository in. Defaults to the authenticated user"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Repository description"),
+			),
+			mcp.WithBoolean("private",
+				mcp.Description("Whether the new repository is private"),
+			),
+			mcp.WithBoolean("include_all_branches",
+				mcp.Description("Copy all branches of the template, not just its default branch"),
+			),
+			WithOutputSchema[MinimalResponse](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			templateOwner, err := RequiredParam[string](request, "template_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			templateRepo, err := RequiredParam[string](request, "template_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := OptionalParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			private, err := OptionalParam[bool](request, "private")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeAllBranches, err := OptionalParam[bool](request, "include_all_branches")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			templateReq := &github.TemplateRepoRequest{
+				Name:               github.Ptr(name),
+				Private:            github.Ptr(private),
+				IncludeAllBranches: github.Ptr(includeAllBranches),
+			}
+			if owner != "" {
+				templateReq.Owner = github.Ptr(owner)
+			}
+			if description != "" {
+				templateReq.Description = github.Ptr(description)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			createdRepo, resp, err := client.Repositories.CreateFromTemplate(ctx, templateOwner, templateRepo, templateReq)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create repository from template: %s/%s", templateOwner, templateRepo),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			return MarshalledTextResult(MinimalResponse{
+				ID:  fmt.Sprintf("%d", createdRepo.GetID()),
+				URL: createdRepo.GetHTMLURL(),
+			}), nil
+		}
+}
+
+// SyncFork creates a tool to update a branch of a fork with the changes of its upstream repository.
+func SyncFork(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("sync_fork",
+			mcp.WithDescription(t("TOOL_SYNC_FORK_DESCRIPTION", "Sync a branch of a forked repository with the same branch of its upstream repository, by fast-forwarding or merging")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SYNC_FORK_USER_TITLE", "Sync fork with upstream"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Owner of the fork"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Name of the fork"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Branch to sync. Defaults to the default branch of the fork"),
+			),
+			WithOutputSchema[ForkSync](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if branch == "" {
+				fork, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get repository: %s/%s", owner, repo),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				if !fork.GetFork() {
+					return mcp.NewToolResultError(fmt.Sprintf("%s/%s is not a fork", owner, repo)), nil
+				}
+				branch = fork.GetDefaultBranch()
+			}
+
+			result, resp, err := client.Repositories.MergeUpstream(ctx, owner, repo, &github.RepoMergeUpstreamRequest{Branch: github.Ptr(branch)})
+			if err != nil {
+				message := fmt.Sprintf("failed to sync branch %s with upstream", branch)
+				if resp != nil && resp.StatusCode == http.StatusConflict {
+					message = fmt.Sprintf("branch %s conflicts with upstream, so it can't be synced automatically; merge the upstream changes through a pull request instead", branch)
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					message,
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			return MarshalledTextResult(ForkSync{
+				Branch:     branch,
+				MergeType:  result.GetMergeType(),
+				BaseBranch: result.GetBaseBranch(),
+				Message:    result.GetMessage(),
+			}), nil
+		}
+}
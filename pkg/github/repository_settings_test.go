@@ -0,0 +1,492 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UpdateRepository(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := UpdateRepository(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint, "update_repository tool should not be read-only")
+	assert.True(t, *tool.Annotations.DestructiveHint, "update_repository tool should be destructive")
+	assert.Contains(t, tool.InputSchema.Properties, "topics")
+	assert.Contains(t, tool.InputSchema.Properties, "visibility")
+	assert.Contains(t, tool.InputSchema.Properties, "default_branch")
+	assert.Contains(t, tool.InputSchema.Properties, "delete_branch_on_merge")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	updatedRepo := &github.Repository{
+		FullName:            github.Ptr("owner/repo"),
+		Description:         github.Ptr(""),
+		Visibility:          github.Ptr("private"),
+		DefaultBranch:       github.Ptr("main"),
+		AllowSquashMerge:    github.Ptr(true),
+		DeleteBranchOnMerge: github.Ptr(true),
+		Topics:              []string{"old"},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedSettings RepositorySettings
+	}{
+		{
+			name: "update settings and topics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"description":            "",
+						"visibility":             "private",
+						"allow_merge_commit":     false,
+						"delete_branch_on_merge": true,
+					}).andThen(
+						mockResponse(t, http.StatusOK, updatedRepo),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"names": []any{"go", "mcp"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]any{"names": []string{"go", "mcp"}}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":                  "owner",
+				"repo":                   "repo",
+				"description":            "",
+				"visibility":             "private",
+				"allow_merge_commit":     false,
+				"delete_branch_on_merge": true,
+				"topics":                 []any{"go", "mcp"},
+			},
+			expectedSettings: RepositorySettings{
+				FullName:            "owner/repo",
+				Topics:              []string{"go", "mcp"},
+				Visibility:          "private",
+				DefaultBranch:       "main",
+				AllowSquashMerge:    true,
+				DeleteBranchOnMerge: true,
+			},
+		},
+		{
+			name: "remove all topics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, updatedRepo),
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"names": []any{},
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]any{"names": []string{}}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"topics": []any{},
+			},
+			expectedSettings: RepositorySettings{
+				FullName:            "owner/repo",
+				Topics:              []string{},
+				Visibility:          "private",
+				DefaultBranch:       "main",
+				AllowSquashMerge:    true,
+				DeleteBranchOnMerge: true,
+			},
+		},
+		{
+			name:         "no settings given",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "no settings to update were given",
+		},
+		{
+			name: "missing default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"default_branch": "trunk",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update repository: owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var settings RepositorySettings
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &settings))
+			assert.Equal(t, tc.expectedSettings, settings)
+		})
+	}
+}
+
+func Test_ArchiveRepository(t *testing.T) {
+	// Verify tool definitions once
+	tool, _ := ArchiveRepository(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+	assert.Equal(t, "archive_repository", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tool, _ = UnarchiveRepository(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+	assert.Equal(t, "unarchive_repository", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name             string
+		newTool          func(GetClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc)
+		mockedClient     *http.Client
+		expectError      bool
+		expectedErrMsg   string
+		expectedArchived bool
+	}{
+		{
+			name:    "archive",
+			newTool: ArchiveRepository,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{FullName: github.Ptr("owner/repo"), Archived: github.Ptr(false)}),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"archived": true}).andThen(
+						mockResponse(t, http.StatusOK, &github.Repository{FullName: github.Ptr("owner/repo"), Archived: github.Ptr(true)}),
+					),
+				),
+			),
+			expectedArchived: true,
+		},
+		{
+			name:    "already archived",
+			newTool: ArchiveRepository,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{FullName: github.Ptr("owner/repo"), Archived: github.Ptr(true)}),
+			),
+			expectedArchived: true,
+		},
+		{
+			name:    "unarchive",
+			newTool: UnarchiveRepository,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{FullName: github.Ptr("owner/repo"), Archived: github.Ptr(true)}),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"archived": false}).andThen(
+						mockResponse(t, http.StatusOK, &github.Repository{FullName: github.Ptr("owner/repo"), Archived: github.Ptr(false)}),
+					),
+				),
+			),
+			expectedArchived: false,
+		},
+		{
+			name:    "archive without admin access",
+			newTool: ArchiveRepository,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{FullName: github.Ptr("owner/repo"), Archived: github.Ptr(false)}),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights to Repository."}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to archive repository: owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := tc.newTool(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var settings RepositorySettings
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &settings))
+			assert.Equal(t, tc.expectedArchived, settings.Archived)
+		})
+	}
+}
+
+func Test_TransferRepository(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := TransferRepository(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "transfer_repository", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint, "transfer_repository tool should be destructive")
+	assert.Contains(t, tool.InputSchema.Properties, "new_name")
+	assert.Contains(t, tool.InputSchema.Properties, "team_ids")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "new_owner"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "transfer started",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposTransferByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"new_owner": "new-org",
+						"new_name":  "renamed",
+						"team_ids":  []any{float64(12)},
+					}).andThen(
+						mockResponse(t, http.StatusAccepted, &github.Repository{FullName: github.Ptr("new-org/renamed")}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":     "owner",
+				"repo":      "repo",
+				"new_owner": "new-org",
+				"new_name":  "renamed",
+				"team_ids":  []any{float64(12)},
+			},
+			expectedText: `{
+				"from": "owner/repo",
+				"to": "new-org/renamed",
+				"message": "transfer started; if new-org is a user, the transfer completes once they accept it"
+			}`,
+		},
+		{
+			name: "new owner can't receive it",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposTransferByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "new-org already has a repository with this name"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":     "owner",
+				"repo":      "repo",
+				"new_owner": "new-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to transfer repository: owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := TransferRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.JSONEq(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_CreateRepositoryFromTemplate(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := CreateRepositoryFromTemplate(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_repository_from_template", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "include_all_branches")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"template_owner", "template_repo", "name"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostReposGenerateByTemplateOwnerByTemplateRepo,
+			expectPath(t, "/repos/templates/go-service/generate").andThen(
+				expectRequestBody(t, map[string]any{
+					"name":                 "payments",
+					"owner":                "org",
+					"description":          "Payments service",
+					"private":              true,
+					"include_all_branches": false,
+				}).andThen(
+					mockResponse(t, http.StatusCreated, &github.Repository{
+						ID:      github.Ptr(int64(123)),
+						HTMLURL: github.Ptr("https://github.com/org/payments"),
+					}),
+				),
+			),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := CreateRepositoryFromTemplate(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"template_owner": "templates",
+		"template_repo":  "go-service",
+		"name":           "payments",
+		"owner":          "org",
+		"description":    "Payments service",
+		"private":        true,
+	}))
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+	var returned MinimalResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	assert.Equal(t, "123", returned.ID)
+	assert.Equal(t, "https://github.com/org/payments", returned.URL)
+}
+
+func Test_SyncFork(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := SyncFork(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "sync_fork", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedResult string
+	}{
+		{
+			name: "sync default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{Fork: github.Ptr(true), DefaultBranch: github.Ptr("main")}),
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergeUpstreamByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"branch": "main"}).andThen(
+						mockResponse(t, http.StatusOK, &github.RepoMergeUpstreamResult{
+							Message:    github.Ptr("Successfully fetched and fast-forwarded from upstream upstream:main."),
+							MergeType:  github.Ptr("fast-forward"),
+							BaseBranch: github.Ptr("upstream:main"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedResult: `{
+				"branch": "main",
+				"merge_type": "fast-forward",
+				"base_branch": "upstream:main",
+				"message": "Successfully fetched and fast-forwarded from upstream upstream:main."
+			}`,
+		},
+		{
+			name: "not a fork",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{Fork: github.Ptr(false), DefaultBranch: github.Ptr("main")}),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "owner/repo is not a fork",
+		},
+		{
+			name: "conflicts with upstream",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergeUpstreamByOwnerByRepo,
+					mockResponse(t, http.StatusConflict, map[string]string{"message": "There are merge conflicts"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "dev",
+			},
+			expectError:    true,
+			expectedErrMsg: "branch dev conflicts with upstream",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SyncFork(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.JSONEq(t, tc.expectedResult, textContent.Text)
+		})
+	}
+}
@@ -0,0 +1,355 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxTreeRequests caps the number of tree requests get_repository_tree makes when
+// the recursive tree is too large for a single response.
+const maxTreeRequests = 50
+
+// RepositoryTreeEntry is a file, directory or submodule in a repository tree.
+type RepositoryTreeEntry struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Mode string `json:"mode"`
+	SHA  string `json:"sha"`
+	Size *int   `json:"size,omitempty"`
+}
+
+// RepositoryTree is the output type of get_repository_tree.
+type RepositoryTree struct {
+	SHA     string                `json:"sha"`
+	Path    string                `json:"path,omitempty"`
+	Entries []RepositoryTreeEntry `json:"entries"`
+	// TotalSize is the sum of the sizes of the returned files
+	TotalSize int64 `json:"total_size"`
+	// Truncated is set when some subtrees couldn't be listed within maxTreeRequests
+	Truncated bool `json:"truncated"`
+}
+
+// pathGlob matches paths against a glob pattern. Patterns without a slash match
+// the last path element, like in .gitignore.
+type pathGlob struct {
+	re       *regexp.Regexp
+	baseName bool
+}
+
+type pathGlobs []pathGlob
+
+func compilePathGlobs(patterns []string) (pathGlobs, error) {
+	globs := make(pathGlobs, 0, len(patterns))
+	for _, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+		re, err := globToRegexp(strings.TrimPrefix(pattern, "/"))
+		if err != nil {
+			return nil, err
+		}
+		globs = append(globs, pathGlob{re: re, baseName: !strings.Contains(pattern, "/")})
+	}
+	return globs, nil
+}
+
+// matchAny reports whether p or, with ancestors set, any of its parent directories matches.
+func (g pathGlobs) matchAny(p string, ancestors bool) bool {
+	for ; p != "." && p != "/" && p != ""; p = path.Dir(p) {
+		for _, glob := range g {
+			name := p
+			if glob.baseName {
+				name = path.Base(p)
+			}
+			if glob.re.MatchString(name) {
+				return true
+			}
+		}
+		if !ancestors {
+			break
+		}
+	}
+	return false
+}
+
+// globToRegexp translates a glob pattern to a regular expression. "*" and "?" don't
+// match "/", "**" matches any number of path elements and [...] matches a character class.
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid pattern %q: unterminated character class", pattern)
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return re, nil
+}
+
+// treeWalker collects the entries of a tree and its subtrees.
+type treeWalker struct {
+	client      *github.Client
+	owner, repo string
+	maxDepth    int
+	exclude     pathGlobs
+	requests    int
+	entries     []*github.TreeEntry
+	truncated   bool
+}
+
+func (w *treeWalker) getTree(ctx context.Context, sha string, recursive bool) (*github.Tree, *github.Response, error) {
+	w.requests++
+	tree, resp, err := w.client.Git.GetTree(ctx, w.owner, w.repo, sha, recursive)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	return tree, resp, err
+}
+
+// walk adds the entries below the tree sha, which is at depth below the starting
+// directory. It lists the tree recursively and only walks the subtrees one by one
+// if the API truncates the recursive listing.
+func (w *treeWalker) walk(ctx context.Context, sha, dir string, depth int) (*github.Response, error) {
+	if w.maxDepth > 0 && depth >= w.maxDepth {
+		return nil, nil
+	}
+	if w.requests >= maxTreeRequests {
+		w.truncated = true
+		return nil, nil
+	}
+
+	// A single level is all that's needed right above the max depth
+	recursive := w.maxDepth == 0 || depth+1 < w.maxDepth
+	tree, resp, err := w.getTree(ctx, sha, recursive)
+	if err != nil {
+		return resp, err
+	}
+	if recursive && tree.GetTruncated() {
+		if w.requests >= maxTreeRequests {
+			w.truncated = true
+			return nil, nil
+		}
+		if tree, resp, err = w.getTree(ctx, sha, false); err != nil {
+			return resp, err
+		}
+		recursive = false
+	}
+	if tree.GetTruncated() {
+		w.truncated = true
+	}
+
+	for _, entry := range tree.Entries {
+		entryPath := path.Join(dir, entry.GetPath())
+		level := depth + strings.Count(entry.GetPath(), "/") + 1
+		if w.maxDepth > 0 && level > w.maxDepth {
+			continue
+		}
+		if w.exclude.matchAny(entryPath, false) {
+			continue
+		}
+		entry.Path = github.Ptr(entryPath)
+		w.entries = append(w.entries, entry)
+
+		if !recursive && entry.GetType() == "tree" {
+			if resp, err := w.walk(ctx, entry.GetSHA(), entryPath, level); err != nil {
+				return resp, err
+			}
+		}
+	}
+	return nil, nil
+}
+
+// GetRepositoryTree creates a tool to list the files and directories of a GitHub repository.
+func GetRepositoryTree(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_tree",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_TREE_DESCRIPTION", "List the files and directories of a GitHub repository recursively, with their sizes, modes and SHAs. Filter by a directory, glob patterns and depth. Use this to explore the layout of a repository instead of listing directories one at a time.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_TREE_USER_TITLE", "Get repository tree"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Accepts optional git refs such as `refs/tags/{tag}`, `refs/heads/{branch}`, or a branch or tag name. Defaults to the default branch"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Accepts optional commit SHA. If specified, it will be used instead of ref"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Directory to list, defaults to the root of the repository"),
+			),
+			mcp.WithArray("include",
+				mcp.Description("Glob patterns of the paths to return, e.g. `*.go` or `docs/**/*.md`. Patterns without a slash match file and directory names, others match paths from the root of the repository. `**` matches any number of directories"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithArray("exclude",
+				mcp.Description("Glob patterns of the paths to leave out, with the same syntax as include. Excluding a directory leaves out everything in it"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithNumber("max_depth",
+				mcp.Description("Number of directory levels to list below path, 1 lists only its direct children. Defaults to no limit"),
+				mcp.Min(1),
+			),
+			WithOutputSchema[RepositoryTree](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dir, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dir = strings.Trim(dir, "/")
+			includePatterns, err := OptionalStringArrayParam(request, "include")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			include, err := compilePathGlobs(includePatterns)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			excludePatterns, err := OptionalStringArrayParam(request, "exclude")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			exclude, err := compilePathGlobs(excludePatterns)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxDepth, err := OptionalIntParam(request, "max_depth")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			rawOpts, err := resolveGitReference(ctx, client, owner, repo, ref, sha)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil
+			}
+
+			walker := &treeWalker{client: client, owner: owner, repo: repo, maxDepth: maxDepth, exclude: exclude}
+
+			// Find the tree of the directory one level at a time
+			treeSHA := rawOpts.SHA
+			if dir != "" {
+				for i, name := range strings.Split(dir, "/") {
+					tree, resp, err := walker.getTree(ctx, treeSHA, false)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get git tree", resp, err), nil
+					}
+					found := false
+					for _, entry := range tree.Entries {
+						if entry.GetPath() == name && entry.GetType() == "tree" {
+							treeSHA, found = entry.GetSHA(), true
+							break
+						}
+					}
+					if !found {
+						return mcp.NewToolResultError(fmt.Sprintf("directory %s not found at %s", path.Join(strings.Split(dir, "/")[:i+1]...), rawOpts.SHA)), nil
+					}
+				}
+			}
+
+			if resp, err := walker.walk(ctx, treeSHA, dir, 0); err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get git tree", resp, err), nil
+			}
+
+			result := RepositoryTree{
+				SHA:       rawOpts.SHA,
+				Path:      dir,
+				Entries:   make([]RepositoryTreeEntry, 0, len(walker.entries)),
+				Truncated: walker.truncated,
+			}
+			for _, entry := range walker.entries {
+				if len(include) > 0 && !include.matchAny(entry.GetPath(), false) {
+					continue
+				}
+				// Exclusion of the walked directories was checked in the walk, but not
+				// of directories within recursive listings
+				if exclude.matchAny(entry.GetPath(), true) {
+					continue
+				}
+				result.Entries = append(result.Entries, RepositoryTreeEntry{
+					Path: entry.GetPath(),
+					Type: entry.GetType(),
+					Mode: entry.GetMode(),
+					SHA:  entry.GetSHA(),
+					Size: entry.Size,
+				})
+				result.TotalSize += int64(entry.GetSize())
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,251 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRepositoryTree(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GetRepositoryTree(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_tree", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "get_repository_tree tool should be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "include")
+	assert.Contains(t, tool.InputSchema.Properties, "exclude")
+	assert.Contains(t, tool.InputSchema.Properties, "max_depth")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	blob := func(p string, size int) *github.TreeEntry {
+		return &github.TreeEntry{Path: github.Ptr(p), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("sha-" + p[strings.LastIndex(p, "/")+1:]), Size: github.Ptr(size)}
+	}
+	dir := func(p, sha string) *github.TreeEntry {
+		return &github.TreeEntry{Path: github.Ptr(p), Type: github.Ptr("tree"), Mode: github.Ptr("040000"), SHA: github.Ptr(sha)}
+	}
+
+	// The root tree is too large to be listed recursively, its subtrees are not
+	trees := map[string]*github.Tree{
+		"abc123":               {Entries: []*github.TreeEntry{blob("README.md", 10), dir("cmd", "tree-cmd"), dir("pkg", "tree-pkg"), dir("vendor", "tree-vendor")}},
+		"abc123?recursive=1":   {Truncated: github.Ptr(true), Entries: []*github.TreeEntry{blob("README.md", 10)}},
+		"tree-cmd":             {Entries: []*github.TreeEntry{blob("main.go", 20)}},
+		"tree-cmd?recursive=1": {Entries: []*github.TreeEntry{blob("main.go", 20)}},
+		"tree-pkg":             {Entries: []*github.TreeEntry{dir("server", "tree-server"), blob("util.go", 30)}},
+		"tree-pkg?recursive=1": {Entries: []*github.TreeEntry{
+			dir("server", "tree-server"),
+			blob("server/server.go", 40),
+			blob("server/server_test.go", 50),
+			blob("util.go", 30),
+		}},
+		"tree-server": {Entries: []*github.TreeEntry{blob("server.go", 40), blob("server_test.go", 50)}},
+	}
+	var requested []string
+	treesHandler := mock.WithRequestMatchHandler(
+		mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			if r.URL.Query().Get("recursive") != "" {
+				key += "?recursive=1"
+			}
+			requested = append(requested, key)
+			tree, ok := trees[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				return
+			}
+			mockResponse(t, http.StatusOK, tree)(w, r)
+		}),
+	)
+
+	entry := func(p, typ string, size int) RepositoryTreeEntry {
+		e := RepositoryTreeEntry{Path: p, Type: typ, Mode: "100644", SHA: "sha-" + p[strings.LastIndex(p, "/")+1:]}
+		if typ == "tree" {
+			e.Mode = "040000"
+			e.SHA = "tree-" + p[strings.LastIndex(p, "/")+1:]
+		} else {
+			e.Size = github.Ptr(size)
+		}
+		return e
+	}
+
+	tests := []struct {
+		name              string
+		requestArgs       map[string]any
+		expectError       bool
+		expectedErrMsg    string
+		expectedTree      RepositoryTree
+		expectedRequested []string
+	}{
+		{
+			name: "walks subtrees when the recursive tree is truncated",
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"sha":     "abc123",
+				"exclude": []any{"vendor"},
+			},
+			expectedTree: RepositoryTree{
+				SHA: "abc123",
+				Entries: []RepositoryTreeEntry{
+					entry("README.md", "blob", 10),
+					entry("cmd", "tree", 0),
+					entry("cmd/main.go", "blob", 20),
+					entry("pkg", "tree", 0),
+					entry("pkg/server", "tree", 0),
+					entry("pkg/server/server.go", "blob", 40),
+					entry("pkg/server/server_test.go", "blob", 50),
+					entry("pkg/util.go", "blob", 30),
+				},
+				TotalSize: 150,
+			},
+			expectedRequested: []string{"abc123?recursive=1", "abc123", "tree-cmd?recursive=1", "tree-pkg?recursive=1"},
+		},
+		{
+			name: "filters by path and glob patterns",
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"sha":     "abc123",
+				"path":    "/pkg/",
+				"include": []any{"**/*.go"},
+				"exclude": []any{"*_test.go"},
+			},
+			expectedTree: RepositoryTree{
+				SHA:  "abc123",
+				Path: "pkg",
+				Entries: []RepositoryTreeEntry{
+					entry("pkg/server/server.go", "blob", 40),
+					entry("pkg/util.go", "blob", 30),
+				},
+				TotalSize: 70,
+			},
+			expectedRequested: []string{"abc123", "tree-pkg?recursive=1"},
+		},
+		{
+			name: "limits the depth",
+			requestArgs: map[string]any{
+				"owner":     "owner",
+				"repo":      "repo",
+				"sha":       "abc123",
+				"path":      "pkg",
+				"max_depth": float64(1),
+			},
+			expectedTree: RepositoryTree{
+				SHA:  "abc123",
+				Path: "pkg",
+				Entries: []RepositoryTreeEntry{
+					entry("pkg/server", "tree", 0),
+					entry("pkg/util.go", "blob", 30),
+				},
+				TotalSize: 30,
+			},
+			expectedRequested: []string{"abc123", "tree-pkg"},
+		},
+		{
+			name: "directory not found",
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+				"path":  "pkg/missing/deeper",
+			},
+			expectError:    true,
+			expectedErrMsg: "directory pkg/missing not found at abc123",
+		},
+		{
+			name: "invalid pattern",
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"sha":     "abc123",
+				"include": []any{"[abc"},
+			},
+			expectError:    true,
+			expectedErrMsg: "unterminated character class",
+		},
+		{
+			name: "tree not found",
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get git tree",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requested = nil
+			client := github.NewClient(mock.NewMockedHTTPClient(treesHandler))
+			_, handler := GetRepositoryTree(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var tree RepositoryTree
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &tree))
+			assert.Equal(t, tc.expectedTree, tree)
+			assert.Equal(t, tc.expectedRequested, requested)
+		})
+	}
+}
+
+func Test_PathGlobs(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		matches bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "pkg/server/main.go", true},
+		{"*.go", "main.go.txt", false},
+		{"pkg/*.go", "pkg/util.go", true},
+		{"pkg/*.go", "pkg/server/util.go", false},
+		{"pkg/**/*.go", "pkg/util.go", true},
+		{"pkg/**/*.go", "pkg/server/util.go", true},
+		{"/README.md", "README.md", true},
+		{"/README.md", "docs/README.md", false},
+		{"docs/**", "docs/a/b.md", true},
+		{"file?.txt", "file1.txt", true},
+		{"file[0-9].txt", "filea.txt", false},
+		{"file[!0-9].txt", "filea.txt", true},
+		{"a+b.txt", "a+b.txt", true},
+	}
+	for _, tc := range tests {
+		globs, err := compilePathGlobs([]string{tc.pattern})
+		require.NoError(t, err)
+		assert.Equal(t, tc.matches, globs.matchAny(tc.path, false), "%s %s", tc.pattern, tc.path)
+	}
+
+	globs, err := compilePathGlobs([]string{"vendor"})
+	require.NoError(t, err)
+	assert.True(t, globs.matchAny("vendor/github.com/pkg/a.go", true))
+	assert.False(t, globs.matchAny("vendor/github.com/pkg/a.go", false))
+}
@@ -0,0 +1,262 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+)
+
+const (
+	// DefaultResourcePollInterval is how often a subscribed resource is checked for changes.
+	DefaultResourcePollInterval = 30 * time.Second
+	// DefaultResourcePollMaxBackoff caps the polling interval of a resource that keeps failing.
+	DefaultResourcePollMaxBackoff = 10 * time.Minute
+	// DefaultMaxResourceSubscriptions is the number of resources a session can subscribe to.
+	DefaultMaxResourceSubscriptions = 25
+)
+
+// ErrTooManySubscriptions is returned when a session exceeds its subscription limit.
+var ErrTooManySubscriptions = errors.New("too many resource subscriptions")
+
+// ResourceSubscriptionsConfig configures the polling of subscribed resources.
+type ResourceSubscriptionsConfig struct {
+	PollInterval     time.Duration
+	MaxBackoff       time.Duration
+	MaxSubscriptions int
+}
+
+type resourceSubscription struct {
+	uri      string
+	apiPath  string
+	etag     string
+	primed   bool
+	failures int
+	nextPoll time.Time
+}
+
+// ResourceSubscriptions tracks the resources a session subscribed to and polls the GitHub API
+// for changes, using conditional requests so that unchanged resources do not count against the rate limit.
+type ResourceSubscriptions struct {
+	getClient GetClientFn
+	notify    func(uri string)
+	cfg       ResourceSubscriptionsConfig
+	now       func() time.Time
+
+	mu   sync.Mutex
+	subs map[string]*resourceSubscription
+}
+
+// NewResourceSubscriptions creates the subscriptions of a session. notify is called with the URI of
+// every subscribed resource that changed. Zero values in cfg are replaced by the defaults.
+func NewResourceSubscriptions(getClient GetClientFn, notify func(uri string), cfg ResourceSubscriptionsConfig) *ResourceSubscriptions {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultResourcePollInterval
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = DefaultResourcePollMaxBackoff
+	}
+	cfg.MaxBackoff = max(cfg.MaxBackoff, cfg.PollInterval)
+	if cfg.MaxSubscriptions <= 0 {
+		cfg.MaxSubscriptions = DefaultMaxResourceSubscriptions
+	}
+	return &ResourceSubscriptions{
+		getClient: getClient,
+		notify:    notify,
+		cfg:       cfg,
+		now:       time.Now,
+		subs:      make(map[string]*resourceSubscription),
+	}
+}
+
+// Subscribe starts watching the resource at uri. Subscribing to the same uri twice is a no-op.
+func (s *ResourceSubscriptions) Subscribe(uri string) error {
+	apiPath, err := resourceAPIPath(uri)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subs[uri]; ok {
+		return nil
+	}
+	if len(s.subs) >= s.cfg.MaxSubscriptions {
+		return fmt.Errorf("%w: limit is %d per session", ErrTooManySubscriptions, s.cfg.MaxSubscriptions)
+	}
+	s.subs[uri] = &resourceSubscription{
+		uri:      uri,
+		apiPath:  apiPath,
+		nextPoll: s.now(),
+	}
+	return nil
+}
+
+// Unsubscribe stops watching the resource at uri.
+func (s *ResourceSubscriptions) Unsubscribe(uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subs, uri)
+}
+
+// Run polls the subscribed resources until ctx is done.
+func (s *ResourceSubscriptions) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.PollDue(ctx)
+		}
+	}
+}
+
+// PollDue checks every subscribed resource whose next poll is due.
+func (s *ResourceSubscriptions) PollDue(ctx context.Context) {
+	s.mu.Lock()
+	var due []resourceSubscription
+	now := s.now()
+	for _, sub := range s.subs {
+		if !sub.nextPoll.After(now) {
+			due = append(due, *sub)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, sub := range due {
+		if ctx.Err() != nil {
+			return
+		}
+		s.poll(ctx, sub)
+	}
+}
+
+func (s *ResourceSubscriptions) poll(ctx context.Context, sub resourceSubscription) {
+	etag, resp, err := s.fetchETag(ctx, sub.apiPath, sub.etag)
+
+	s.mu.Lock()
+	current, ok := s.subs[sub.uri]
+	if !ok {
+		// Unsubscribed while polling
+		s.mu.Unlock()
+		return
+	}
+
+	changed := false
+	switch {
+	case resp != nil && resp.StatusCode == http.StatusNotModified:
+		current.failures = 0
+		current.nextPoll = s.now().Add(s.cfg.PollInterval)
+	case err != nil:
+		current.failures++
+		current.nextPoll = s.now().Add(s.backoff(current.failures))
+		// Wait for the rate limit to reset rather than burning through retries
+		var rateLimitErr *github.RateLimitError
+		if errors.As(err, &rateLimitErr) && rateLimitErr.Rate.Reset.After(current.nextPoll) {
+			current.nextPoll = rateLimitErr.Rate.Reset.Time
+		}
+	default:
+		current.failures = 0
+		current.nextPoll = s.now().Add(s.cfg.PollInterval)
+		// The first successful response only records the state the client subscribed to
+		changed = current.primed && etag != current.etag
+		current.etag = etag
+		current.primed = true
+	}
+	s.mu.Unlock()
+
+	if changed && s.notify != nil {
+		s.notify(sub.uri)
+	}
+}
+
+// backoff doubles the poll interval for every consecutive failure, up to the configured maximum.
+func (s *ResourceSubscriptions) backoff(failures int) time.Duration {
+	d := s.cfg.PollInterval
+	for i := 0; i < failures && d < s.cfg.MaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, s.cfg.MaxBackoff)
+}
+
+// fetchETag requests apiPath conditionally on etag and returns the ETag of the current representation.
+func (s *ResourceSubscriptions) fetchETag(ctx context.Context, apiPath, etag string) (string, *github.Response, error) {
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+
+	req, err := client.NewRequest(http.MethodGet, apiPath, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := client.Do(ctx, req, nil)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		return "", resp, err
+	}
+	return resp.Header.Get("ETag"), resp, nil
+}
+
+// resourceAPIPath maps a subscribable resource URI to the REST API path that represents its state.
+func resourceAPIPath(uri string) (string, error) {
+	rest, ok := strings.CutPrefix(uri, "repo://")
+	if !ok {
+		return "", fmt.Errorf("unsupported resource URI: %s", uri)
+	}
+	parts := strings.Split(rest, "/")
+	if len(parts) < 3 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("unsupported resource URI: %s", uri)
+	}
+	repoPath := fmt.Sprintf("repos/%s/%s", url.PathEscape(parts[0]), url.PathEscape(parts[1]))
+	parts = parts[2:]
+
+	switch {
+	case len(parts) == 2 && parts[0] == "issues":
+		return fmt.Sprintf("%s/issues/%s", repoPath, url.PathEscape(parts[1])), nil
+	case (len(parts) == 2 || len(parts) == 3 && parts[2] == "diff") && parts[0] == "pulls":
+		return fmt.Sprintf("%s/pulls/%s", repoPath, url.PathEscape(parts[1])), nil
+	case len(parts) == 4 && parts[0] == "actions" && parts[1] == "runs" && parts[3] == "logs":
+		return fmt.Sprintf("%s/actions/runs/%s", repoPath, url.PathEscape(parts[2])), nil
+	}
+
+	// Repository content, optionally at a ref
+	ref := ""
+	switch {
+	case len(parts) > 3 && parts[0] == "refs" && (parts[1] == "heads" || parts[1] == "tags"):
+		ref, parts = strings.Join(parts[:3], "/"), parts[3:]
+	case len(parts) > 4 && parts[0] == "refs" && parts[1] == "pull" && parts[3] == "head":
+		ref, parts = strings.Join(parts[:4], "/"), parts[4:]
+	case len(parts) > 2 && parts[0] == "sha":
+		ref, parts = parts[1], parts[2:]
+	}
+	if len(parts) < 2 || parts[0] != "contents" {
+		return "", fmt.Errorf("unsupported resource URI: %s", uri)
+	}
+
+	segments := make([]string, 0, len(parts)-1)
+	for _, segment := range parts[1:] {
+		segments = append(segments, url.PathEscape(segment))
+	}
+	apiPath := fmt.Sprintf("%s/contents/%s", repoPath, strings.Join(segments, "/"))
+	if ref != "" {
+		apiPath += "?ref=" + url.QueryEscape(ref)
+	}
+	return apiPath, nil
+}
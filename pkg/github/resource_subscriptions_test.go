@@ -0,0 +1,143 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ResourceAPIPath(t *testing.T) {
+	tests := []struct {
+		uri          string
+		expectedPath string
+		expectError  bool
+	}{
+		{uri: "repo://owner/repo/issues/1", expectedPath: "repos/owner/repo/issues/1"},
+		{uri: "repo://owner/repo/pulls/2", expectedPath: "repos/owner/repo/pulls/2"},
+		{uri: "repo://owner/repo/pulls/2/diff", expectedPath: "repos/owner/repo/pulls/2"},
+		{uri: "repo://owner/repo/actions/runs/3/logs", expectedPath: "repos/owner/repo/actions/runs/3"},
+		{uri: "repo://owner/repo/contents/docs/README.md", expectedPath: "repos/owner/repo/contents/docs/README.md"},
+		{uri: "repo://owner/repo/refs/heads/main/contents/go.mod", expectedPath: "repos/owner/repo/contents/go.mod?ref=refs%2Fheads%2Fmain"},
+		{uri: "repo://owner/repo/refs/tags/v1.0.0/contents/go.mod", expectedPath: "repos/owner/repo/contents/go.mod?ref=refs%2Ftags%2Fv1.0.0"},
+		{uri: "repo://owner/repo/refs/pull/4/head/contents/go.mod", expectedPath: "repos/owner/repo/contents/go.mod?ref=refs%2Fpull%2F4%2Fhead"},
+		{uri: "repo://owner/repo/sha/abc123/contents/go.mod", expectedPath: "repos/owner/repo/contents/go.mod?ref=abc123"},
+		{uri: "repo://owner/repo/discussions/5", expectError: true},
+		{uri: "repo://owner/repo/contents", expectError: true},
+		{uri: "https://github.com/owner/repo", expectError: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.uri, func(t *testing.T) {
+			path, err := resourceAPIPath(tc.uri)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPath, path)
+		})
+	}
+}
+
+func Test_ResourceSubscriptions(t *testing.T) {
+	// Each poll is answered by the next response in order
+	type stubbedResponse struct {
+		status int
+		etag   string
+	}
+	responses := []stubbedResponse{
+		{status: http.StatusOK, etag: `"a"`},
+		{status: http.StatusNotModified},
+		{status: http.StatusOK, etag: `"b"`},
+		{status: http.StatusInternalServerError},
+		{status: http.StatusInternalServerError},
+		{status: http.StatusNotModified},
+	}
+	var ifNoneMatch []string
+	calls := 0
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Less(t, calls, len(responses), "unexpected poll")
+				res := responses[calls]
+				calls++
+				ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+				if res.etag != "" {
+					w.Header().Set("ETag", res.etag)
+				}
+				w.WriteHeader(res.status)
+				if res.status == http.StatusOK {
+					_, _ = w.Write([]byte(`{"number": 1}`))
+				}
+			}),
+		),
+	)
+
+	var notified []string
+	subs := NewResourceSubscriptions(stubGetClientFn(github.NewClient(mockedClient)), func(uri string) {
+		notified = append(notified, uri)
+	}, ResourceSubscriptionsConfig{
+		PollInterval:     time.Minute,
+		MaxBackoff:       3 * time.Minute,
+		MaxSubscriptions: 1,
+	})
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	subs.now = func() time.Time { return now }
+
+	const uri = "repo://owner/repo/issues/1"
+	require.NoError(t, subs.Subscribe(uri))
+	require.NoError(t, subs.Subscribe(uri), "subscribing twice should be a no-op")
+	require.ErrorIs(t, subs.Subscribe("repo://owner/repo/issues/2"), ErrTooManySubscriptions)
+
+	ctx := context.Background()
+
+	// The first poll records the current state without notifying
+	subs.PollDue(ctx)
+	assert.Empty(t, notified)
+
+	// Nothing is due before the poll interval elapsed
+	now = now.Add(30 * time.Second)
+	subs.PollDue(ctx)
+	assert.Equal(t, 1, calls)
+
+	// Unchanged
+	now = now.Add(30 * time.Second)
+	subs.PollDue(ctx)
+	assert.Empty(t, notified)
+
+	// Changed
+	now = now.Add(time.Minute)
+	subs.PollDue(ctx)
+	assert.Equal(t, []string{uri}, notified)
+	assert.Equal(t, []string{"", `"a"`, `"a"`}, ifNoneMatch)
+
+	// Failures back off exponentially
+	now = now.Add(time.Minute)
+	subs.PollDue(ctx)
+	assert.Equal(t, 4, calls)
+	now = now.Add(time.Minute)
+	subs.PollDue(ctx)
+	assert.Equal(t, 4, calls, "should back off after a failure")
+	now = now.Add(time.Minute)
+	subs.PollDue(ctx)
+	assert.Equal(t, 5, calls)
+	now = now.Add(3 * time.Minute)
+	subs.PollDue(ctx)
+	assert.Equal(t, 6, calls, "backoff should be capped")
+	assert.Equal(t, `"b"`, ifNoneMatch[5])
+
+	// No polling after unsubscribing
+	subs.Unsubscribe(uri)
+	now = now.Add(time.Hour)
+	subs.PollDue(ctx)
+	assert.Equal(t, 6, calls)
+	assert.Len(t, notified, 1)
+}
@@ -0,0 +1,350 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultMaxResponseTokens is the default size limit of tool results, in approximate tokens.
+const DefaultMaxResponseTokens = 25000
+
+const (
+	// charsPerToken is the rough number of characters per token of JSON and English text
+	charsPerToken = 4
+	// minTruncatedStringChars is the length below which strings are not truncated any further
+	minTruncatedStringChars = 64
+	// defaultPerPage is the page size of tools that don't ask for one
+	defaultPerPage = 30
+)
+
+// TruncationHint tells the model that a result was cut to fit the response budget, and how to get the rest.
+// It is returned as an extra JSON text content after the truncated result.
+type TruncationHint struct {
+	Truncated      bool `json:"truncated"`
+	OriginalTokens int  `json:"original_tokens"`
+	ReturnedTokens int  `json:"returned_tokens"`
+	// ReturnedItems and OmittedItems count the items of the truncated list, if any
+	ReturnedItems int `json:"returned_items,omitempty"`
+	OmittedItems  int `json:"omitted_items,omitempty"`
+	// TruncatedStrings counts the strings that were shortened to their head and tail
+	TruncatedStrings int `json:"truncated_strings,omitempty"`
+	// NextArguments are the arguments to pass, along with the original ones, to continue where the result ends
+	NextArguments map[string]any `json:"next_arguments,omitempty"`
+	// SkipItems is the number of items at the start of the page NextArguments get that the result already holds
+	SkipItems int    `json:"skip_items,omitempty"`
+	Hint      string `json:"hint"`
+}
+
+// WithResponseBudget limits the size of the results of a tool to about maxTokens tokens. Larger results
+// are cut: lists are shortened and long strings and text keep their head and tail. A TruncationHint is
+// appended to cut results. A maxTokens of zero or less disables the limit.
+func WithResponseBudget(maxTokens int) func(server.ServerTool) server.ServerTool {
+	return func(tool server.ServerTool) server.ServerTool {
+		if maxTokens <= 0 {
+			return tool
+		}
+
+		handler := tool.Handler
+		tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := handler(ctx, request)
+			if err != nil || result == nil || result.IsError {
+				return result, err
+			}
+			budget := maxTokens * charsPerToken
+			if resultSize(result) <= budget {
+				return result, nil
+			}
+			return truncateResult(tool.Tool, request, result, budget), nil
+		}
+		return tool
+	}
+}
+
+// resultSize is the number of characters of text in a result.
+func resultSize(result *mcp.CallToolResult) int {
+	size := 0
+	for _, content := range result.Content {
+		switch c := content.(type) {
+		case mcp.TextContent:
+			size += len(c.Text)
+		case mcp.EmbeddedResource:
+			if text, ok := c.Resource.(mcp.TextResourceContents); ok {
+				size += len(text.Text)
+			}
+		}
+	}
+	return size
+}
+
+func truncateResult(tool mcp.Tool, request mcp.CallToolRequest, result *mcp.CallToolResult, budget int) *mcp.CallToolResult {
+	hint := TruncationHint{
+		Truncated:      true,
+		OriginalTokens: approxTokens(resultSize(result)),
+		Hint:           "The result was cut to fit the response size limit. Narrow the request, e.g. with filters, to get the parts that were left out.",
+	}
+
+	// Structured content is kept valid, so that it still matches the output schema of the tool
+	var structured any
+	if text, rest, ok := leadingText(result); ok {
+		jsonHint := hint
+		truncated, fits := truncateJSON(text, budget-rest, &jsonHint, tool, request)
+		if fits {
+			result.Content[0] = mcp.NewTextContent(truncated)
+			if result.StructuredContent != nil {
+				result.StructuredContent, _ = structuredContent(truncated)
+			}
+			return withHint(result, jsonHint)
+		}
+		if truncated != "" {
+			// The JSON cut as far as it goes, which is larger than the budget but still parses
+			structured, _ = structuredContent(truncated)
+		}
+	}
+
+	// Share the budget among the texts of the result, in order
+	remaining := budget
+	for i, content := range result.Content {
+		switch c := content.(type) {
+		case mcp.TextContent:
+			c.Text = truncateText(c.Text, remaining, &hint)
+			remaining -= len(c.Text)
+			result.Content[i] = c
+		case mcp.EmbeddedResource:
+			if text, ok := c.Resource.(mcp.TextResourceContents); ok {
+				text.Text = truncateText(text.Text, remaining, &hint)
+				remaining -= len(text.Text)
+				c.Resource = text
+				result.Content[i] = c
+			}
+		}
+	}
+	if result.StructuredContent != nil {
+		if resource, ok := resourceStructuredContent(result.Content); ok {
+			structured = resource
+		}
+		result.StructuredContent = structured
+	}
+	return withHint(result, hint)
+}
+
+// leadingText returns the text of the result itself, and the size of what follows it, like the
+// summary of a call following pages.
+func leadingText(result *mcp.CallToolResult) (string, int, bool) {
+	if len(result.Content) == 0 {
+		return "", 0, false
+	}
+	text, ok := result.Content[0].(mcp.TextContent)
+	return text.Text, resultSize(result) - len(text.Text), ok
+}
+
+func withHint(result *mcp.CallToolResult, hint TruncationHint) *mcp.CallToolResult {
+	hint.ReturnedTokens = approxTokens(resultSize(result))
+	data, err := json.Marshal(hint)
+	if err != nil {
+		// The hint only contains strings, numbers and arguments that came in as JSON, so this
+		// is not expected, but the cut result is still better than none
+		return result
+	}
+	result.Content = append(result.Content, mcp.NewTextContent(string(data)))
+	return result
+}
+
+func approxTokens(chars int) int {
+	return (chars + charsPerToken - 1) / charsPerToken
+}
+
+// truncateJSON fits a JSON result into budget by shortening long strings and then the largest list in it.
+// It reports false if data is not JSON or can't be made to fit, returning data cut as far as it goes
+// if it is JSON.
+func truncateJSON(data string, budget int, hint *TruncationHint, tool mcp.Tool, request mcp.CallToolRequest) (string, bool) {
+	value, err := decodeOrderedJSON([]byte(data))
+	if err != nil {
+		return "", false
+	}
+	encode := func() string {
+		return string(mustMarshal(value))
+	}
+
+	// Shorten long strings, like bodies and patches, until the result fits or they are short
+	out := data
+	for maxChars := budget / 10; len(out) > budget && maxChars >= minTruncatedStringChars; maxChars /= 2 {
+		var truncated int
+		value = truncateStrings(value, maxChars, &truncated)
+		if truncated == 0 {
+			continue
+		}
+		hint.TruncatedStrings += truncated
+		out = encode()
+	}
+	if len(out) <= budget {
+		return out, true
+	}
+
+	list, setList := largestList(&value)
+	if list == nil {
+		return out, false
+	}
+	listSize := len(mustMarshal(list))
+	kept, size := 0, len(out)-listSize+len("[]")
+	for _, item := range list {
+		itemSize := len(mustMarshal(item)) + len(",")
+		if size+itemSize > budget {
+			break
+		}
+		size += itemSize
+		kept++
+	}
+
+	hint.NextArguments, hint.SkipItems = continuationArguments(tool, request, kept)
+	if hint.NextArguments != nil {
+		hint.Hint = "The list in the result was cut to fit the response size limit. Call the tool again with next_arguments and leave out the first skip_items items of its result to continue where it ends."
+	} else {
+		hint.Hint = "The list in the result was cut to fit the response size limit. Narrow the request, e.g. with filters or a smaller perPage, to get the items that were left out."
+	}
+	hint.ReturnedItems = kept
+	hint.OmittedItems = len(list) - kept
+	setList(list[:kept])
+	if object, ok := value.(*jsonObject); ok {
+		if _, ok := object.values["truncated"]; ok {
+			// List results flag it themselves, see ListResult
+			object.values["truncated"] = true
+		}
+	}
+
+	out = encode()
+	return out, len(out) <= budget
+}
+
+func mustMarshal(value any) []byte {
+	out, err := json.Marshal(value)
+	if err != nil {
+		// Decoded JSON can always be marshaled again
+		panic(err)
+	}
+	return out
+}
+
+// continuationArguments returns the pagination arguments that continue after the first kept items of
+// the page a paginated tool returned, and the number of items at the start of the page they get that
+// were already returned.
+func continuationArguments(tool mcp.Tool, request mcp.CallToolRequest, kept int) (map[string]any, int) {
+	_, hasPage := tool.InputSchema.Properties["page"]
+	_, hasPerPage := tool.InputSchema.Properties["perPage"]
+	if !hasPage || !hasPerPage || kept == 0 {
+		return nil, 0
+	}
+	if _, followsPages := request.GetArguments()["max_items"]; followsPages {
+		// The result holds several pages, so the requested page doesn't tell where it ends
+		return nil, 0
+	}
+	page, err := OptionalIntParamWithDefault(request, "page", 1)
+	if err != nil {
+		return nil, 0
+	}
+	perPage, err := OptionalIntParamWithDefault(request, "perPage", defaultPerPage)
+	if err != nil || perPage <= 0 {
+		return nil, 0
+	}
+
+	// Resume from the page the next item is on, keeping the page size
+	offset := (page-1)*perPage + kept
+	return map[string]any{"page": offset/perPage + 1, "perPage": perPage}, offset % perPage
+}
+
+// largestList finds the list to cut: the result itself if it is a list, otherwise the largest list
+// among the fields of the result. It returns a function replacing the list in the result.
+func largestList(value *any) ([]any, func([]any)) {
+	switch v := (*value).(type) {
+	case []any:
+		return v, func(list []any) {
+			*value = list
+		}
+	case *jsonObject:
+		var largestKey string
+		largestSize := -1
+		for _, key := range v.keys {
+			if list, ok := v.values[key].([]any); ok && len(list) > 0 {
+				if size := len(mustMarshal(list)); size > largestSize {
+					largestKey, largestSize = key, size
+				}
+			}
+		}
+		if largestSize < 0 {
+			return nil, nil
+		}
+		return v.values[largestKey].([]any), func(list []any) {
+			v.values[largestKey] = list
+		}
+	default:
+		return nil, nil
+	}
+}
+
+// truncateStrings shortens the strings in value longer than maxChars to their head and tail.
+func truncateStrings(value any, maxChars int, truncated *int) any {
+	switch v := value.(type) {
+	case string:
+		if len(v) <= maxChars {
+			return v
+		}
+		*truncated++
+		return headAndTail(v, maxChars)
+	case *jsonObject:
+		for _, key := range v.keys {
+			v.values[key] = truncateStrings(v.values[key], maxChars, truncated)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = truncateStrings(item, maxChars, truncated)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// truncateText shortens text to its head and tail if it is longer than maxChars.
+func truncateText(text string, maxChars int, hint *TruncationHint) string {
+	if len(text) <= maxChars {
+		return text
+	}
+	hint.TruncatedStrings++
+	hint.Hint = "The text of the result was cut to fit the response size limit, keeping its beginning and end. Request a smaller part, e.g. a single file or fewer lines, to see the rest."
+	return headAndTail(text, maxChars)
+}
+
+// headAndTail keeps the beginning and end of s, cut at line breaks where possible, and marks what was left out.
+func headAndTail(s string, maxChars int) string {
+	marker := fmt.Sprintf("\n[... %d characters truncated ...]\n", len(s))
+	keep := maxChars - len(marker)
+	if keep <= 0 {
+		return marker
+	}
+
+	headEnd := runeBoundary(s, keep/2)
+	if i := strings.LastIndexByte(s[:headEnd], '\n'); i > headEnd/2 {
+		headEnd = i + 1
+	}
+	tailStart := runeBoundary(s, len(s)-keep/2)
+	if i := strings.IndexByte(s[tailStart:], '\n'); i >= 0 && i < (len(s)-tailStart)/2 {
+		tailStart += i + 1
+	}
+
+	marker = fmt.Sprintf("\n[... %d characters truncated ...]\n", tailStart-headEnd)
+	return s[:headEnd] + marker + s[tailStart:]
+}
+
+// runeBoundary moves i back to the start of the UTF-8 sequence it falls into.
+func runeBoundary(s string, i int) int {
+	for i > 0 && i < len(s) && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return i
+}
@@ -0,0 +1,219 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func budgetTestTool(result *mcp.CallToolResult, opts ...mcp.ToolOption) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("tool", opts...),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return result, nil
+		},
+	}
+}
+
+func truncationHint(t *testing.T, result *mcp.CallToolResult) TruncationHint {
+	t.Helper()
+	require.Len(t, result.Content, 2)
+	var hint TruncationHint
+	require.NoError(t, json.Unmarshal([]byte(result.Content[1].(mcp.TextContent).Text), &hint))
+	assert.True(t, hint.Truncated)
+	return hint
+}
+
+func Test_WithResponseBudget(t *testing.T) {
+	issues := make([]map[string]any, 30)
+	for i := range issues {
+		issues[i] = map[string]any{"number": i + 1, "title": strings.Repeat("x", 80)}
+	}
+	issuesJSON, err := json.Marshal(issues)
+	require.NoError(t, err)
+
+	t.Run("small results are left alone", func(t *testing.T) {
+		tool := WithResponseBudget(1000)(budgetTestTool(mcp.NewToolResultText(string(issuesJSON))))
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		assert.Equal(t, string(issuesJSON), getTextResult(t, result).Text)
+	})
+
+	t.Run("lists of paginated tools continue on the page they are cut in", func(t *testing.T) {
+		result := mcp.NewToolResultText(string(issuesJSON))
+		result.StructuredContent = map[string]any{}
+		tool := WithResponseBudget(300)(budgetTestTool(result, WithPagination()))
+
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{"page": float64(2), "perPage": float64(30)}))
+		require.NoError(t, err)
+
+		var returned []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &returned))
+		require.Len(t, returned, 11)
+		assert.Equal(t, float64(1), returned[0]["number"])
+		assert.Equal(t, map[string]any{"items": toAnySlice(returned)}, result.StructuredContent)
+
+		hint := truncationHint(t, result)
+		assert.Equal(t, 11, hint.ReturnedItems)
+		assert.Equal(t, 19, hint.OmittedItems)
+		assert.LessOrEqual(t, hint.ReturnedTokens, 300)
+		// The rest of page 2 is fetched again, without the 11 issues already returned
+		assert.Equal(t, map[string]any{"page": float64(2), "perPage": float64(30)}, hint.NextArguments)
+		assert.Equal(t, 11, hint.SkipItems)
+	})
+
+	t.Run("the largest list of an object is cut", func(t *testing.T) {
+		data, err := json.Marshal(map[string]any{"total_count": 1000, "items": issues})
+		require.NoError(t, err)
+		tool := WithResponseBudget(300)(budgetTestTool(mcp.NewToolResultText(string(data))))
+
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+
+		var returned struct {
+			TotalCount int              `json:"total_count"`
+			Items      []map[string]any `json:"items"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &returned))
+		assert.Equal(t, 1000, returned.TotalCount)
+		assert.Len(t, returned.Items, 11)
+
+		hint := truncationHint(t, result)
+		assert.Nil(t, hint.NextArguments)
+		assert.Equal(t, 19, hint.OmittedItems)
+	})
+
+	t.Run("list results are flagged as truncated", func(t *testing.T) {
+		data, err := json.Marshal(ListResult[map[string]any]{Items: issues, PageInfo: PageInfo{HasNext: true, NextPage: 2}})
+		require.NoError(t, err)
+		tool := WithResponseBudget(300)(budgetTestTool(mcp.NewToolResultText(string(data)), WithPagination()))
+
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+
+		var returned ListResult[map[string]any]
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &returned))
+		assert.True(t, returned.Truncated)
+		assert.Len(t, returned.Items, 10)
+		hint := truncationHint(t, result)
+		assert.Equal(t, map[string]any{"page": float64(1), "perPage": float64(30)}, hint.NextArguments)
+		assert.Equal(t, 10, hint.SkipItems)
+	})
+
+	t.Run("long strings keep their head and tail", func(t *testing.T) {
+		body := "first line\n" + strings.Repeat("middle line\n", 1000) + "last line"
+		data, err := json.Marshal(map[string]any{"number": 1, "body": body})
+		require.NoError(t, err)
+		tool := WithResponseBudget(500)(budgetTestTool(mcp.NewToolResultText(string(data))))
+
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+
+		var returned map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &returned))
+		assert.True(t, strings.HasPrefix(returned["body"].(string), "first line\n"))
+		assert.True(t, strings.HasSuffix(returned["body"].(string), "last line"))
+		assert.Contains(t, returned["body"], "characters truncated ...]")
+		assert.Equal(t, 1, truncationHint(t, result).TruncatedStrings)
+	})
+
+	t.Run("text and resources share the budget", func(t *testing.T) {
+		content := "package main\n" + strings.Repeat("// comment\n", 2000) + "func main() {}\n"
+		result := &mcp.CallToolResult{Content: []mcp.Content{
+			mcp.NewTextContent("successfully downloaded text file"),
+			mcp.NewEmbeddedResource(mcp.TextResourceContents{URI: "repo://o/r/contents/main.go", Text: content}),
+		}}
+		tool := WithResponseBudget(250)(budgetTestTool(result))
+
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		require.Len(t, result.Content, 3)
+		assert.Equal(t, "successfully downloaded text file", result.Content[0].(mcp.TextContent).Text)
+
+		text := result.Content[1].(mcp.EmbeddedResource).Resource.(mcp.TextResourceContents).Text
+		assert.LessOrEqual(t, len(text), 1000)
+		assert.True(t, strings.HasPrefix(text, "package main\n"))
+		assert.True(t, strings.HasSuffix(text, "func main() {}\n"))
+
+		var hint TruncationHint
+		require.NoError(t, json.Unmarshal([]byte(result.Content[2].(mcp.TextContent).Text), &hint))
+		assert.Equal(t, 1, hint.TruncatedStrings)
+		assert.Contains(t, hint.Hint, "keeping its beginning and end")
+	})
+
+	t.Run("structured content of cut resources is rebuilt", func(t *testing.T) {
+		content := strings.Repeat("line\n", 2000)
+		result := &mcp.CallToolResult{Content: []mcp.Content{
+			mcp.NewTextContent("successfully downloaded text file"),
+			mcp.NewEmbeddedResource(mcp.TextResourceContents{URI: "repo://o/r/contents/file.txt", MIMEType: "text/plain", Text: content}),
+		}}
+		result.StructuredContent = map[string]any{}
+		tool := WithResponseBudget(250)(budgetTestTool(result))
+
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+
+		text := result.Content[1].(mcp.EmbeddedResource).Resource.(mcp.TextResourceContents).Text
+		assert.Equal(t, EmbeddedResourceResult{
+			Message:  "successfully downloaded text file",
+			URI:      "repo://o/r/contents/file.txt",
+			MIMEType: "text/plain",
+			Text:     text,
+		}, result.StructuredContent)
+	})
+
+	t.Run("structured content stays valid when JSON can't be cut to fit", func(t *testing.T) {
+		fields := make(map[string]any, 500)
+		for i := range 500 {
+			fields[fmt.Sprintf("field_%d", i)] = strings.Repeat("x", 40)
+		}
+		data, err := json.Marshal(fields)
+		require.NoError(t, err)
+		result := mcp.NewToolResultText(string(data))
+		result.StructuredContent = map[string]any{}
+		tool := WithResponseBudget(100)(budgetTestTool(result))
+
+		result, err = tool.Handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+
+		assert.LessOrEqual(t, len(result.Content[0].(mcp.TextContent).Text), 400)
+		assert.Equal(t, fields, result.StructuredContent)
+	})
+
+	t.Run("errors are left alone", func(t *testing.T) {
+		message := strings.Repeat("x", 10000)
+		tool := WithResponseBudget(10)(budgetTestTool(mcp.NewToolResultError(message)))
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		assert.Equal(t, message, getErrorResult(t, result).Text)
+	})
+
+	t.Run("a budget of zero disables the limit", func(t *testing.T) {
+		tool := budgetTestTool(mcp.NewToolResultText("x"))
+		assert.Equal(t, fmt.Sprintf("%p", tool.Handler), fmt.Sprintf("%p", WithResponseBudget(0)(tool).Handler))
+	})
+}
+
+func Test_HeadAndTail(t *testing.T) {
+	s := strings.Repeat("é", 100)
+	truncated := headAndTail(s, 80)
+	assert.LessOrEqual(t, len(truncated), 80)
+	assert.True(t, strings.HasPrefix(truncated, "é"))
+	assert.True(t, strings.HasSuffix(truncated, "é"))
+	assert.Contains(t, truncated, "characters truncated")
+}
+
+func toAnySlice(items []map[string]any) []any {
+	out := make([]any, 0, len(items))
+	for _, item := range items {
+		out = append(out, item)
+	}
+	return out
+}
@@ -0,0 +1,264 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// restMaxResponseBytes is the largest response body github_rest_request reads.
+var restMaxResponseBytes int64 = 5 << 20
+
+// RESTAllowRule lets github_rest_request send Method to paths matching Pattern.
+// In patterns, "*" matches exactly one path segment and "**" matches one or more.
+type RESTAllowRule struct {
+	Method  string
+	Pattern string
+}
+
+// RESTAllowlist lists the write requests github_rest_request may send. GET requests
+// are always allowed.
+type RESTAllowlist []RESTAllowRule
+
+// ParseRESTAllowlist parses entries of the form "METHOD /path/pattern", e.g. "POST /repos/*/*/labels".
+func ParseRESTAllowlist(entries []string) (RESTAllowlist, error) {
+	var allowlist RESTAllowlist
+	for _, entry := range entries {
+		fields := strings.Fields(entry)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid REST allowlist entry %q: expected METHOD /path", entry)
+		}
+		method := strings.ToUpper(fields[0])
+		switch method {
+		case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			return nil, fmt.Errorf("invalid REST allowlist entry %q: unsupported method %s", entry, fields[0])
+		}
+		if !strings.HasPrefix(fields[1], "/") {
+			return nil, fmt.Errorf("invalid REST allowlist entry %q: path must start with /", entry)
+		}
+		allowlist = append(allowlist, RESTAllowRule{Method: method, Pattern: fields[1]})
+	}
+	return allowlist, nil
+}
+
+// Methods returns GET followed by the other methods of the allowlist.
+func (a RESTAllowlist) Methods() []string {
+	methods := []string{http.MethodGet}
+	for _, rule := range a {
+		found := false
+		for _, m := range methods {
+			found = found || m == rule.Method
+		}
+		if !found {
+			methods = append(methods, rule.Method)
+		}
+	}
+	return methods
+}
+
+// AllowsWrites reports whether any method other than GET is allowed.
+func (a RESTAllowlist) AllowsWrites() bool {
+	return len(a.Methods()) > 1
+}
+
+// Allows reports whether a request with method may be sent to path.
+func (a RESTAllowlist) Allows(method, path string) bool {
+	if method == http.MethodGet {
+		return true
+	}
+	for _, rule := range a {
+		if rule.Method == method && matchRESTPattern(splitRESTPath(rule.Pattern), splitRESTPath(path)) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitRESTPath(p string) []string {
+	return strings.Split(strings.Trim(p, "/"), "/")
+}
+
+func matchRESTPattern(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	switch pattern[0] {
+	case "*":
+		return len(segments) > 0 && segments[0] != "" && matchRESTPattern(pattern[1:], segments[1:])
+	case "**":
+	default:
+		return len(segments) > 0 && pattern[0] == segments[0] && matchRESTPattern(pattern[1:], segments[1:])
+	}
+	for i := 1; i <= len(segments); i++ {
+		if matchRESTPattern(pattern[1:], segments[i:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// GitHubRESTRequest creates a tool to send requests to any GitHub REST API path. Only GET
+// requests are sent unless the allowlist allows other methods for the path.
+func GitHubRESTRequest(getClient GetClientFn, allowlist RESTAllowlist, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	methods := allowlist.Methods()
+	description := "Send a GET request to a GitHub REST API path and return the response. Use this only for endpoints no other tool covers."
+	if allowlist.AllowsWrites() {
+		description = "Send a request to a GitHub REST API path and return the response. Use this only for endpoints no other tool covers. GET requests can go to any path, other methods only to these paths: "
+		rules := make([]string, 0, len(allowlist))
+		for _, rule := range allowlist {
+			rules = append(rules, rule.Method+" "+rule.Pattern)
+		}
+		description += strings.Join(rules, ", ")
+	}
+
+	return mcp.NewTool("github_rest_request",
+			mcp.WithDescription(t("TOOL_GITHUB_REST_REQUEST_DESCRIPTION", description)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GITHUB_REST_REQUEST_USER_TITLE", "Send GitHub REST API request"),
+				ReadOnlyHint: ToBoolPtr(!allowlist.AllowsWrites()),
+			}),
+			mcp.WithString("method",
+				mcp.Description("HTTP method"),
+				mcp.Enum(methods...),
+				mcp.DefaultString(http.MethodGet),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("API path, e.g. /repos/octocat/hello-world/topics. May include a query string"),
+			),
+			mcp.WithObject("query",
+				mcp.Description("Query parameters to add to the path"),
+			),
+			mcp.WithObject("body",
+				mcp.Description("JSON request body"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			method, err := OptionalParam[string](request, "method")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if method == "" {
+				method = http.MethodGet
+			}
+			method = strings.ToUpper(method)
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			page, err := OptionalIntParam(request, "page")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			perPage, err := OptionalIntParam(request, "perPage")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			u, err := url.Parse(path)
+			if err != nil || u.Scheme != "" || u.Host != "" || !strings.HasPrefix(u.Path, "/") {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid path %q: expected an API path starting with /", path)), nil
+			}
+			// The path is checked decoded, so it must be sent as it is checked: encoded slashes and
+			// dots, or any other encoding that doesn't round trip, would change it on the way to GitHub
+			escaped := strings.ToUpper(u.EscapedPath())
+			if u.RawPath != "" || strings.Contains(escaped, "%2F") || strings.Contains(escaped, "%2E") {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid path %q: must not contain encoded slashes or dots", path)), nil
+			}
+			for _, segment := range strings.Split(u.Path, "/") {
+				if segment == ".." || segment == "." {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid path %q: must not contain . or .. segments", path)), nil
+				}
+			}
+			if !allowlist.Allows(method, u.Path) {
+				return mcp.NewToolResultError(fmt.Sprintf("%s %s is not allowed, only GET requests and allowlisted paths can be sent", method, u.Path)), nil
+			}
+
+			query := u.Query()
+			if requestQuery, ok := request.GetArguments()["query"]; ok && requestQuery != nil {
+				queryMap, ok := requestQuery.(map[string]any)
+				if !ok {
+					return mcp.NewToolResultError("query must be an object"), nil
+				}
+				for key, value := range queryMap {
+					query.Set(key, restQueryValue(value))
+				}
+			}
+			if page > 0 {
+				query.Set("page", strconv.Itoa(page))
+			}
+			if perPage > 0 {
+				query.Set("per_page", strconv.Itoa(perPage))
+			}
+			u.RawQuery = query.Encode()
+
+			var body any
+			if requestBody, ok := request.GetArguments()["body"]; ok && requestBody != nil {
+				if method == http.MethodGet {
+					return mcp.NewToolResultError("GET requests can't have a body"), nil
+				}
+				body = requestBody
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			req, err := client.NewRequest(method, strings.TrimPrefix(u.String(), "/"), body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+
+			resp, err := client.BareDo(ctx, req)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to send %s %s", method, u.Path),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			respBody, err := io.ReadAll(io.LimitReader(resp.Body, restMaxResponseBytes+1))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read response body: %w", err)
+			}
+			if int64(len(respBody)) > restMaxResponseBytes {
+				return mcp.NewToolResultError(fmt.Sprintf("response is larger than %d bytes, narrow the request with query parameters or perPage", restMaxResponseBytes)), nil
+			}
+
+			if len(respBody) == 0 {
+				return MarshalledTextResult(map[string]any{"status": resp.StatusCode}), nil
+			}
+			return mcp.NewToolResultText(string(respBody)), nil
+		}
+}
+
+// restQueryValue formats a query parameter value given as JSON.
+func restQueryValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
@@ -0,0 +1,326 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseRESTAllowlist(t *testing.T) {
+	allowlist, err := ParseRESTAllowlist([]string{"post /repos/*/*/labels", "  ", "DELETE /repos/*/*/labels/*"})
+	require.NoError(t, err)
+	assert.Equal(t, RESTAllowlist{
+		{Method: "POST", Pattern: "/repos/*/*/labels"},
+		{Method: "DELETE", Pattern: "/repos/*/*/labels/*"},
+	}, allowlist)
+	assert.Equal(t, []string{"GET", "POST", "DELETE"}, allowlist.Methods())
+	assert.True(t, allowlist.AllowsWrites())
+
+	for _, entry := range []string{"POST", "POST repos/*/labels", "TRACE /repos", "POST /a /b"} {
+		_, err := ParseRESTAllowlist([]string{entry})
+		assert.Error(t, err, entry)
+	}
+
+	empty, err := ParseRESTAllowlist(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GET"}, empty.Methods())
+	assert.False(t, empty.AllowsWrites())
+}
+
+func Test_RESTAllowlist_Allows(t *testing.T) {
+	allowlist := RESTAllowlist{
+		{Method: "POST", Pattern: "/repos/*/*/labels"},
+		{Method: "DELETE", Pattern: "/repos/*/*/labels/*"},
+		{Method: "PUT", Pattern: "/repos/*"},
+		{Method: "PATCH", Pattern: "/orgs/*/**"},
+	}
+
+	tests := []struct {
+		method  string
+		path    string
+		allowed bool
+	}{
+		{"GET", "/anything/at/all", true},
+		{"POST", "/repos/octo/repo/labels", true},
+		{"POST", "/repos/octo/repo/issues/1/labels", false},
+		{"POST", "/repos/octo/labels", false},
+		{"POST", "/repos//repo/labels", false},
+		{"POST", "/repos/octo/repo/labels/bug", false},
+		{"PATCH", "/repos/octo/repo/labels", false},
+		{"DELETE", "/repos/octo/repo/labels/bug", true},
+		{"DELETE", "/repos/octo/repo/labels", false},
+		{"DELETE", "/repos/octo/repo/issues/1/labels/bug", false},
+		{"PUT", "/repos/octo", true},
+		{"PUT", "/repos/octo/repo/topics", false},
+		{"PATCH", "/orgs/octo/teams/core", true},
+		{"PATCH", "/orgs/octo", false},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.allowed, allowlist.Allows(tc.method, tc.path), "%s %s", tc.method, tc.path)
+	}
+}
+
+func Test_GitHubRESTRequest(t *testing.T) {
+	tool, _ := GitHubRESTRequest(nil, nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "github_rest_request", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "github_rest_request should be read-only without an allowlist")
+	assert.Contains(t, tool.InputSchema.Properties, "method")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"path"})
+
+	writeTool, _ := GitHubRESTRequest(nil, RESTAllowlist{{Method: "POST", Pattern: "/repos/*/*/labels"}}, translations.NullTranslationHelper)
+	assert.False(t, *writeTool.Annotations.ReadOnlyHint, "github_rest_request should not be read-only with write methods allowed")
+	assert.Contains(t, writeTool.Description, "POST /repos/*/*/labels")
+
+	tests := []struct {
+		name           string
+		allowlist      RESTAllowlist
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "sends GET request with query parameters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{Pattern: "/repos/owner/repo/topics", Method: "GET"},
+					expectQueryParams(t, map[string]string{"state": "all", "page": "2", "per_page": "5"}).andThen(
+						mockResponse(t, http.StatusOK, map[string]any{"names": []string{"go"}}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"path":    "/repos/owner/repo/topics?state=all",
+				"page":    float64(2),
+				"perPage": float64(5),
+			},
+			expectedText: `{"names":["go"]}`,
+		},
+		{
+			name: "sends allowlisted write request with body",
+			allowlist: RESTAllowlist{
+				{Method: "POST", Pattern: "/repos/*/*/labels"},
+			},
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{Pattern: "/repos/owner/repo/labels", Method: "POST"},
+					expectRequestBody(t, map[string]any{"name": "bug"}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Label{Name: github.Ptr("bug")}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method": "POST",
+				"path":   "/repos/owner/repo/labels",
+				"body":   map[string]any{"name": "bug"},
+			},
+			expectedText: `{"name":"bug"}`,
+		},
+		{
+			name: "reports status of empty responses",
+			allowlist: RESTAllowlist{
+				{Method: "DELETE", Pattern: "/repos/*/*/labels/*"},
+			},
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{Pattern: "/repos/owner/repo/labels/bug", Method: "DELETE"},
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"method": "DELETE",
+				"path":   "/repos/owner/repo/labels/bug",
+			},
+			expectedText: `{"status":204}`,
+		},
+		{
+			name:         "rejects write request without allowlist",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method": "POST",
+				"path":   "/repos/owner/repo/labels",
+			},
+			expectError:    true,
+			expectedErrMsg: "POST /repos/owner/repo/labels is not allowed",
+		},
+		{
+			name: "rejects write request to a path outside the allowlist",
+			allowlist: RESTAllowlist{
+				{Method: "POST", Pattern: "/repos/*/*/labels"},
+			},
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method": "POST",
+				"path":   "/repos/owner/repo/issues",
+			},
+			expectError:    true,
+			expectedErrMsg: "POST /repos/owner/repo/issues is not allowed",
+		},
+		{
+			name:         "rejects absolute URLs",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"path": "https://example.com/repos/owner/repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "expected an API path starting with /",
+		},
+		{
+			name:         "rejects dot segments",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"path": "/repos/owner/repo/../../user",
+			},
+			expectError:    true,
+			expectedErrMsg: "must not contain . or .. segments",
+		},
+		{
+			name:         "rejects encoded slashes that would change the allowlisted path",
+			mockedClient: mock.NewMockedHTTPClient(),
+			allowlist:    RESTAllowlist{{Method: "POST", Pattern: "/repos/*/*/labels"}},
+			requestArgs: map[string]any{
+				"method": "POST",
+				"path":   "/repos/owner%2Frepo%2Flabels",
+			},
+			expectError:    true,
+			expectedErrMsg: "must not contain encoded slashes or dots",
+		},
+		{
+			name:         "rejects encoded dot segments",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"path": "/repos/owner/repo/%2e%2e/%2E%2E/user",
+			},
+			expectError:    true,
+			expectedErrMsg: "must not contain encoded slashes or dots",
+		},
+		{
+			name: "reports API errors",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{Pattern: "/repos/owner/repo/topics", Method: "GET"},
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"path": "/repos/owner/repo/topics",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to send GET /repos/owner/repo/topics",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GitHubRESTRequest(stubGetClientFromHTTPFn(tc.mockedClient), tc.allowlist, translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.JSONEq(t, tc.expectedText, textContent.Text)
+		})
+	}
+
+	t.Run("rejects responses over the size limit", func(t *testing.T) {
+		limit := restMaxResponseBytes
+		restMaxResponseBytes = 16
+		defer func() { restMaxResponseBytes = limit }()
+
+		client := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.EndpointPattern{Pattern: "/repos/owner/repo/topics", Method: "GET"},
+				mockResponse(t, http.StatusOK, map[string]any{"names": []string{"a-long-topic-name"}}),
+			),
+		)
+		_, handler := GitHubRESTRequest(stubGetClientFromHTTPFn(client), nil, translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"path": "/repos/owner/repo/topics"}))
+		require.NoError(t, err)
+		errorContent := getErrorResult(t, result)
+		assert.Contains(t, errorContent.Text, "response is larger than 16 bytes")
+	})
+
+	t.Run("follows pages through the Link header", func(t *testing.T) {
+		pages := 0
+		client := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.EndpointPattern{Pattern: "/orgs/octo/hooks", Method: "GET"},
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					pages++
+					if r.URL.Query().Get("page") != "2" {
+						w.Header().Set("Link", `<https://api.github.com/orgs/octo/hooks?page=2&per_page=1>; rel="next"`)
+						_, _ = w.Write([]byte(`[{"id":1}]`))
+						return
+					}
+					_, _ = w.Write([]byte(`[{"id":2}]`))
+				}),
+			),
+		)
+		client.Transport = &PageLinksTransport{Transport: client.Transport}
+
+		serverTool := WithAutoPagination(0)(toolsets.NewServerTool(GitHubRESTRequest(stubGetClientFromHTTPFn(client), nil, translations.NullTranslationHelper)))
+		result, err := serverTool.Handler(context.Background(), createMCPRequest(map[string]any{
+			"path":      "/orgs/octo/hooks",
+			"max_items": float64(10),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var hooks []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(mergedText(t, result)), &hooks))
+		assert.Len(t, hooks, 2)
+		assert.Equal(t, 2, pages)
+	})
+}
+
+func Test_GitHubRESTRequest_ReadOnlyMode(t *testing.T) {
+	allowlist := RESTAllowlist{{Method: "POST", Pattern: "/repos/*/*/labels"}}
+
+	for _, readOnly := range []bool{true, false} {
+		tsg := DefaultToolsetGroup(readOnly, nil, nil, nil, nil, translations.NullTranslationHelper, 5000, nil, allowlist)
+		rest, err := tsg.GetToolset("rest")
+		require.NoError(t, err)
+		assert.True(t, rest.OptIn, "rest toolset should be opt-in")
+
+		tools := rest.GetAvailableTools()
+		require.Len(t, tools, 1)
+		assert.Equal(t, readOnly, *tools[0].Tool.Annotations.ReadOnlyHint)
+
+		method, ok := tools[0].Tool.InputSchema.Properties["method"].(map[string]any)
+		require.True(t, ok)
+		if readOnly {
+			assert.Equal(t, []string{"GET"}, method["enum"])
+		} else {
+			assert.Equal(t, []string{"GET", "POST"}, method["enum"])
+		}
+	}
+}
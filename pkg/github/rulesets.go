@@ -0,0 +1,1008 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// classicProtectionSource is the source reported for requirements of a branch protection rule.
+const classicProtectionSource = "branch protection"
+
+// rulesetRuleTypes are the rule types a ruleset can contain.
+var rulesetRuleTypes = []string{
+	string(github.RulesetRuleTypeCreation),
+	string(github.RulesetRuleTypeUpdate),
+	string(github.RulesetRuleTypeDeletion),
+	string(github.RulesetRuleTypeRequiredLinearHistory),
+	string(github.RulesetRuleTypeMergeQueue),
+	string(github.RulesetRuleTypeRequiredDeployments),
+	string(github.RulesetRuleTypeRequiredSignatures),
+	string(github.RulesetRuleTypePullRequest),
+	string(github.RulesetRuleTypeRequiredStatusChecks),
+	string(github.RulesetRuleTypeNonFastForward),
+	string(github.RulesetRuleTypeCommitMessagePattern),
+	string(github.RulesetRuleTypeCommitAuthorEmailPattern),
+	string(github.RulesetRuleTypeCommitterEmailPattern),
+	string(github.RulesetRuleTypeBranchNamePattern),
+	string(github.RulesetRuleTypeTagNamePattern),
+	string(github.RulesetRuleTypeFilePathRestriction),
+	string(github.RulesetRuleTypeMaxFilePathLength),
+	string(github.RulesetRuleTypeFileExtensionRestriction),
+	string(github.RulesetRuleTypeMaxFileSize),
+	string(github.RulesetRuleTypeWorkflows),
+	string(github.RulesetRuleTypeCodeScanning),
+}
+
+// BranchRuleRequirement is one requirement a rule puts on a branch, and the rule it comes from.
+type BranchRuleRequirement struct {
+	Rule        string `json:"rule"`
+	Requirement string `json:"requirement"`
+	Source      string `json:"source"`
+	RulesetID   int64  `json:"ruleset_id,omitempty"`
+}
+
+// MergeRequirements sums up what a pull request needs before it can be merged into a branch,
+// combining every branch protection rule and ruleset that applies to it.
+type MergeRequirements struct {
+	PullRequestRequired      bool     `json:"pull_request_required"`
+	RequiredApprovingReviews int      `json:"required_approving_reviews"`
+	CodeOwnerReview          bool     `json:"code_owner_review"`
+	LastPushApproval         bool     `json:"last_push_approval"`
+	ConversationResolution   bool     `json:"conversation_resolution"`
+	RequiredStatusChecks     []string `json:"required_status_checks,omitempty"`
+	UpToDateWithBase         bool     `json:"up_to_date_with_base"`
+	SignedCommits            bool     `json:"signed_commits"`
+	LinearHistory            bool     `json:"linear_history"`
+	MergeQueue               bool     `json:"merge_queue"`
+	RequiredDeployments      []string `json:"required_deployments,omitempty"`
+	AllowedMergeMethods      []string `json:"allowed_merge_methods"`
+}
+
+// BranchRulesEvaluation is the output type of get_branch_rules.
+type BranchRulesEvaluation struct {
+	Branch            string                  `json:"branch"`
+	Protected         bool                    `json:"protected"`
+	MergeRequirements MergeRequirements       `json:"merge_requirements"`
+	Rules             []BranchRuleRequirement `json:"rules"`
+	Notes             []string                `json:"notes,omitempty"`
+}
+
+func (e *BranchRulesEvaluation) add(rule github.RepositoryRuleType, source string, rulesetID int64, format string, args ...any) {
+	e.Rules = append(e.Rules, BranchRuleRequirement{
+		Rule:        string(rule),
+		Requirement: fmt.Sprintf(format, args...),
+		Source:      source,
+		RulesetID:   rulesetID,
+	})
+}
+
+func (e *BranchRulesEvaluation) requireChecks(checks []string, strict bool) {
+	for _, check := range checks {
+		if !slices.Contains(e.MergeRequirements.RequiredStatusChecks, check) {
+			e.MergeRequirements.RequiredStatusChecks = append(e.MergeRequirements.RequiredStatusChecks, check)
+		}
+	}
+	e.MergeRequirements.UpToDateWithBase = e.MergeRequirements.UpToDateWithBase || strict
+}
+
+func (e *BranchRulesEvaluation) requireReviews(count int, codeOwners, lastPush, threads bool) {
+	m := &e.MergeRequirements
+	m.PullRequestRequired = true
+	m.RequiredApprovingReviews = max(m.RequiredApprovingReviews, count)
+	m.CodeOwnerReview = m.CodeOwnerReview || codeOwners
+	m.LastPushApproval = m.LastPushApproval || lastPush
+	m.ConversationResolution = m.ConversationResolution || threads
+}
+
+// reviewRequirement describes the reviews a pull request needs.
+func reviewRequirement(count int, codeOwners, lastPush, threads, dismissStale bool) string {
+	var b strings.Builder
+	b.WriteString("changes must be made through a pull request")
+	switch {
+	case count == 1:
+		b.WriteString(" with at least 1 approving review")
+	case count > 1:
+		fmt.Fprintf(&b, " with at least %d approving reviews", count)
+	case codeOwners:
+		b.WriteString(" with an approving review")
+	}
+	if codeOwners {
+		b.WriteString(", including one from a code owner")
+	}
+	if lastPush {
+		b.WriteString(", and the most recent push must be approved by someone other than its author")
+	}
+	if threads {
+		b.WriteString("; all review threads must be resolved")
+	}
+	if dismissStale {
+		b.WriteString("; approvals are dismissed when new commits are pushed")
+	}
+	return b.String()
+}
+
+// addProtection adds the requirements of a branch protection rule.
+func (e *BranchRulesEvaluation) addProtection(p *github.Protection) {
+	if checks := p.GetRequiredStatusChecks(); checks != nil {
+		var contexts []string
+		if checks.Checks != nil {
+			for _, check := range *checks.Checks {
+				contexts = append(contexts, check.Context)
+			}
+		} else if checks.Contexts != nil {
+			contexts = *checks.Contexts
+		}
+		e.requireChecks(contexts, checks.Strict)
+		e.add(github.RulesetRuleTypeRequiredStatusChecks, classicProtectionSource, 0, "%s", statusCheckRequirement(contexts, checks.Strict))
+	}
+	// The nested settings of a branch protection rule are nil when they're turned off
+	threads := p.RequiredConversationResolution != nil && p.RequiredConversationResolution.Enabled
+	if reviews := p.GetRequiredPullRequestReviews(); reviews != nil {
+		e.requireReviews(reviews.RequiredApprovingReviewCount, reviews.RequireCodeOwnerReviews, reviews.RequireLastPushApproval, threads)
+		e.add(github.RulesetRuleTypePullRequest, classicProtectionSource, 0, "%s",
+			reviewRequirement(reviews.RequiredApprovingReviewCount, reviews.RequireCodeOwnerReviews, reviews.RequireLastPushApproval, threads, reviews.DismissStaleReviews))
+	} else if threads {
+		e.MergeRequirements.ConversationResolution = true
+		e.add("required_conversation_resolution", classicProtectionSource, 0, "all review threads must be resolved")
+	}
+	if p.GetRequiredSignatures().GetEnabled() {
+		e.MergeRequirements.SignedCommits = true
+		e.add(github.RulesetRuleTypeRequiredSignatures, classicProtectionSource, 0, "commits must have verified signatures")
+	}
+	if p.RequireLinearHistory != nil && p.RequireLinearHistory.Enabled {
+		e.MergeRequirements.LinearHistory = true
+		e.add(github.RulesetRuleTypeRequiredLinearHistory, classicProtectionSource, 0, "merge commits are not allowed, so pull requests must be squashed or rebased")
+	}
+	if p.GetLockBranch().GetEnabled() {
+		e.add(github.RulesetRuleTypeUpdate, classicProtectionSource, 0, "the branch is locked, and nobody can push to it")
+	} else if r := p.GetRestrictions(); r != nil {
+		var actors []string
+		actors = append(actors, minimalLogins(r.Users)...)
+		for _, team := range r.Teams {
+			actors = append(actors, "team "+team.GetSlug())
+		}
+		for _, app := range r.Apps {
+			actors = append(actors, "app "+app.GetSlug())
+		}
+		e.add(github.RulesetRuleTypeUpdate, classicProtectionSource, 0, "only these can push to the branch: %s", strings.Join(actors, ", "))
+	}
+	if p.AllowForcePushes == nil || !p.AllowForcePushes.Enabled {
+		e.add(github.RulesetRuleTypeNonFastForward, classicProtectionSource, 0, "force pushes are blocked")
+	}
+	if p.AllowDeletions == nil || !p.AllowDeletions.Enabled {
+		e.add(github.RulesetRuleTypeDeletion, classicProtectionSource, 0, "deleting the branch is blocked")
+	}
+	if p.EnforceAdmins != nil && p.EnforceAdmins.Enabled {
+		e.add("enforce_admins", classicProtectionSource, 0, "the branch protection rule applies to administrators too")
+	}
+}
+
+func statusCheckRequirement(checks []string, strict bool) string {
+	requirement := "status checks must pass: " + strings.Join(checks, ", ")
+	if len(checks) == 0 {
+		requirement = "status checks are required, but none are configured"
+	}
+	if strict {
+		requirement += "; the branch must be up to date with the base branch before merging"
+	}
+	return requirement
+}
+
+// patternRequirement describes a pattern rule such as commit_message_pattern.
+func patternRequirement(subject string, p github.PatternRuleParameters) string {
+	verbs := map[github.PatternRuleOperator]string{
+		github.PatternRuleOperatorStartsWith: "start with",
+		github.PatternRuleOperatorEndsWith:   "end with",
+		github.PatternRuleOperatorContains:   "contain",
+		github.PatternRuleOperatorRegex:      "match the regular expression",
+	}
+	must := "must"
+	if p.GetNegate() {
+		must = "must not"
+	}
+	return fmt.Sprintf("%s %s %s %q", subject, must, verbs[p.Operator], p.Pattern)
+}
+
+// rulesetSource describes the ruleset a branch rule comes from.
+func rulesetSource(m github.BranchRuleMetadata) string {
+	return fmt.Sprintf("ruleset %d (%s %s)", m.RulesetID, m.RulesetSourceType, m.RulesetSource)
+}
+
+// addBranchRules adds the requirements of the active ruleset rules for a branch.
+func (e *BranchRulesEvaluation) addBranchRules(rules *github.BranchRules) {
+	metadata := func(rule github.RepositoryRuleType, m *github.BranchRuleMetadata, requirement string) {
+		e.add(rule, rulesetSource(*m), m.RulesetID, "%s", requirement)
+	}
+
+	for _, r := range rules.Creation {
+		metadata(github.RulesetRuleTypeCreation, r, "creating matching branches is restricted")
+	}
+	for _, r := range rules.Update {
+		requirement := "pushing to the branch is restricted"
+		if r.Parameters.UpdateAllowsFetchAndMerge {
+			requirement += ", except to fetch and merge from upstream"
+		}
+		metadata(github.RulesetRuleTypeUpdate, &r.BranchRuleMetadata, requirement)
+	}
+	for _, r := range rules.Deletion {
+		metadata(github.RulesetRuleTypeDeletion, r, "deleting the branch is blocked")
+	}
+	for _, r := range rules.RequiredLinearHistory {
+		e.MergeRequirements.LinearHistory = true
+		metadata(github.RulesetRuleTypeRequiredLinearHistory, r, "merge commits are not allowed, so pull requests must be squashed or rebased")
+	}
+	for _, r := range rules.MergeQueue {
+		e.MergeRequirements.MergeQueue = true
+		method := strings.ToLower(string(r.Parameters.MergeMethod))
+		// Pull requests are merged by the queue, with its merge method only
+		e.restrictMergeMethods([]string{method})
+		metadata(github.RulesetRuleTypeMergeQueue, &r.BranchRuleMetadata,
+			fmt.Sprintf("pull requests must be merged through the merge queue, which merges with %s", method))
+	}
+	for _, r := range rules.RequiredDeployments {
+		for _, env := range r.Parameters.RequiredDeploymentEnvironments {
+			if !slices.Contains(e.MergeRequirements.RequiredDeployments, env) {
+				e.MergeRequirements.RequiredDeployments = append(e.MergeRequirements.RequiredDeployments, env)
+			}
+		}
+		metadata(github.RulesetRuleTypeRequiredDeployments, &r.BranchRuleMetadata,
+			"deployments must succeed before merging: "+strings.Join(r.Parameters.RequiredDeploymentEnvironments, ", "))
+	}
+	for _, r := range rules.RequiredSignatures {
+		e.MergeRequirements.SignedCommits = true
+		metadata(github.RulesetRuleTypeRequiredSignatures, r, "commits must have verified signatures")
+	}
+	for _, r := range rules.PullRequest {
+		p := r.Parameters
+		e.requireReviews(p.RequiredApprovingReviewCount, p.RequireCodeOwnerReview, p.RequireLastPushApproval, p.RequiredReviewThreadResolution)
+		requirement := reviewRequirement(p.RequiredApprovingReviewCount, p.RequireCodeOwnerReview, p.RequireLastPushApproval, p.RequiredReviewThreadResolution, p.DismissStaleReviewsOnPush)
+		if len(p.AllowedMergeMethods) > 0 {
+			methods := make([]string, 0, len(p.AllowedMergeMethods))
+			for _, method := range p.AllowedMergeMethods {
+				methods = append(methods, string(method))
+			}
+			requirement += "; allowed merge methods: " + strings.Join(methods, ", ")
+			e.restrictMergeMethods(methods)
+		}
+		metadata(github.RulesetRuleTypePullRequest, &r.BranchRuleMetadata, requirement)
+	}
+	for _, r := range rules.RequiredStatusChecks {
+		checks := make([]string, 0, len(r.Parameters.RequiredStatusChecks))
+		for _, check := range r.Parameters.RequiredStatusChecks {
+			checks = append(checks, check.Context)
+		}
+		e.requireChecks(checks, r.Parameters.StrictRequiredStatusChecksPolicy)
+		metadata(github.RulesetRuleTypeRequiredStatusChecks, &r.BranchRuleMetadata, statusCheckRequirement(checks, r.Parameters.StrictRequiredStatusChecksPolicy))
+	}
+	for _, r := range rules.NonFastForward {
+		metadata(github.RulesetRuleTypeNonFastForward, r, "force pushes are blocked")
+	}
+	for _, r := range rules.CommitMessagePattern {
+		metadata(github.RulesetRuleTypeCommitMessagePattern, &r.BranchRuleMetadata, patternRequirement("commit messages", r.Parameters))
+	}
+	for _, r := range rules.CommitAuthorEmailPattern {
+		metadata(github.RulesetRuleTypeCommitAuthorEmailPattern, &r.BranchRuleMetadata, patternRequirement("commit author emails", r.Parameters))
+	}
+	for _, r := range rules.CommitterEmailPattern {
+		metadata(github.RulesetRuleTypeCommitterEmailPattern, &r.BranchRuleMetadata, patternRequirement("committer emails", r.Parameters))
+	}
+	for _, r := range rules.BranchNamePattern {
+		metadata(github.RulesetRuleTypeBranchNamePattern, &r.BranchRuleMetadata, patternRequirement("branch names", r.Parameters))
+	}
+	for _, r := range rules.TagNamePattern {
+		metadata(github.RulesetRuleTypeTagNamePattern, &r.BranchRuleMetadata, patternRequirement("tag names", r.Parameters))
+	}
+	for _, r := range rules.FilePathRestriction {
+		metadata(github.RulesetRuleTypeFilePathRestriction, &r.BranchRuleMetadata,
+			"commits can't change these paths: "+strings.Join(r.Parameters.RestrictedFilePaths, ", "))
+	}
+	for _, r := range rules.MaxFilePathLength {
+		metadata(github.RulesetRuleTypeMaxFilePathLength, &r.BranchRuleMetadata,
+			fmt.Sprintf("file paths must be at most %d characters long", r.Parameters.MaxFilePathLength))
+	}
+	for _, r := range rules.FileExtensionRestriction {
+		metadata(github.RulesetRuleTypeFileExtensionRestriction, &r.BranchRuleMetadata,
+			"commits can't add files with these extensions: "+strings.Join(r.Parameters.RestrictedFileExtensions, ", "))
+	}
+	for _, r := range rules.MaxFileSize {
+		metadata(github.RulesetRuleTypeMaxFileSize, &r.BranchRuleMetadata,
+			fmt.Sprintf("files must be at most %d MB", r.Parameters.MaxFileSize))
+	}
+	for _, r := range rules.Workflows {
+		workflows := make([]string, 0, len(r.Parameters.Workflows))
+		for _, w := range r.Parameters.Workflows {
+			workflows = append(workflows, w.Path)
+		}
+		metadata(github.RulesetRuleTypeWorkflows, &r.BranchRuleMetadata, "these workflows must pass: "+strings.Join(workflows, ", "))
+	}
+	for _, r := range rules.CodeScanning {
+		tools := make([]string, 0, len(r.Parameters.CodeScanningTools))
+		for _, tool := range r.Parameters.CodeScanningTools {
+			tools = append(tools, fmt.Sprintf("%s (alerts: %s, security alerts: %s)", tool.Tool, tool.AlertsThreshold, tool.SecurityAlertsThreshold))
+		}
+		metadata(github.RulesetRuleTypeCodeScanning, &r.BranchRuleMetadata, "code scanning results must stay under their thresholds: "+strings.Join(tools, ", "))
+	}
+}
+
+// repositoryMergeMethods returns the merge methods the settings of a repository allow. It reports
+// false if the settings are missing, as they are for users without push access to the repository.
+func repositoryMergeMethods(repository *github.Repository) ([]string, bool) {
+	if repository.AllowMergeCommit == nil && repository.AllowSquashMerge == nil && repository.AllowRebaseMerge == nil {
+		return []string{"merge", "squash", "rebase"}, false
+	}
+	methods := []string{}
+	if repository.GetAllowMergeCommit() {
+		methods = append(methods, "merge")
+	}
+	if repository.GetAllowSquashMerge() {
+		methods = append(methods, "squash")
+	}
+	if repository.GetAllowRebaseMerge() {
+		methods = append(methods, "rebase")
+	}
+	return methods, true
+}
+
+// restrictMergeMethods keeps only the allowed merge methods that are also in methods.
+func (e *BranchRulesEvaluation) restrictMergeMethods(methods []string) {
+	e.MergeRequirements.AllowedMergeMethods = slices.DeleteFunc(e.MergeRequirements.AllowedMergeMethods, func(method string) bool {
+		return !slices.Contains(methods, method)
+	})
+}
+
+// GetBranchRules creates a tool that evaluates the branch protection rule and rulesets that apply to a branch.
+func GetBranchRules(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_branch_rules",
+			mcp.WithDescription(t("TOOL_GET_BRANCH_RULES_DESCRIPTION", "Get the branch protection rule and the active ruleset rules that apply to a branch, with a summary of what a pull request needs to be merged into it: reviews, status checks, signed commits, linear history and more. Each requirement names the rule it comes from. Use this to find out why a push or merge was rejected")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_BRANCH_RULES_USER_TITLE", "Get branch rules"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
+			),
+			WithOutputSchema[BranchRulesEvaluation](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branchName, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			branch, resp, err := client.Repositories.GetBranch(ctx, owner, repo, branchName, 1)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get branch: %s", branchName),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get repository: %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			mergeMethods, known := repositoryMergeMethods(repository)
+
+			evaluation := BranchRulesEvaluation{
+				Branch:    branch.GetName(),
+				Protected: branch.GetProtected(),
+				MergeRequirements: MergeRequirements{
+					AllowedMergeMethods: mergeMethods,
+				},
+				Rules: []BranchRuleRequirement{},
+			}
+			if !known {
+				evaluation.Notes = append(evaluation.Notes, "the merge methods the repository settings allow can't be read without push access to the repository, so all of them are assumed to be allowed")
+			}
+
+			if branch.GetProtected() {
+				protection, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branchName)
+				switch {
+				case err == nil:
+					_ = resp.Body.Close()
+					evaluation.addProtection(protection)
+				case errors.Is(err, github.ErrBranchNotProtected):
+					// The branch is protected only by rulesets
+				case resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound):
+					// Reading a branch protection rule needs admin access, but its
+					// required status checks are part of the branch
+					if protection := branch.GetProtection(); protection.GetRequiredStatusChecks() != nil {
+						evaluation.addProtection(&github.Protection{
+							RequiredStatusChecks: protection.RequiredStatusChecks,
+							AllowForcePushes:     &github.AllowForcePushes{Enabled: true},
+							AllowDeletions:       &github.AllowDeletions{Enabled: true},
+						})
+					}
+					evaluation.Notes = append(evaluation.Notes, "the branch protection rule can't be read without admin access to the repository, so only its required status checks are listed")
+				default:
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get branch protection: %s", branchName),
+						resp,
+						err,
+					), nil
+				}
+			}
+
+			opts := &github.ListOptions{PerPage: 100}
+			for {
+				rules, resp, err := client.Repositories.GetRulesForBranch(ctx, owner, repo, branchName, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get rules for branch: %s", branchName),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				evaluation.addBranchRules(rules)
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			if evaluation.MergeRequirements.LinearHistory {
+				evaluation.restrictMergeMethods([]string{"squash", "rebase"})
+			}
+
+			return MarshalledTextResult(evaluation), nil
+		}
+}
+
+// ListRulesets creates a tool to list the rulesets of a repository.
+func ListRulesets(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_rulesets",
+			mcp.WithDescription(t("TOOL_LIST_RULESETS_DESCRIPTION", "List the rulesets of a GitHub repository, including those its organization or enterprise applies to it. Use get_ruleset to see the rules and conditions of a ruleset")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_RULESETS_USER_TITLE", "List rulesets"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithBoolean("includes_parents",
+				mcp.Description("Include rulesets configured at the organization or enterprise level. Defaults to true"),
+			),
+			WithPagination(),
+			WithOutputSchema[ListResult[*github.RepositoryRuleset]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includesParents, err := OptionalBoolParamWithDefault(request, "includes_parents", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			rulesets, resp, err := client.Repositories.GetAllRulesets(ctx, owner, repo, &github.RepositoryListRulesetsOptions{
+				IncludesParents: github.Ptr(includesParents),
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list rulesets",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			return MarshalledTextResult(restListResult(rulesets, resp)), nil
+		}
+}
+
+// GetRuleset creates a tool to get a ruleset with its rules and conditions.
+func GetRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_ruleset",
+			mcp.WithDescription(t("TOOL_GET_RULESET_DESCRIPTION", "Get a ruleset of a GitHub repository, with its rules, the refs it applies to and who can bypass it")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RULESET_USER_TITLE", "Get ruleset"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("ruleset_id",
+				mcp.Required(),
+				mcp.Description("The ID of the ruleset"),
+			),
+			WithOutputSchema[*github.RepositoryRuleset](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rulesetID, err := RequiredInt(request, "ruleset_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			ruleset, resp, err := client.Repositories.GetRuleset(ctx, owner, repo, int64(rulesetID), true)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get ruleset: %d", rulesetID),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			return MarshalledTextResult(ruleset), nil
+		}
+}
+
+// withRulesetParams adds the ruleset fields shared by create_ruleset and update_ruleset.
+func withRulesetParams(required bool) mcp.ToolOption {
+	requiredOpt := mcp.PropertyOption(func(map[string]any) {})
+	if required {
+		requiredOpt = mcp.Required()
+	}
+	return func(tool *mcp.Tool) {
+		mcp.WithString("name",
+			requiredOpt,
+			mcp.Description("Ruleset name"),
+		)(tool)
+		mcp.WithString("target",
+			mcp.Description("What the ruleset applies to. Defaults to branch when creating a ruleset"),
+			mcp.Enum(string(github.RulesetTargetBranch), string(github.RulesetTargetTag), string(github.RulesetTargetPush)),
+		)(tool)
+		mcp.WithString("enforcement",
+			mcp.Description("active enforces the rules, evaluate only reports what they would block. Defaults to active when creating a ruleset"),
+			mcp.Enum(string(github.RulesetEnforcementActive), string(github.RulesetEnforcementEvaluate), string(github.RulesetEnforcementDisabled)),
+		)(tool)
+		mcp.WithArray("include",
+			mcp.Description("Refs the ruleset applies to, such as main, release/*, refs/heads/dev, ~DEFAULT_BRANCH or ~ALL. Names without a refs/ prefix are branches, or tags for tag rulesets"),
+			mcp.WithStringItems(),
+		)(tool)
+		mcp.WithArray("exclude",
+			mcp.Description("Refs excluded from the ruleset, in the same format as include"),
+			mcp.WithStringItems(),
+		)(tool)
+		mcp.WithArray("rules",
+			requiredOpt,
+			mcp.Description("The rules of the ruleset, in the format of the GitHub REST API, such as {\"type\": \"pull_request\", \"parameters\": {\"required_approving_review_count\": 1, ...}} or {\"type\": \"required_signatures\"}. When updating a ruleset, these replace all its rules"),
+			mcp.Items(map[string]any{
+				"type":     "object",
+				"required": []string{"type"},
+				"properties": map[string]any{
+					"type": map[string]any{
+						"type": "string",
+						"enum": rulesetRuleTypes,
+					},
+					"parameters": map[string]any{
+						"type":        "object",
+						"description": "parameters of the rule, as documented for the GitHub REST API",
+					},
+				},
+			}),
+		)(tool)
+		mcp.WithArray("bypass_actors",
+			mcp.Description("Who can bypass the ruleset. When updating a ruleset, these replace all its bypass actors, and an empty array removes them"),
+			mcp.Items(map[string]any{
+				"type":                 "object",
+				"additionalProperties": false,
+				"required":             []string{"actor_type"},
+				"properties": map[string]any{
+					"actor_id": map[string]any{
+						"type":        "number",
+						"description": "ID of the team, app or repository role. Not needed for OrganizationAdmin",
+					},
+					"actor_type": map[string]any{
+						"type": "string",
+						"enum": []string{
+							string(github.BypassActorTypeIntegration),
+							string(github.BypassActorTypeOrganizationAdmin),
+							string(github.BypassActorTypeRepositoryRole),
+							string(github.BypassActorTypeTeam),
+							string(github.BypassActorTypeDeployKey),
+						},
+					},
+					"bypass_mode": map[string]any{
+						"type":        "string",
+						"enum":        []string{string(github.BypassModeAlways), string(github.BypassModePullRequest)},
+						"description": "always, or only when merging pull requests. Defaults to always",
+					},
+				},
+			}),
+		)(tool)
+	}
+}
+
+// parseRulesetRules converts the rules parameter to ruleset rules.
+func parseRulesetRules(raw any) (*github.RepositoryRulesetRules, error) {
+	items, ok := raw.([]any)
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("rules must be a non-empty array of objects")
+	}
+	seen := make(map[string]bool)
+	for i, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("rule %d must be an object", i)
+		}
+		ruleType, _ := m["type"].(string)
+		if !slices.Contains(rulesetRuleTypes, ruleType) {
+			return nil, fmt.Errorf("rule %d has unknown type %q", i, ruleType)
+		}
+		if seen[ruleType] {
+			return nil, fmt.Errorf("rule %s is given more than once", ruleType)
+		}
+		seen[ruleType] = true
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rules: %w", err)
+	}
+	var rules github.RepositoryRulesetRules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("invalid rule parameters: %w", err)
+	}
+	return &rules, nil
+}
+
+// parseBypassActors converts the bypass_actors parameter to bypass actors.
+func parseBypassActors(raw any) ([]*github.BypassActor, error) {
+	items, ok := raw.([]any)
+	if !ok {
+		return nil, fmt.Errorf("bypass_actors must be an array of objects")
+	}
+	actors := make([]*github.BypassActor, 0, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("bypass actor %d must be an object", i)
+		}
+		actorType, _ := m["actor_type"].(string)
+		if actorType == "" {
+			return nil, fmt.Errorf("bypass actor %d needs an actor_type", i)
+		}
+		actor := &github.BypassActor{
+			ActorType:  github.Ptr(github.BypassActorType(actorType)),
+			BypassMode: github.Ptr(github.BypassModeAlways),
+		}
+		if id, ok := m["actor_id"].(float64); ok {
+			actor.ActorID = github.Ptr(int64(id))
+		} else if actorType != string(github.BypassActorTypeOrganizationAdmin) && actorType != string(github.BypassActorTypeDeployKey) {
+			return nil, fmt.Errorf("bypass actor %d needs an actor_id", i)
+		}
+		if mode, _ := m["bypass_mode"].(string); mode != "" {
+			actor.BypassMode = github.Ptr(github.BypassMode(mode))
+		}
+		actors = append(actors, actor)
+	}
+	return actors, nil
+}
+
+// qualifyRefPatterns prefixes ref patterns that aren't full refs with refs/heads/ or refs/tags/.
+func qualifyRefPatterns(patterns []string, target github.RulesetTarget) []string {
+	prefix := "refs/heads/"
+	if target == github.RulesetTargetTag {
+		prefix = "refs/tags/"
+	}
+	qualified := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		if !strings.HasPrefix(p, "refs/") && !strings.HasPrefix(p, "~") {
+			p = prefix + p
+		}
+		qualified = append(qualified, p)
+	}
+	return qualified
+}
+
+// rulesetTarget returns the target of a ruleset, which is branch if it isn't set.
+func rulesetTarget(ruleset *github.RepositoryRuleset) github.RulesetTarget {
+	if ruleset.Target == nil {
+		return github.RulesetTargetBranch
+	}
+	return *ruleset.Target
+}
+
+// applyRulesetParams sets the ruleset fields given in the request.
+// It reports whether bypass_actors was given.
+func applyRulesetParams(request mcp.CallToolRequest, ruleset *github.RepositoryRuleset) (bool, error) {
+	name, err := OptionalParam[string](request, "name")
+	if err != nil {
+		return false, err
+	}
+	if name != "" {
+		ruleset.Name = name
+	}
+	target, err := OptionalParam[string](request, "target")
+	if err != nil {
+		return false, err
+	}
+	if target != "" {
+		ruleset.Target = github.Ptr(github.RulesetTarget(target))
+	}
+	enforcement, err := OptionalParam[string](request, "enforcement")
+	if err != nil {
+		return false, err
+	}
+	if enforcement != "" {
+		ruleset.Enforcement = github.RulesetEnforcement(enforcement)
+	}
+
+	include, err := OptionalStringArrayParam(request, "include")
+	if err != nil {
+		return false, err
+	}
+	exclude, err := OptionalStringArrayParam(request, "exclude")
+	if err != nil {
+		return false, err
+	}
+	if len(include) > 0 || len(exclude) > 0 {
+		if rulesetTarget(ruleset) == github.RulesetTargetPush {
+			return false, fmt.Errorf("push rulesets apply to the whole repository, so they don't take include or exclude")
+		}
+		conditions := ruleset.GetConditions()
+		if conditions == nil {
+			conditions = &github.RepositoryRulesetConditions{}
+		}
+		refName := conditions.RefName
+		if refName == nil {
+			refName = &github.RepositoryRulesetRefConditionParameters{Include: []string{}, Exclude: []string{}}
+		}
+		if len(include) > 0 {
+			refName.Include = qualifyRefPatterns(include, rulesetTarget(ruleset))
+		}
+		if len(exclude) > 0 {
+			refName.Exclude = qualifyRefPatterns(exclude, rulesetTarget(ruleset))
+		}
+		conditions.RefName = refName
+		ruleset.Conditions = conditions
+	}
+
+	if raw, ok := request.GetArguments()["rules"]; ok {
+		rules, err := parseRulesetRules(raw)
+		if err != nil {
+			return false, err
+		}
+		ruleset.Rules = rules
+	}
+
+	raw, ok := request.GetArguments()["bypass_actors"]
+	if !ok {
+		return false, nil
+	}
+	actors, err := parseBypassActors(raw)
+	if err != nil {
+		return false, err
+	}
+	ruleset.BypassActors = actors
+	return true, nil
+}
+
+// CreateRuleset creates a tool to create a ruleset in a repository.
+func CreateRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_ruleset",
+			mcp.WithDescription(t("TOOL_CREATE_RULESET_DESCRIPTION", "Create a ruleset in a GitHub repository, to protect branches or tags with rules such as required reviews, status checks or signed commits")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_RULESET_USER_TITLE", "Create ruleset"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			withRulesetParams(true),
+			WithOutputSchema[*github.RepositoryRuleset](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, err := RequiredParam[string](request, "name"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			ruleset := github.RepositoryRuleset{
+				Target:      github.Ptr(github.RulesetTargetBranch),
+				Enforcement: github.RulesetEnforcementActive,
+			}
+			if _, err := applyRulesetParams(request, &ruleset); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if ruleset.Rules == nil {
+				return mcp.NewToolResultError("missing required parameter: rules"), nil
+			}
+			if rulesetTarget(&ruleset) != github.RulesetTargetPush && ruleset.GetConditions().GetRefName() == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("include is required for %s rulesets", rulesetTarget(&ruleset))), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Repositories.CreateRuleset(ctx, owner, repo, ruleset)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create ruleset: %s", ruleset.Name),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			return MarshalledTextResult(created), nil
+		}
+}
+
+// UpdateRuleset creates a tool to update a ruleset of a repository.
+func UpdateRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_ruleset",
+			mcp.WithDescription(t("TOOL_UPDATE_RULESET_DESCRIPTION", "Update a ruleset of a GitHub repository. Only the given fields change. Rulesets of an organization or enterprise can't be updated from a repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_UPDATE_RULESET_USER_TITLE", "Update ruleset"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("ruleset_id",
+				mcp.Required(),
+				mcp.Description("The ID of the ruleset"),
+			),
+			withRulesetParams(false),
+			WithOutputSchema[*github.RepositoryRuleset](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rulesetID, err := RequiredInt(request, "ruleset_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			existing, resp, err := client.Repositories.GetRuleset(ctx, owner, repo, int64(rulesetID), false)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get ruleset: %d", rulesetID),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			if sourceType := existing.SourceType; sourceType != nil && *sourceType != github.RulesetSourceTypeRepository {
+				return mcp.NewToolResultError(fmt.Sprintf("ruleset %d belongs to %s %s, and can't be updated from this repository", rulesetID, strings.ToLower(string(*sourceType)), existing.Source)), nil
+			}
+
+			// Send only the writable fields, starting from the current ruleset
+			ruleset := github.RepositoryRuleset{
+				Name:         existing.Name,
+				Target:       existing.Target,
+				Enforcement:  existing.Enforcement,
+				BypassActors: existing.BypassActors,
+				Conditions:   existing.Conditions,
+				Rules:        existing.Rules,
+			}
+			bypassActorsGiven, err := applyRulesetParams(request, &ruleset)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var updated *github.RepositoryRuleset
+			if bypassActorsGiven && len(ruleset.BypassActors) == 0 {
+				// UpdateRuleset leaves out an empty bypass_actors, which would keep the current ones
+				updated, resp, err = client.Repositories.UpdateRulesetNoBypassActor(ctx, owner, repo, int64(rulesetID), ruleset)
+			} else {
+				updated, resp, err = client.Repositories.UpdateRuleset(ctx, owner, repo, int64(rulesetID), ruleset)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update ruleset: %d", rulesetID),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			return MarshalledTextResult(updated), nil
+		}
+}
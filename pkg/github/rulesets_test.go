@@ -0,0 +1,678 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetBranchRules(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GetBranchRules(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_branch_rules", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "get_branch_rules tool should be read-only")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	protectedBranch := func() mock.MockBackendOption {
+		return mock.WithRequestMatch(
+			mock.GetReposBranchesByOwnerByRepoByBranch,
+			&github.Branch{
+				Name:      github.Ptr("main"),
+				Protected: github.Ptr(true),
+				Protection: &github.Protection{
+					RequiredStatusChecks: &github.RequiredStatusChecks{Contexts: &[]string{"build"}},
+				},
+			},
+		)
+	}
+	repository := func(settings *github.Repository) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.GetReposByOwnerByRepo,
+			expectPath(t, "/repos/owner/repo").andThen(
+				mockResponse(t, http.StatusOK, settings),
+			),
+		)
+	}
+	allMergeMethods := &github.Repository{
+		AllowMergeCommit: github.Ptr(true),
+		AllowSquashMerge: github.Ptr(true),
+		AllowRebaseMerge: github.Ptr(true),
+	}
+	branchRules := mock.WithRequestMatchHandler(
+		mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+		expectPath(t, "/repos/owner/repo/rules/branches/main").andThen(
+			mockResponse(t, http.StatusOK, []map[string]any{
+				{
+					"type":                "pull_request",
+					"ruleset_source_type": "Repository",
+					"ruleset_source":      "owner/repo",
+					"ruleset_id":          42,
+					"parameters": map[string]any{
+						"allowed_merge_methods":             []string{"merge", "squash"},
+						"dismiss_stale_reviews_on_push":     false,
+						"require_code_owner_review":         true,
+						"require_last_push_approval":        false,
+						"required_approving_review_count":   2,
+						"required_review_thread_resolution": true,
+					},
+				},
+				{
+					"type":                "required_status_checks",
+					"ruleset_source_type": "Organization",
+					"ruleset_source":      "owner",
+					"ruleset_id":          7,
+					"parameters": map[string]any{
+						"required_status_checks":               []map[string]any{{"context": "build"}, {"context": "lint"}},
+						"strict_required_status_checks_policy": true,
+					},
+				},
+				{
+					"type":                "required_linear_history",
+					"ruleset_source_type": "Repository",
+					"ruleset_source":      "owner/repo",
+					"ruleset_id":          42,
+				},
+				{
+					"type":                "commit_message_pattern",
+					"ruleset_source_type": "Repository",
+					"ruleset_source":      "owner/repo",
+					"ruleset_id":          42,
+					"parameters": map[string]any{
+						"operator": "starts_with",
+						"pattern":  "JIRA-",
+					},
+				},
+			}),
+		),
+	)
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectError        bool
+		expectedErrMsg     string
+		expectedMerge      MergeRequirements
+		expectedRules      []BranchRuleRequirement
+		expectedNotesCount int
+	}{
+		{
+			name: "branch protection and rulesets",
+			mockedClient: mock.NewMockedHTTPClient(
+				protectedBranch(),
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					&github.Protection{
+						RequiredStatusChecks: &github.RequiredStatusChecks{Strict: false, Contexts: &[]string{"build"}},
+						RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{
+							RequiredApprovingReviewCount: 1,
+							DismissStaleReviews:          true,
+						},
+						RequiredSignatures: &github.SignaturesProtectedBranch{Enabled: github.Ptr(true)},
+						AllowForcePushes:   &github.AllowForcePushes{Enabled: false},
+						AllowDeletions:     &github.AllowDeletions{Enabled: true},
+					},
+				),
+				repository(allMergeMethods),
+				branchRules,
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectedMerge: MergeRequirements{
+				PullRequestRequired:      true,
+				RequiredApprovingReviews: 2,
+				CodeOwnerReview:          true,
+				ConversationResolution:   true,
+				RequiredStatusChecks:     []string{"build", "lint"},
+				UpToDateWithBase:         true,
+				SignedCommits:            true,
+				LinearHistory:            true,
+				AllowedMergeMethods:      []string{"squash"},
+			},
+			expectedRules: []BranchRuleRequirement{
+				{Rule: "required_status_checks", Requirement: "status checks must pass: build", Source: "branch protection"},
+				{Rule: "pull_request", Requirement: "changes must be made through a pull request with at least 1 approving review; approvals are dismissed when new commits are pushed", Source: "branch protection"},
+				{Rule: "required_signatures", Requirement: "commits must have verified signatures", Source: "branch protection"},
+				{Rule: "non_fast_forward", Requirement: "force pushes are blocked", Source: "branch protection"},
+				{Rule: "required_linear_history", Requirement: "merge commits are not allowed, so pull requests must be squashed or rebased", Source: "ruleset 42 (Repository owner/repo)", RulesetID: 42},
+				{Rule: "pull_request", Requirement: "changes must be made through a pull request with at least 2 approving reviews, including one from a code owner; all review threads must be resolved; allowed merge methods: merge, squash", Source: "ruleset 42 (Repository owner/repo)", RulesetID: 42},
+				{Rule: "required_status_checks", Requirement: "status checks must pass: build, lint; the branch must be up to date with the base branch before merging", Source: "ruleset 7 (Organization owner)", RulesetID: 7},
+				{Rule: "commit_message_pattern", Requirement: `commit messages must start with "JIRA-"`, Source: "ruleset 42 (Repository owner/repo)", RulesetID: 42},
+			},
+		},
+		{
+			name: "branch protection needs admin access",
+			mockedClient: mock.NewMockedHTTPClient(
+				protectedBranch(),
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Resource not accessible by integration"}),
+				),
+				repository(&github.Repository{Name: github.Ptr("repo")}),
+				mock.WithRequestMatch(mock.GetReposRulesBranchesByOwnerByRepoByBranch, []any{}),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectedMerge: MergeRequirements{
+				RequiredStatusChecks: []string{"build"},
+				AllowedMergeMethods:  []string{"merge", "squash", "rebase"},
+			},
+			expectedRules: []BranchRuleRequirement{
+				{Rule: "required_status_checks", Requirement: "status checks must pass: build", Source: "branch protection"},
+			},
+			expectedNotesCount: 2,
+		},
+		{
+			name: "unprotected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepoByBranch, &github.Branch{Name: github.Ptr("dev"), Protected: github.Ptr(false)}),
+				repository(allMergeMethods),
+				mock.WithRequestMatch(mock.GetReposRulesBranchesByOwnerByRepoByBranch, []any{}),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "dev",
+			},
+			expectedMerge: MergeRequirements{
+				AllowedMergeMethods: []string{"merge", "squash", "rebase"},
+			},
+			expectedRules: []BranchRuleRequirement{},
+		},
+		{
+			name: "merge methods disabled in the repository settings",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepoByBranch, &github.Branch{Name: github.Ptr("dev"), Protected: github.Ptr(false)}),
+				repository(&github.Repository{
+					AllowMergeCommit: github.Ptr(false),
+					AllowSquashMerge: github.Ptr(true),
+					AllowRebaseMerge: github.Ptr(true),
+				}),
+				mock.WithRequestMatch(mock.GetReposRulesBranchesByOwnerByRepoByBranch, []map[string]any{
+					{
+						"type":                "pull_request",
+						"ruleset_source_type": "Repository",
+						"ruleset_source":      "owner/repo",
+						"ruleset_id":          42,
+						"parameters": map[string]any{
+							"allowed_merge_methods":             []string{"merge", "squash"},
+							"dismiss_stale_reviews_on_push":     false,
+							"require_code_owner_review":         false,
+							"require_last_push_approval":        false,
+							"required_approving_review_count":   0,
+							"required_review_thread_resolution": false,
+						},
+					},
+				}),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "dev",
+			},
+			expectedMerge: MergeRequirements{
+				PullRequestRequired: true,
+				AllowedMergeMethods: []string{"squash"},
+			},
+			expectedRules: []BranchRuleRequirement{
+				{Rule: "pull_request", Requirement: "changes must be made through a pull request; allowed merge methods: merge, squash", Source: "ruleset 42 (Repository owner/repo)", RulesetID: 42},
+			},
+		},
+		{
+			name: "rules on several pages and a merge queue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepoByBranch, &github.Branch{Name: github.Ptr("dev"), Protected: github.Ptr(false)}),
+				repository(allMergeMethods),
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if r.URL.Query().Get("page") == "2" {
+							mockResponse(t, http.StatusOK, []map[string]any{
+								{
+									"type":                "merge_queue",
+									"ruleset_source_type": "Repository",
+									"ruleset_source":      "owner/repo",
+									"ruleset_id":          43,
+									"parameters": map[string]any{
+										"check_response_timeout_minutes":    60,
+										"grouping_strategy":                 "ALLGREEN",
+										"max_entries_to_build":              5,
+										"max_entries_to_merge":              5,
+										"merge_method":                      "SQUASH",
+										"min_entries_to_merge":              1,
+										"min_entries_to_merge_wait_minutes": 5,
+									},
+								},
+							})(w, r)
+							return
+						}
+						w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/rules/branches/dev?page=2&per_page=100>; rel="next"`)
+						mockResponse(t, http.StatusOK, []map[string]any{
+							{
+								"type":                "non_fast_forward",
+								"ruleset_source_type": "Repository",
+								"ruleset_source":      "owner/repo",
+								"ruleset_id":          42,
+							},
+						})(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "dev",
+			},
+			expectedMerge: MergeRequirements{
+				MergeQueue:          true,
+				AllowedMergeMethods: []string{"squash"},
+			},
+			expectedRules: []BranchRuleRequirement{
+				{Rule: "non_fast_forward", Requirement: "force pushes are blocked", Source: "ruleset 42 (Repository owner/repo)", RulesetID: 42},
+				{Rule: "merge_queue", Requirement: "pull requests must be merged through the merge queue, which merges with squash", Source: "ruleset 43 (Repository owner/repo)", RulesetID: 43},
+			},
+		},
+		{
+			name: "branch not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Branch not found"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get branch: missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetBranchRules(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var evaluation BranchRulesEvaluation
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &evaluation))
+			assert.Equal(t, tc.expectedMerge, evaluation.MergeRequirements)
+			assert.Equal(t, tc.expectedRules, evaluation.Rules)
+			assert.Len(t, evaluation.Notes, tc.expectedNotesCount)
+		})
+	}
+}
+
+func Test_ListRulesets(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := ListRulesets(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_rulesets", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "list_rulesets tool should be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "includes_parents")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposRulesetsByOwnerByRepo,
+			expectQueryParams(t, map[string]string{
+				"includes_parents": "false",
+				"page":             "1",
+				"per_page":         "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.RepositoryRuleset{
+					{ID: github.Ptr(int64(42)), Name: "protect main", Enforcement: github.RulesetEnforcementActive, Source: "owner/repo"},
+				}),
+			),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := ListRulesets(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":            "owner",
+		"repo":             "repo",
+		"includes_parents": false,
+	}))
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+	var returned ListResult[*github.RepositoryRuleset]
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	require.Len(t, returned.Items, 1)
+	assert.Equal(t, int64(42), returned.Items[0].GetID())
+	assert.Equal(t, "protect main", returned.Items[0].Name)
+}
+
+func Test_GetRuleset(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GetRuleset(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_ruleset", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "get_ruleset tool should be read-only")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ruleset_id"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+			expectPath(t, "/repos/owner/repo/rulesets/42").andThen(
+				mockResponse(t, http.StatusOK, &github.RepositoryRuleset{
+					ID:          github.Ptr(int64(42)),
+					Name:        "protect main",
+					Enforcement: github.RulesetEnforcementActive,
+					Rules:       &github.RepositoryRulesetRules{RequiredSignatures: &github.EmptyRuleParameters{}},
+				}),
+			),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := GetRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":      "owner",
+		"repo":       "repo",
+		"ruleset_id": float64(42),
+	}))
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+	var returned github.RepositoryRuleset
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	assert.Equal(t, "protect main", returned.Name)
+	assert.NotNil(t, returned.Rules.RequiredSignatures)
+}
+
+func Test_CreateRuleset(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := CreateRuleset(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_ruleset", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint, "create_ruleset tool should not be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "include")
+	assert.Contains(t, tool.InputSchema.Properties, "bypass_actors")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name", "rules"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "create branch ruleset",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposRulesetsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"name":        "protect main",
+						"target":      "branch",
+						"source":      "",
+						"enforcement": "active",
+						"conditions": map[string]any{
+							"ref_name": map[string]any{
+								"include": []any{"refs/heads/main", "~DEFAULT_BRANCH"},
+								"exclude": []any{},
+							},
+						},
+						"rules": []any{
+							map[string]any{"type": "required_linear_history"},
+							map[string]any{"type": "required_signatures"},
+						},
+						"bypass_actors": []any{
+							map[string]any{"actor_id": float64(5), "actor_type": "Team", "bypass_mode": "pull_request"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.RepositoryRuleset{ID: github.Ptr(int64(42)), Name: "protect main"}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"name":    "protect main",
+				"include": []any{"main", "~DEFAULT_BRANCH"},
+				"rules": []any{
+					map[string]any{"type": "required_signatures"},
+					map[string]any{"type": "required_linear_history"},
+				},
+				"bypass_actors": []any{
+					map[string]any{"actor_id": float64(5), "actor_type": "Team", "bypass_mode": "pull_request"},
+				},
+			},
+		},
+		{
+			name:         "missing include",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "protect main",
+				"rules": []any{map[string]any{"type": "deletion"}},
+			},
+			expectError:    true,
+			expectedErrMsg: "include is required for branch rulesets",
+		},
+		{
+			name:         "unknown rule type",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"name":    "protect main",
+				"include": []any{"main"},
+				"rules":   []any{map[string]any{"type": "require_tests"}},
+			},
+			expectError:    true,
+			expectedErrMsg: `rule 0 has unknown type "require_tests"`,
+		},
+		{
+			name:         "team without an id",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"name":          "protect main",
+				"include":       []any{"main"},
+				"rules":         []any{map[string]any{"type": "deletion"}},
+				"bypass_actors": []any{map[string]any{"actor_type": "Team"}},
+			},
+			expectError:    true,
+			expectedErrMsg: "bypass actor 0 needs an actor_id",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned github.RepositoryRuleset
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, int64(42), returned.GetID())
+		})
+	}
+}
+
+func Test_UpdateRuleset(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := UpdateRuleset(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_ruleset", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint, "update_ruleset tool should be destructive")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ruleset_id"})
+
+	existing := &github.RepositoryRuleset{
+		ID:          github.Ptr(int64(42)),
+		Name:        "protect main",
+		Target:      github.Ptr(github.RulesetTargetBranch),
+		SourceType:  github.Ptr(github.RulesetSourceTypeRepository),
+		Source:      "owner/repo",
+		Enforcement: github.RulesetEnforcementActive,
+		BypassActors: []*github.BypassActor{
+			{ActorID: github.Ptr(int64(5)), ActorType: github.Ptr(github.BypassActorTypeTeam), BypassMode: github.Ptr(github.BypassModeAlways)},
+		},
+		Conditions: &github.RepositoryRulesetConditions{
+			RefName: &github.RepositoryRulesetRefConditionParameters{Include: []string{"~DEFAULT_BRANCH"}, Exclude: []string{}},
+		},
+		Rules: &github.RepositoryRulesetRules{Deletion: &github.EmptyRuleParameters{}},
+	}
+	getExisting := func() mock.MockBackendOption {
+		return mock.WithRequestMatch(mock.GetReposRulesetsByOwnerByRepoByRulesetId, existing)
+	}
+	conditions := map[string]any{
+		"ref_name": map[string]any{
+			"include": []any{"~DEFAULT_BRANCH"},
+			"exclude": []any{},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "change enforcement only",
+			mockedClient: mock.NewMockedHTTPClient(
+				getExisting(),
+				mock.WithRequestMatchHandler(
+					mock.PutReposRulesetsByOwnerByRepoByRulesetId,
+					expectRequestBody(t, map[string]any{
+						"name":        "protect main",
+						"target":      "branch",
+						"source":      "",
+						"enforcement": "evaluate",
+						"conditions":  conditions,
+						"rules":       []any{map[string]any{"type": "deletion"}},
+						"bypass_actors": []any{
+							map[string]any{"actor_id": float64(5), "actor_type": "Team", "bypass_mode": "always"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, existing),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"ruleset_id":  float64(42),
+				"enforcement": "evaluate",
+			},
+		},
+		{
+			name: "remove bypass actors",
+			mockedClient: mock.NewMockedHTTPClient(
+				getExisting(),
+				mock.WithRequestMatchHandler(
+					mock.PutReposRulesetsByOwnerByRepoByRulesetId,
+					expectRequestBody(t, map[string]any{
+						"name":          "protect main",
+						"target":        "branch",
+						"source":        "",
+						"enforcement":   "active",
+						"conditions":    conditions,
+						"rules":         []any{map[string]any{"type": "deletion"}},
+						"bypass_actors": []any{},
+					}).andThen(
+						mockResponse(t, http.StatusOK, existing),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"ruleset_id":    float64(42),
+				"bypass_actors": []any{},
+			},
+		},
+		{
+			name: "organization ruleset",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposRulesetsByOwnerByRepoByRulesetId, &github.RepositoryRuleset{
+					ID:         github.Ptr(int64(7)),
+					Name:       "org rules",
+					SourceType: github.Ptr(github.RulesetSourceTypeOrganization),
+					Source:     "owner",
+				}),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"ruleset_id":  float64(7),
+				"enforcement": "disabled",
+			},
+			expectError:    true,
+			expectedErrMsg: "ruleset 7 belongs to organization owner, and can't be updated from this repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned github.RepositoryRuleset
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, int64(42), returned.GetID())
+		})
+	}
+}
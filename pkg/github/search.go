@@ -31,6 +31,7 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 				mcp.DefaultBool(true),
 			),
 			WithPagination(),
+			WithOutputSchema[*github.RepositoriesSearchResult](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			query, err := RequiredParam[string](request, "query")
@@ -149,6 +150,7 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				mcp.Enum("asc", "desc"),
 			),
 			WithPagination(),
+			WithOutputSchema[*github.CodeSearchResult](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			query, err := RequiredParam[string](request, "query")
@@ -318,6 +320,7 @@ func SearchUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			mcp.Enum("asc", "desc"),
 		),
 		WithPagination(),
+		WithOutputSchema[MinimalSearchUsersResult](),
 	), userOrOrgHandler("user", getClient)
 }
 
@@ -343,5 +346,6 @@ func SearchOrgs(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			mcp.Enum("asc", "desc"),
 		),
 		WithPagination(),
+		WithOutputSchema[MinimalSearchUsersResult](),
 	), userOrOrgHandler("org", getClient)
 }
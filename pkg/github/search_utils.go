@@ -77,6 +77,10 @@ func searchHandler(
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	minimalOutput, err := OptionalMinimalOutput(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	opts := &github.SearchOptions{
 		// Default to "created" if no sort is provided, as it's a common use case.
@@ -106,6 +110,10 @@ func searchHandler(
 		return mcp.NewToolResultError(fmt.Sprintf("%s: %s", errorPrefix, string(body))), nil
 	}
 
+	if minimalOutput {
+		return MarshalledTextResult(convertToMinimalSearchIssuesResult(result)), nil
+	}
+
 	r, err := json.Marshal(result)
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to marshal response: %w", errorPrefix, err)
@@ -60,6 +60,7 @@ func ListGlobalSecurityAdvisories(getClient GetClientFn, t translations.Translat
 			mcp.WithString("modified",
 				mcp.Description("Filter by publish or update date or date range (ISO 8601 date or range)."),
 			),
+			WithOutputSchema[[]*github.GlobalSecurityAdvisory](),
 		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := getClient(ctx)
 			if err != nil {
@@ -209,6 +210,7 @@ func ListRepositorySecurityAdvisories(getClient GetClientFn, t translations.Tran
 				mcp.Description("Filter by advisory state."),
 				mcp.Enum("triage", "draft", "published", "closed"),
 			),
+			WithOutputSchema[[]*github.SecurityAdvisory](),
 		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
 			if err != nil {
@@ -282,6 +284,7 @@ func GetGlobalSecurityAdvisory(getClient GetClientFn, t translations.Translation
 				mcp.Description("GitHub Security Advisory ID (format: GHSA-xxxx-xxxx-xxxx)."),
 				mcp.Required(),
 			),
+			WithOutputSchema[*github.GlobalSecurityAdvisory](),
 		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := getClient(ctx)
 			if err != nil {
@@ -339,6 +342,7 @@ func ListOrgRepositorySecurityAdvisories(getClient GetClientFn, t translations.T
 				mcp.Description("Filter by advisory state."),
 				mcp.Enum("triage", "draft", "published", "closed"),
 			),
+			WithOutputSchema[[]*github.SecurityAdvisory](),
 		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			org, err := RequiredParam[string](request, "org")
 			if err != nil {
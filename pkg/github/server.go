@@ -16,7 +16,9 @@ func NewServer(version string, opts ...server.ServerOption) *server.MCPServer {
 	// Add default options
 	defaultOpts := []server.ServerOption{
 		server.WithToolCapabilities(true),
-		server.WithResourceCapabilities(true, true),
+		// Subscriptions are only advertised by transports that deliver the updates, see
+		// ResourceSubscriptions
+		server.WithResourceCapabilities(false, true),
 		server.WithLogging(),
 	}
 	opts = append(defaultOpts, opts...)
@@ -189,7 +191,8 @@ func OptionalStringArrayParam(r mcp.CallToolRequest, p string) ([]string, error)
 	}
 }
 
-// WithPagination adds REST API pagination parameters to a tool.
+// WithPagination adds REST API pagination parameters to a tool, along with the parameters to follow
+// the next pages in one call, see WithAutoPagination.
 // https://docs.github.com/en/rest/using-the-rest-api/using-pagination-in-the-rest-api
 func WithPagination() mcp.ToolOption {
 	return func(tool *mcp.Tool) {
@@ -203,6 +206,8 @@ func WithPagination() mcp.ToolOption {
 			mcp.Min(1),
 			mcp.Max(100),
 		)(tool)
+
+		withAutoPaginationParams(tool)
 	}
 }
 
@@ -222,8 +227,10 @@ func WithUnifiedPagination() mcp.ToolOption {
 		)(tool)
 
 		mcp.WithString("after",
-			mcp.Description("Cursor for pagination. Use the endCursor from the previous page's PageInfo for GraphQL APIs."),
+			mcp.Description("Cursor for pagination. Use the end_cursor from the page_info of the previous page for GraphQL APIs."),
 		)(tool)
+
+		withAutoPaginationParams(tool)
 	}
 }
 
@@ -237,11 +244,32 @@ func WithCursorPagination() mcp.ToolOption {
 		)(tool)
 
 		mcp.WithString("after",
-			mcp.Description("Cursor for pagination. Use the endCursor from the previous page's PageInfo for GraphQL APIs."),
+			mcp.Description("Cursor for pagination. Use the end_cursor from the page_info of the previous page for GraphQL APIs."),
 		)(tool)
+
+		withAutoPaginationParams(tool)
 	}
 }
 
+// WithMinimalOutput adds a minimal_output parameter to a tool, which returns M, a curated version of its
+// result with only the essential fields, when it is set. The output schema of the tool is extended to
+// describe M as well, so this must follow WithOutputSchema.
+func WithMinimalOutput[M any]() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithBoolean("minimal_output",
+			mcp.Description("Return only the essential fields instead of the full GitHub API objects, leaving out bodies of listed items (default: false)"),
+			mcp.DefaultBool(false),
+		)(tool)
+
+		tool.RawOutputSchema = OutputSchemaAnyOf(tool.RawOutputSchema, OutputSchema[M]())
+	}
+}
+
+// OptionalMinimalOutput returns the "minimal_output" parameter from the request, false if not present.
+func OptionalMinimalOutput(r mcp.CallToolRequest) (bool, error) {
+	return OptionalBoolParamWithDefault(r, "minimal_output", false)
+}
+
 type PaginationParams struct {
 	Page    int
 	PerPage int
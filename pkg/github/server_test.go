@@ -10,8 +10,11 @@ import (
 
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func stubGetClientFn(client *github.Client) GetClientFn {
@@ -59,6 +62,25 @@ func badRequestHandler(msg string) http.HandlerFunc {
 	}
 }
 
+func Test_NewServerResourceCapabilities(t *testing.T) {
+	initialize := []byte(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1.0"}}}`)
+	resourceCapabilities := func(s *server.MCPServer) *struct {
+		Subscribe   bool `json:"subscribe,omitempty"`
+		ListChanged bool `json:"listChanged,omitempty"`
+	} {
+		response, ok := s.HandleMessage(context.Background(), initialize).(mcp.JSONRPCResponse)
+		require.True(t, ok)
+		result, ok := response.Result.(mcp.InitializeResult)
+		require.True(t, ok)
+		require.NotNil(t, result.Capabilities.Resources)
+		return result.Capabilities.Resources
+	}
+
+	// Only transports that deliver resource updates advertise subscriptions
+	assert.False(t, resourceCapabilities(NewServer("test")).Subscribe)
+	assert.True(t, resourceCapabilities(NewServer("test", server.WithResourceCapabilities(true, true))).Subscribe)
+}
+
 func Test_IsAcceptedError(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -16,7 +16,7 @@ type GetGQLClientFn func(context.Context) (*githubv4.Client, error)
 
 var DefaultTools = []string{"all"}
 
-func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc, contentWindowSize int) *toolsets.ToolsetGroup {
+func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, getGQLRawClient GetGQLRawClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc, contentWindowSize int, repoResolver *RepoContextResolver, restAllowlist RESTAllowlist) *toolsets.ToolsetGroup {
 	tsg := toolsets.NewToolsetGroup(readOnly)
 
 	// Define all available features with their default state (disabled)
@@ -25,23 +25,44 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 		AddReadTools(
 			toolsets.NewServerTool(SearchRepositories(getClient, t)),
 			toolsets.NewServerTool(GetFileContents(getClient, getRawClient, t)),
+			toolsets.NewServerTool(GetFiles(getClient, getRawClient, t)),
+			toolsets.NewServerTool(GetFileBlame(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(GetRepositoryTree(getClient, t)),
 			toolsets.NewServerTool(ListCommits(getClient, t)),
 			toolsets.NewServerTool(SearchCode(getClient, t)),
+			toolsets.NewServerTool(GrepRepository(getClient, t)),
 			toolsets.NewServerTool(GetCommit(getClient, t)),
+			toolsets.NewServerTool(CompareRefs(getClient, t, contentWindowSize)),
 			toolsets.NewServerTool(ListBranches(getClient, t)),
 			toolsets.NewServerTool(ListTags(getClient, t)),
 			toolsets.NewServerTool(GetTag(getClient, t)),
 			toolsets.NewServerTool(ListReleases(getClient, t)),
 			toolsets.NewServerTool(GetLatestRelease(getClient, t)),
 			toolsets.NewServerTool(GetReleaseByTag(getClient, t)),
+			toolsets.NewServerTool(GenerateReleaseNotes(getClient, t)),
 			toolsets.NewServerTool(ListStarredRepositories(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateOrUpdateFile(getClient, t)),
 			toolsets.NewServerTool(CreateRepository(getClient, t)),
+			toolsets.NewServerTool(CreateRepositoryFromTemplate(getClient, t)),
+			toolsets.NewServerTool(UpdateRepository(getClient, t)),
+			toolsets.NewServerTool(ArchiveRepository(getClient, t)),
+			toolsets.NewServerTool(UnarchiveRepository(getClient, t)),
+			toolsets.NewServerTool(TransferRepository(getClient, t)),
 			toolsets.NewServerTool(ForkRepository(getClient, t)),
+			toolsets.NewServerTool(SyncFork(getClient, t)),
 			toolsets.NewServerTool(CreateBranch(getClient, t)),
+			toolsets.NewServerTool(CreateTag(getClient, t)),
+			toolsets.NewServerTool(UpdateRef(getClient, t)),
+			toolsets.NewServerTool(DeleteRef(getClient, t)),
 			toolsets.NewServerTool(PushFiles(getClient, t)),
+			toolsets.NewServerTool(CommitChanges(getClient, t)),
+			toolsets.NewServerTool(ApplyPatch(getClient, t)),
+			toolsets.NewServerTool(CreateRelease(getClient, t)),
+			toolsets.NewServerTool(UpdateRelease(getClient, t)),
+			toolsets.NewServerTool(DeleteRelease(getClient, t)),
+			toolsets.NewServerTool(UploadReleaseAsset(getClient, t)),
 			toolsets.NewServerTool(DeleteFile(getClient, t)),
 			toolsets.NewServerTool(StarRepository(getClient, t)),
 			toolsets.NewServerTool(UnstarRepository(getClient, t)),
@@ -53,6 +74,16 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerResourceTemplate(GetRepositoryResourceTagContent(getClient, getRawClient, t)),
 			toolsets.NewServerResourceTemplate(GetRepositoryResourcePrContent(getClient, getRawClient, t)),
 		)
+	rulesets := toolsets.NewToolset("rulesets", "Branch protection rules and repository rulesets").
+		AddReadTools(
+			toolsets.NewServerTool(GetBranchRules(getClient, t)),
+			toolsets.NewServerTool(ListRulesets(getClient, t)),
+			toolsets.NewServerTool(GetRuleset(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateRuleset(getClient, t)),
+			toolsets.NewServerTool(UpdateRuleset(getClient, t)),
+		)
 	issues := toolsets.NewToolset("issues", "GitHub Issues related tools").
 		AddReadTools(
 			toolsets.NewServerTool(GetIssue(getClient, t)),
@@ -70,6 +101,9 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(AddSubIssue(getClient, t)),
 			toolsets.NewServerTool(RemoveSubIssue(getClient, t)),
 			toolsets.NewServerTool(ReprioritizeSubIssue(getClient, t)),
+		).
+		AddResourceTemplates(
+			toolsets.NewServerResourceTemplate(GetIssueResource(getClient, t)),
 		).AddPrompts(
 		toolsets.NewServerPrompt(AssignCodingAgentPrompt(t)),
 		toolsets.NewServerPrompt(IssueToFixWorkflowPrompt(t)),
@@ -106,6 +140,10 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(AddCommentToPendingReview(getGQLClient, t)),
 			toolsets.NewServerTool(SubmitPendingPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(DeletePendingPullRequestReview(getGQLClient, t)),
+		).
+		AddResourceTemplates(
+			toolsets.NewServerResourceTemplate(GetPullRequestResource(getClient, t)),
+			toolsets.NewServerResourceTemplate(GetPullRequestDiffResource(getClient, t)),
 		)
 	codeSecurity := toolsets.NewToolset("code_security", "Code security related tools, such as GitHub Code Scanning").
 		AddReadTools(
@@ -141,6 +179,9 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(GetDiscussion(getGQLClient, t)),
 			toolsets.NewServerTool(GetDiscussionComments(getGQLClient, t)),
 			toolsets.NewServerTool(ListDiscussionCategories(getGQLClient, t)),
+		).
+		AddResourceTemplates(
+			toolsets.NewServerResourceTemplate(GetDiscussionResource(getGQLClient, t)),
 		)
 
 	actions := toolsets.NewToolset("actions", "GitHub Actions workflows and CI/CD operations").
@@ -161,6 +202,9 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(RerunFailedJobs(getClient, t)),
 			toolsets.NewServerTool(CancelWorkflowRun(getClient, t)),
 			toolsets.NewServerTool(DeleteWorkflowRunLogs(getClient, t)),
+		).
+		AddResourceTemplates(
+			toolsets.NewServerResourceTemplate(GetWorkflowRunLogsResource(getClient, t, contentWindowSize)),
 		)
 
 	securityAdvisories := toolsets.NewToolset("security_advisories", "Security advisories related tools").
@@ -180,6 +224,10 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(GetTeams(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(GetTeamMembers(getGQLClient, t)),
 		)
+	if repoResolver != nil {
+		// get_context reports the repository tools default to, which only happens with repo inference
+		contextTools.AddReadTools(toolsets.NewServerTool(GetContext(repoResolver, t)))
+	}
 
 	gists := toolsets.NewToolset("gists", "GitHub Gist related tools").
 		AddReadTools(
@@ -197,9 +245,30 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(ListProjectFields(getClient, t)),
 		)
 
+	// Opt-in as it lets the model run queries no other tool was reviewed for
+	graphQL := toolsets.NewToolset("graphql", "Read-only GitHub GraphQL queries for questions the other tools can't answer").
+		AddReadTools(
+			toolsets.NewServerTool(GraphQLQuery(getGQLRawClient, t)),
+		)
+	graphQL.OptIn = true
+
+	// Read-only mode only ever sends GET requests
+	if readOnly {
+		restAllowlist = nil
+	}
+	rest := toolsets.NewToolset("rest", "Requests to any GitHub REST API path, for endpoints without a dedicated tool")
+	restRequest := toolsets.NewServerTool(GitHubRESTRequest(getClient, restAllowlist, t))
+	if restAllowlist.AllowsWrites() {
+		rest.AddWriteTools(restRequest)
+	} else {
+		rest.AddReadTools(restRequest)
+	}
+	rest.OptIn = true
+
 	// Add toolsets to the group
 	tsg.AddToolset(contextTools)
 	tsg.AddToolset(repos)
+	tsg.AddToolset(rulesets)
 	tsg.AddToolset(issues)
 	tsg.AddToolset(orgs)
 	tsg.AddToolset(users)
@@ -214,6 +283,11 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	tsg.AddToolset(gists)
 	tsg.AddToolset(securityAdvisories)
 	tsg.AddToolset(projects)
+	tsg.AddToolset(graphQL)
+	tsg.AddToolset(rest)
+
+	// Return the results of tools with an output schema as structured content too
+	tsg.UpdateTools(StructuredContent)
 
 	return tsg
 }
@@ -229,6 +303,7 @@ func InitDynamicToolset(s *server.MCPServer, tsg *toolsets.ToolsetGroup, t trans
 			toolsets.NewServerTool(EnableToolset(s, tsg, t)),
 		)
 
+	dynamicToolSelection.UpdateTools(StructuredContent)
 	dynamicToolSelection.Enabled = true
 	return dynamicToolSelection
 }
@@ -0,0 +1,199 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// clientLoggerName identifies the server as the source of the log messages sent to the client.
+const clientLoggerName = "github-mcp-server"
+
+// ClientHandler is a slog.Handler that sends records logged while handling a request to the client
+// of that request as notifications/message, honoring the level the client set with logging/setLevel.
+// Records logged outside of a request are dropped.
+type ClientHandler struct {
+	// goas holds the groups and attributes added with WithGroup and WithAttrs, in order
+	goas []groupOrAttrs
+}
+
+type groupOrAttrs struct {
+	group string
+	attrs []slog.Attr
+}
+
+// NewClientHandler creates a handler that forwards records to MCP clients.
+func NewClientHandler() *ClientHandler {
+	return &ClientHandler{}
+}
+
+// Enabled reports whether the client of the request in ctx wants records at level.
+func (h *ClientHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	session, ok := server.ClientSessionFromContext(ctx).(server.SessionWithLogging)
+	if !ok || !session.Initialized() {
+		return false
+	}
+	return loggingLevel(level).ShouldSendTo(session.GetLogLevel())
+}
+
+// Handle sends the record to the client of the request in ctx.
+func (h *ClientHandler) Handle(ctx context.Context, r slog.Record) error {
+	s := server.ServerFromContext(ctx)
+	if s == nil {
+		return nil
+	}
+
+	data := map[string]any{"message": r.Message}
+	fields := data
+	for _, goa := range h.goas {
+		if goa.group != "" {
+			nested := map[string]any{}
+			fields[goa.group] = nested
+			fields = nested
+			continue
+		}
+		for _, attr := range goa.attrs {
+			addAttr(fields, attr)
+		}
+	}
+	r.Attrs(func(attr slog.Attr) bool {
+		addAttr(fields, attr)
+		return true
+	})
+
+	err := s.SendLogMessageToClient(ctx, mcp.NewLoggingMessageNotification(loggingLevel(r.Level), clientLoggerName, data))
+	if errors.Is(err, server.ErrNotificationNotInitialized) || errors.Is(err, server.ErrSessionDoesNotSupportLogging) {
+		return nil
+	}
+	return err
+}
+
+// WithAttrs returns a handler that includes attrs in every record.
+func (h *ClientHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.with(groupOrAttrs{attrs: attrs})
+}
+
+// WithGroup returns a handler that nests the attributes of every record in a group.
+func (h *ClientHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.with(groupOrAttrs{group: name})
+}
+
+func (h *ClientHandler) with(goa groupOrAttrs) *ClientHandler {
+	goas := make([]groupOrAttrs, 0, len(h.goas)+1)
+	goas = append(goas, h.goas...)
+	return &ClientHandler{goas: append(goas, goa)}
+}
+
+func addAttr(fields map[string]any, attr slog.Attr) {
+	value := attr.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindGroup:
+		group := value.Group()
+		if len(group) == 0 {
+			return
+		}
+		// Inline groups without a key, as slog handlers do
+		target := fields
+		if attr.Key != "" {
+			nested, ok := fields[attr.Key].(map[string]any)
+			if !ok {
+				nested = map[string]any{}
+				fields[attr.Key] = nested
+			}
+			target = nested
+		}
+		for _, a := range group {
+			addAttr(target, a)
+		}
+	case slog.KindAny:
+		if attr.Key == "" {
+			return
+		}
+		if err, ok := value.Any().(error); ok {
+			// Errors marshal to empty objects, send their message instead
+			fields[attr.Key] = err.Error()
+			return
+		}
+		fields[attr.Key] = value.Any()
+	default:
+		if attr.Key == "" {
+			return
+		}
+		fields[attr.Key] = value.Any()
+	}
+}
+
+// loggingLevel maps a slog level to the closest MCP logging level.
+func loggingLevel(level slog.Level) mcp.LoggingLevel {
+	switch {
+	case level >= slog.LevelError:
+		return mcp.LoggingLevelError
+	case level >= slog.LevelWarn:
+		return mcp.LoggingLevelWarning
+	case level >= slog.LevelInfo:
+		return mcp.LoggingLevelInfo
+	default:
+		return mcp.LoggingLevelDebug
+	}
+}
+
+// MultiHandler is a slog.Handler that passes every record to several handlers.
+type MultiHandler struct {
+	handlers []slog.Handler
+}
+
+// NewMultiHandler creates a handler that fans records out to handlers.
+func NewMultiHandler(handlers ...slog.Handler) *MultiHandler {
+	return &MultiHandler{handlers: handlers}
+}
+
+// Enabled reports whether any of the handlers is enabled for level.
+func (h *MultiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle passes the record to every handler enabled for its level.
+func (h *MultiHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, handler := range h.handlers {
+		if !handler.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := handler.Handle(ctx, r.Clone()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs returns a handler whose handlers all include attrs.
+func (h *MultiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, 0, len(h.handlers))
+	for _, handler := range h.handlers {
+		handlers = append(handlers, handler.WithAttrs(attrs))
+	}
+	return &MultiHandler{handlers: handlers}
+}
+
+// WithGroup returns a handler whose handlers all use the group.
+func (h *MultiHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, 0, len(h.handlers))
+	for _, handler := range h.handlers {
+		handlers = append(handlers, handler.WithGroup(name))
+	}
+	return &MultiHandler{handlers: handlers}
+}
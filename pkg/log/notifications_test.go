@@ -0,0 +1,108 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type loggingSession struct {
+	level         mcp.LoggingLevel
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *loggingSession) Initialize()                                         {}
+func (s *loggingSession) Initialized() bool                                   { return true }
+func (s *loggingSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s *loggingSession) SessionID() string                                   { return "session" }
+func (s *loggingSession) SetLogLevel(level mcp.LoggingLevel)                  { s.level = level }
+func (s *loggingSession) GetLogLevel() mcp.LoggingLevel                       { return s.level }
+
+// handleRequest runs fn as a tool call, with a context like the one the server hands to tool handlers.
+func handleRequest(ctx context.Context, s *server.MCPServer, fn func(ctx context.Context)) {
+	s.AddTool(mcp.NewTool("log"), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		fn(ctx)
+		return mcp.NewToolResultText("ok"), nil
+	})
+	s.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"log"}}`))
+}
+
+func TestClientHandler(t *testing.T) {
+	s := server.NewMCPServer("test", "1.0.0", server.WithLogging())
+	session := &loggingSession{level: mcp.LoggingLevelWarning, notifications: make(chan mcp.JSONRPCNotification, 10)}
+	ctx := s.WithContext(context.Background(), session)
+
+	logger := slog.New(NewClientHandler()).With("tool", "get_me").WithGroup("request")
+
+	t.Run("records below the client level are dropped", func(t *testing.T) {
+		handleRequest(ctx, s, func(ctx context.Context) {
+			logger.InfoContext(ctx, "calling API")
+		})
+		assert.Empty(t, session.notifications)
+	})
+
+	t.Run("records are sent with their attributes", func(t *testing.T) {
+		handleRequest(ctx, s, func(ctx context.Context) {
+			logger.WarnContext(ctx, "rate limit running low", "remaining", 10, "error", errors.New("boom"))
+		})
+		require.Len(t, session.notifications, 1)
+
+		notification := <-session.notifications
+		assert.Equal(t, "notifications/message", notification.Method)
+		assert.Equal(t, mcp.LoggingLevelWarning, notification.Params.AdditionalFields["level"])
+		assert.Equal(t, "github-mcp-server", notification.Params.AdditionalFields["logger"])
+		assert.Equal(t, map[string]any{
+			"message": "rate limit running low",
+			"tool":    "get_me",
+			"request": map[string]any{
+				"remaining": int64(10),
+				"error":     "boom",
+			},
+		}, notification.Params.AdditionalFields["data"])
+	})
+
+	t.Run("the client can change its level", func(t *testing.T) {
+		session.SetLogLevel(mcp.LoggingLevelDebug)
+		handleRequest(ctx, s, func(ctx context.Context) {
+			logger.DebugContext(ctx, "details")
+		})
+		require.Len(t, session.notifications, 1)
+		<-session.notifications
+	})
+
+	t.Run("records outside of a request are dropped", func(t *testing.T) {
+		logger.ErrorContext(context.Background(), "starting")
+		assert.Empty(t, session.notifications)
+	})
+}
+
+func TestMultiHandler(t *testing.T) {
+	s := server.NewMCPServer("test", "1.0.0", server.WithLogging())
+	session := &loggingSession{level: mcp.LoggingLevelError, notifications: make(chan mcp.JSONRPCNotification, 10)}
+	ctx := s.WithContext(context.Background(), session)
+
+	var logBuffer bytes.Buffer
+	logger := slog.New(NewMultiHandler(
+		slog.NewTextHandler(&logBuffer, &slog.HandlerOptions{ReplaceAttr: removeTimeAttr}),
+		NewClientHandler(),
+	))
+
+	handleRequest(ctx, s, func(ctx context.Context) {
+		logger.InfoContext(ctx, "only in the log file")
+		logger.ErrorContext(ctx, "everywhere")
+	})
+
+	assert.Contains(t, logBuffer.String(), "only in the log file")
+	assert.Contains(t, logBuffer.String(), "everywhere")
+	require.Len(t, session.notifications, 1)
+	notification := <-session.notifications
+	assert.Equal(t, "everywhere", notification.Params.AdditionalFields["data"].(map[string]any)["message"])
+}
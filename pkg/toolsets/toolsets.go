@@ -52,9 +52,11 @@ type Toolset struct {
 	Name        string
 	Description string
 	Enabled     bool
-	readOnly    bool
-	writeTools  []server.ServerTool
-	readTools   []server.ServerTool
+	// OptIn toolsets are left out of "all" and have to be enabled by name.
+	OptIn      bool
+	readOnly   bool
+	writeTools []server.ServerTool
+	readTools  []server.ServerTool
 	// resources are not tools, but the community seems to be moving towards namespaces as a broader concept
 	// and in order to have multiple servers running concurrently, we want to avoid overlapping resources too.
 	resourceTemplates []server.ServerResourceTemplate
@@ -93,6 +95,16 @@ func (t *Toolset) RegisterTools(s *server.MCPServer) {
 	}
 }
 
+// UpdateTools replaces every tool in the toolset with the result of fn, e.g. to adjust tool schemas before registration.
+func (t *Toolset) UpdateTools(fn func(server.ServerTool) server.ServerTool) {
+	for i, tool := range t.readTools {
+		t.readTools[i] = fn(tool)
+	}
+	for i, tool := range t.writeTools {
+		t.writeTools[i] = fn(tool)
+	}
+}
+
 func (t *Toolset) AddResourceTemplates(templates ...server.ServerResourceTemplate) *Toolset {
 	t.resourceTemplates = append(t.resourceTemplates, templates...)
 	return t
@@ -192,11 +204,11 @@ func NewToolset(name string, description string) *Toolset {
 
 func (tg *ToolsetGroup) IsEnabled(name string) bool {
 	// If everythingOn is true, all features are enabled
-	if tg.everythingOn {
+	feature, exists := tg.Toolsets[name]
+	if tg.everythingOn && (!exists || !feature.OptIn) {
 		return true
 	}
 
-	feature, exists := tg.Toolsets[name]
 	if !exists {
 		return false
 	}
@@ -208,7 +220,7 @@ func (tg *ToolsetGroup) EnableToolsets(names []string) error {
 	for _, name := range names {
 		if name == "all" {
 			tg.everythingOn = true
-			break
+			continue
 		}
 		err := tg.EnableToolset(name)
 		if err != nil {
@@ -217,7 +229,10 @@ func (tg *ToolsetGroup) EnableToolsets(names []string) error {
 	}
 	// Do this after to ensure all toolsets are enabled if "all" is present anywhere in list
 	if tg.everythingOn {
-		for name := range tg.Toolsets {
+		for name, toolset := range tg.Toolsets {
+			if toolset.OptIn {
+				continue
+			}
 			err := tg.EnableToolset(name)
 			if err != nil {
 				return err
@@ -246,6 +261,13 @@ func (tg *ToolsetGroup) RegisterAll(s *server.MCPServer) {
 	}
 }
 
+// UpdateTools replaces every tool in every toolset of the group with the result of fn.
+func (tg *ToolsetGroup) UpdateTools(fn func(server.ServerTool) server.ServerTool) {
+	for _, toolset := range tg.Toolsets {
+		toolset.UpdateTools(fn)
+	}
+}
+
 func (tg *ToolsetGroup) GetToolset(name string) (*Toolset, error) {
 	toolset, exists := tg.Toolsets[name]
 	if !exists {
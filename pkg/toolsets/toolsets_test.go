@@ -227,6 +227,36 @@ func TestIsEnabledWithEverythingOn(t *testing.T) {
 	}
 }
 
+func TestEnableEverythingSkipsOptInToolsets(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+
+	optIn := NewToolset("opt-in", "An opt-in toolset")
+	optIn.OptIn = true
+	tsg.AddToolset(optIn)
+	tsg.AddToolset(NewToolset("regular", "A regular toolset"))
+
+	err := tsg.EnableToolsets([]string{"all"})
+	if err != nil {
+		t.Errorf("Expected no error when enabling 'all', got: %v", err)
+	}
+
+	if !tsg.IsEnabled("regular") {
+		t.Error("Expected regular toolset to be enabled by 'all'")
+	}
+	if tsg.IsEnabled("opt-in") {
+		t.Error("Expected opt-in toolset to stay disabled with 'all'")
+	}
+
+	// Naming the toolset enables it alongside "all"
+	err = tsg.EnableToolsets([]string{"all", "opt-in"})
+	if err != nil {
+		t.Errorf("Expected no error when enabling 'opt-in', got: %v", err)
+	}
+	if !tsg.IsEnabled("opt-in") {
+		t.Error("Expected opt-in toolset to be enabled when named")
+	}
+}
+
 func TestToolsetGroup_GetToolset(t *testing.T) {
 	tsg := NewToolsetGroup(false)
 	toolset := NewToolset("my-toolset", "desc")